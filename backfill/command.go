@@ -0,0 +1,47 @@
+package backfill
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+)
+
+//Usage explains the backfill command
+const Usage = "usage: backfill duplicates [-batch 1000] [-after id]"
+
+//RunCommand runs the backfill command, i.e. `backfill duplicates`, which records the pairs of near duplicates of the
+//quotes in batches, each in its own transaction, and writes a line for each batch to out. An interrupted backfill is
+//resumed with -after and the last id it wrote.
+func RunCommand(repos *repository.Repositories, args []string, out io.Writer) error {
+	if len(args) == 0 || args[0] != "duplicates" {
+		return errors.New(Usage)
+	}
+	flags := flag.NewFlagSet("backfill duplicates", flag.ContinueOnError)
+	flags.SetOutput(out)
+	batch := flags.Int("batch", 1000, "the number of quotes in each batch")
+	after := flags.Int("after", 0, "the id of the last quote that was backfilled")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *batch <= 0 || flags.NArg() > 0 {
+		return errors.New(Usage)
+	}
+
+	lastId := *after
+	for {
+		next, err := repos.Duplicates.RecordPairs(lastId, *batch)
+		if err != nil {
+			return fmt.Errorf("the quotes up to id %d were backfilled: %w", lastId, err)
+		}
+		if next == 0 {
+			break
+		}
+		lastId = next
+		fmt.Fprintf(out, "recorded the near duplicates of the quotes up to id %d\n", lastId)
+	}
+	fmt.Fprintln(out, "the near duplicates of all the quotes are recorded")
+	return nil
+}
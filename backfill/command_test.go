@@ -0,0 +1,45 @@
+package backfill
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+)
+
+func TestRunCommand(t *testing.T) {
+	t.Run("Should backfill the quotes in batches", func(t *testing.T) {
+		fixtures := repository.SeedFixtures()
+		var out bytes.Buffer
+		if err := RunCommand(repository.NewMemoryRepositories(fixtures), []string{"duplicates", "-batch", "5"}, &out); err != nil {
+			t.Fatalf("Expected no error but got %s", err.Error())
+		}
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		batches := (len(fixtures.Quotes) + 4) / 5
+		if len(lines) != batches+1 {
+			t.Fatalf("Expected a line for each of the %d batches and one at the end but got %q", batches, out.String())
+		}
+	})
+
+	t.Run("Should resume after the given id", func(t *testing.T) {
+		fixtures := repository.SeedFixtures()
+		lastId := fixtures.Quotes[len(fixtures.Quotes)-1].Id
+		var out bytes.Buffer
+		args := []string{"duplicates", "-after", fmt.Sprint(lastId)}
+		if err := RunCommand(repository.NewMemoryRepositories(fixtures), args, &out); err != nil {
+			t.Fatalf("Expected no error but got %s", err.Error())
+		}
+		if strings.Contains(out.String(), "up to id") {
+			t.Fatalf("Expected no batches after the last quote %d but got %q", lastId, out.String())
+		}
+	})
+
+	t.Run("Should refuse anything but duplicates", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := RunCommand(repository.NewMemoryRepositories(repository.SeedFixtures()), []string{"quotes"}, &out); err == nil {
+			t.Fatalf("Expected an error for an unknown backfill")
+		}
+	})
+}
@@ -11,8 +11,16 @@ import (
 const InternalServerError = "Internal Server error when fetching the data. Sorry for the inconveniance and try again later."
 
 var REQUESTS_PER_HOUR = map[string]float64{"free": 100, "basic": 1000, "lilleBoy": 100000, "GOD": math.Inf(1)}
+
+//UNCOUNTED_REQUESTS_PER_MINUTE is how many of the requests that do not count against the hourly quota, e.g. autocomplete,
+//an api-key of each tier may make per minute
+var UNCOUNTED_REQUESTS_PER_MINUTE = map[string]float64{"free": 60, "basic": 300, "lilleBoy": 3000, "GOD": math.Inf(1)}
+
 var TIERS = []string{"free", "basic", "lilleBoy", "GOD"}
 
+//EXPORT_TIER is the lowest tier that may export the whole corpus
+const EXPORT_TIER = "lilleBoy"
+
 const DATABASE_URL = "DATABASE_URL"
 const AUTHORS_TABLE = "AUTHORS_TABLE"
 const QUOTES_TABLE = "QUOTES_TABLE"
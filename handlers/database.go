@@ -8,7 +8,8 @@ import (
 
 var Db *gorm.DB
 
-func init() {
+//ConnectDatabase opens the connection to the Postgres database at DATABASE_URL and stores it in Db
+func ConnectDatabase() error {
 
 	var err error
 
@@ -16,11 +17,6 @@ func init() {
 	Db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
-	if err != nil {
 
-		panic("failed to connect database")
-
-	}
-
-	// defer db.Close()
+	return err
 }
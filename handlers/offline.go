@@ -1,6 +1,9 @@
 package handlers
 
-import "github.com/Skjaldbaka17/quotes-api/structs"
+import (
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
 
 //Functions below are for "offline" updating Database
 //Points incremented for appearing in search list
@@ -10,28 +13,28 @@ const incrementAppearInSearchList = 1
 const incrementIdFetch = 10
 
 //DirectFetchAuthorsCountIncrement increments the popularity count of the Authors from a id-query
-func DirectFetchAuthorsCountIncrement(authorIds []int) error {
+func DirectFetchAuthorsCountIncrement(repos *repository.Repositories, authorIds []int) error {
 	if len(authorIds) == 0 {
 		return nil
 	}
-	return Db.Exec("UPDATE authors SET count = count + ? where id in (?) returning *", incrementIdFetch, authorIds).Error
+	return repos.Authors.IncrementCount(authorIds, incrementIdFetch)
 }
 
 //DirectFetchQuotesCountIncrement increments the popularity count of the Quotes from a id-query
-func DirectFetchQuotesCountIncrement(quoteIds []int) error {
+func DirectFetchQuotesCountIncrement(repos *repository.Repositories, quoteIds []int) error {
 	if len(quoteIds) == 0 {
 		return nil
 	}
-	return Db.Exec("UPDATE quotes SET count = count + ? where id in (?) returning *", incrementIdFetch, quoteIds).Error
+	return repos.Quotes.IncrementCount(quoteIds, incrementIdFetch)
 }
 
 //DirectFetchTopicCountIncrement increments the popularity count of the Topic from a id- or name-query
-func DirectFetchTopicCountIncrement(topicId int, topicName string) error {
-	return Db.Exec("UPDATE topics SET count = count + ? where id = ? or lower(name) = lower(?) returning *", incrementIdFetch, topicId, topicName).Error
+func DirectFetchTopicCountIncrement(repos *repository.Repositories, topicId int, topicName string) error {
+	return repos.Topics.IncrementCount(topicId, topicName, incrementIdFetch)
 }
 
 //AuthorsAppearInSearchCountIncrement increments the popularity count of the Authors from a listing in a search
-func AuthorsAppearInSearchCountIncrement(authors []structs.AuthorDBModel) error {
+func AuthorsAppearInSearchCountIncrement(repos *repository.Repositories, authors []structs.AuthorDBModel) error {
 	if len(authors) == 0 {
 		return nil
 	}
@@ -41,11 +44,11 @@ func AuthorsAppearInSearchCountIncrement(authors []structs.AuthorDBModel) error
 		authorIds = append(authorIds, author.Id)
 	}
 
-	return Db.Exec("UPDATE authors SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, authorIds).Error
+	return repos.Authors.IncrementCount(authorIds, incrementAppearInSearchList)
 }
 
 //QuotesAppearInSearchCountIncrement increments the popularity count of the Quotes from a listing in a search
-func QuotesAppearInSearchCountIncrement(quotes []structs.SearchViewDBModel) error {
+func QuotesAppearInSearchCountIncrement(repos *repository.Repositories, quotes []structs.SearchViewDBModel) error {
 	if len(quotes) == 0 {
 		return nil
 	}
@@ -55,11 +58,11 @@ func QuotesAppearInSearchCountIncrement(quotes []structs.SearchViewDBModel) erro
 		quoteIds = append(quoteIds, quote.QuoteId)
 	}
 
-	return Db.Exec("UPDATE quotes SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, quoteIds).Error
+	return repos.Quotes.IncrementCount(quoteIds, incrementAppearInSearchList)
 }
 
 //AppearInSearchCountIncrement increments the popularity count of the Authors and quotes from a listing in a search
-func TopicViewAppearInSearchCountIncrement(quotes []structs.TopicViewDBModel) error {
+func TopicViewAppearInSearchCountIncrement(repos *repository.Repositories, quotes []structs.TopicViewDBModel) error {
 	if len(quotes) == 0 {
 		return nil
 	}
@@ -70,16 +73,16 @@ func TopicViewAppearInSearchCountIncrement(quotes []structs.TopicViewDBModel) er
 		quoteIds = append(quoteIds, quote.QuoteId)
 	}
 
-	err := Db.Exec("UPDATE authors SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, authorIds).Error
+	err := repos.Authors.IncrementCount(authorIds, incrementAppearInSearchList)
 	if err != nil {
 		return err
 	}
-	err = Db.Exec("UPDATE quotes SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, quoteIds).Error
+	err = repos.Quotes.IncrementCount(quoteIds, incrementAppearInSearchList)
 	return err
 }
 
 //AppearInSearchCountIncrement increments the popularity count of the Authors and quotes from a listing in a search
-func SearchViewAppearInSearchCountIncrement(quotes []structs.SearchViewDBModel) error {
+func SearchViewAppearInSearchCountIncrement(repos *repository.Repositories, quotes []structs.SearchViewDBModel) error {
 	if len(quotes) == 0 {
 		return nil
 	}
@@ -90,10 +93,10 @@ func SearchViewAppearInSearchCountIncrement(quotes []structs.SearchViewDBModel)
 		quoteIds = append(quoteIds, quote.QuoteId)
 	}
 
-	err := Db.Exec("UPDATE authors SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, authorIds).Error
+	err := repos.Authors.IncrementCount(authorIds, incrementAppearInSearchList)
 	if err != nil {
 		return err
 	}
-	err = Db.Exec("UPDATE quotes SET count = count + ? where id in (?) returning *", incrementAppearInSearchList, quoteIds).Error
+	err = repos.Quotes.IncrementCount(quoteIds, incrementAppearInSearchList)
 	return err
 }
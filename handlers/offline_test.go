@@ -3,26 +3,30 @@ package handlers
 import (
 	"testing"
 
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
 )
 
 func TestIncrementCount(t *testing.T) {
+	repos := repository.NewMemoryRepositories(repository.SeedFixtures())
 	t.Run("Should Increment Authors count from direct fetch by ids", func(t *testing.T) {
 		authorIds := Set{1, 2, 3}
-		err := DirectFetchAuthorsCountIncrement(authorIds)
+		before, _ := repos.Authors.GetByIds(authorIds)
+		err := DirectFetchAuthorsCountIncrement(repos, authorIds)
 
 		if err != nil {
 			t.Fatalf("Expected no error but got %s", err.Error())
 		}
-		var authors []structs.TestApiResponse
-		err = Db.Table("authors").Where("id in ?", authorIds).Find(&authors).Error
+		authors, err := repos.Authors.GetByIds(authorIds)
 
 		if err != nil {
 			t.Fatalf("Expected no error but got %s", err.Error())
 		}
 
-		if authors[0].Count == 0 || authors[1].Count == 0 || authors[2].Count == 0 {
-			t.Fatalf("Expected count of authors given to increase to above 0 but got count 0 for author: %+v", authors[0])
+		for idx, author := range authors {
+			if author.Count <= before[idx].Count {
+				t.Fatalf("Expected count of authors given to increase above %d but got count %d for author: %+v", before[idx].Count, author.Count, author)
+			}
 		}
 	})
 
@@ -30,23 +34,23 @@ func TestIncrementCount(t *testing.T) {
 
 		quotes := []structs.TopicViewDBModel{
 			{
-				AuthorId: 100,
-				QuoteId:  100,
+				AuthorId: 10,
+				QuoteId:  19,
 			},
 		}
-		err := TopicViewAppearInSearchCountIncrement(quotes)
+		before, _ := repos.Authors.GetByIds([]int{quotes[0].AuthorId})
+		err := TopicViewAppearInSearchCountIncrement(repos, quotes)
 
 		if err != nil {
 			t.Fatalf("Expected no error but got %s", err.Error())
 		}
 
-		var authors []structs.AuthorDBModel
-		err = Db.Table("authors").Where("id = ?", quotes[0].AuthorId).Find(&authors).Error
+		authors, err := repos.Authors.GetByIds([]int{quotes[0].AuthorId})
 		if err != nil {
 			t.Fatalf("Expected no error but got %s", err.Error())
 		}
-		if authors[0].Count == 0 {
-			t.Fatalf("Expected count of authors given to increase to above 0 but got count 0 for author: %+v", authors[0])
+		if authors[0].Count <= before[0].Count {
+			t.Fatalf("Expected count of authors given to increase above %d but got count %d for author: %+v", before[0].Count, authors[0].Count, authors[0])
 		}
 
 	})
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+//rateLimitWindow is how long the windows the uncounted requests are limited in are
+const rateLimitWindow = time.Minute
+
+//maxRateLimitWindows is how many windows the limiter holds before it drops the ones that have ended
+const maxRateLimitWindows = 10000
+
+//rateLimiter limits the requests of each api key to a number per window. The windows are fixed, start at the first
+//request of the key and are kept in the memory of this instance only
+type rateLimiter struct {
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+	now     func() time.Time
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+//uncountedRequests limits the requests that do not count against the hourly quota, see GetUncountedRequestBody
+var uncountedRequests = newRateLimiter(time.Now)
+
+func newRateLimiter(now func() time.Time) *rateLimiter {
+	return &rateLimiter{windows: map[string]*rateWindow{}, now: now}
+}
+
+//allow records a request of the api key and returns whether it is within the limit of the key's window, otherwise
+//also how long until the window ends
+func (limiter *rateLimiter) allow(apiKey string, limit float64) (bool, time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	window, ok := limiter.windows[apiKey]
+	if !ok || now.Sub(window.start) >= rateLimitWindow {
+		if len(limiter.windows) >= maxRateLimitWindows {
+			limiter.dropEnded(now)
+		}
+		window = &rateWindow{start: now}
+		limiter.windows[apiKey] = window
+	}
+	if float64(window.count) >= limit {
+		return false, window.start.Add(rateLimitWindow).Sub(now)
+	}
+	window.count++
+	return true, 0
+}
+
+func (limiter *rateLimiter) dropEnded(now time.Time) {
+	for apiKey, window := range limiter.windows {
+		if now.Sub(window.start) >= rateLimitWindow {
+			delete(limiter.windows, apiKey)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(func() time.Time { return now })
+
+	t.Run("Should refuse the requests above the limit until the window ends", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if ok, _ := limiter.allow("key", 3); !ok {
+				t.Fatalf("Expected request %d to be allowed", i+1)
+			}
+		}
+		now = now.Add(20 * time.Second)
+		ok, retryAfter := limiter.allow("key", 3)
+		if ok || retryAfter != 40*time.Second {
+			t.Fatalf("Expected the 4th request to be refused for 40s but got %v and %s", ok, retryAfter)
+		}
+		if ok, _ := limiter.allow("other key", 3); !ok {
+			t.Fatalf("Expected the requests of another key to be allowed")
+		}
+
+		now = now.Add(40 * time.Second)
+		if ok, _ := limiter.allow("key", 3); !ok {
+			t.Fatalf("Expected a request in the next window to be allowed")
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+const VIEW_REFRESH_INTERVAL = "VIEW_REFRESH_INTERVAL"
+const VIEW_REFRESH_DELAY = "VIEW_REFRESH_DELAY"
+
+//Defaults for how often the materialized views are refreshed and how long after a write
+const defaultViewRefreshInterval = 15 * time.Minute
+const defaultViewRefreshDelay = time.Minute
+
+//ViewRefresher refreshes the materialized views (searchview and topicsview) in the background, both on a schedule
+//and a short delay after writes, so that new quotes and popularity counts show up in search and random results. The
+//word lists of the spelling suggestions (the LexemeViews) are only refreshed on the schedule.
+type ViewRefresher struct {
+	views repository.ViewRepository
+
+	//refreshMu makes sure only one refresh runs at a time
+	refreshMu sync.Mutex
+	staleMu   sync.Mutex
+	stale     bool
+	writes    chan struct{}
+	done      chan struct{}
+}
+
+//NewViewRefresher returns a refresher for the given views, call Start to run it in the background
+func NewViewRefresher(views repository.ViewRepository) *ViewRefresher {
+	return &ViewRefresher{views: views, writes: make(chan struct{}, 1)}
+}
+
+//ViewRefreshSchedule reads VIEW_REFRESH_INTERVAL and VIEW_REFRESH_DELAY (e.g. "15m" and "1m") from the environment
+func ViewRefreshSchedule() (interval time.Duration, delay time.Duration) {
+	interval, delay = defaultViewRefreshInterval, defaultViewRefreshDelay
+	if parsed, err := time.ParseDuration(GetEnvVariable(VIEW_REFRESH_INTERVAL)); err == nil && parsed > 0 {
+		interval = parsed
+	}
+	if parsed, err := time.ParseDuration(GetEnvVariable(VIEW_REFRESH_DELAY)); err == nil && parsed >= 0 {
+		delay = parsed
+	}
+	return interval, delay
+}
+
+//Start refreshes all the views every interval, and the MaterializedViews delay after writes, until Stop is called
+func (refresher *ViewRefresher) Start(interval time.Duration, delay time.Duration) {
+	refresher.done = make(chan struct{})
+	go refresher.run(interval, delay, refresher.done)
+}
+
+//Stop stops the background refreshing
+func (refresher *ViewRefresher) Stop() {
+	if refresher.done != nil {
+		close(refresher.done)
+		refresher.done = nil
+	}
+}
+
+func (refresher *ViewRefresher) run(interval time.Duration, delay time.Duration, done chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var afterWrites <-chan time.Time
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			refresher.RefreshAll()
+		case <-refresher.writes:
+			//Wait a little so that the writes during the delay are refreshed together
+			if afterWrites == nil {
+				afterWrites = time.After(delay)
+			}
+		case <-afterWrites:
+			afterWrites = nil
+			if refresher.isStale() {
+				refresher.Refresh(repository.MaterializedViews...)
+			}
+		}
+	}
+}
+
+//MarkStale tells the refresher that the data behind the views has changed, it never blocks
+func (refresher *ViewRefresher) MarkStale() {
+	refresher.setStale(true)
+	select {
+	case refresher.writes <- struct{}{}:
+	default:
+	}
+}
+
+//RefreshAll refreshes all the materialized views, i.e. also the LexemeViews
+func (refresher *ViewRefresher) RefreshAll() error {
+	return refresher.Refresh(repository.AllViews()...)
+}
+
+//Refresh refreshes the given views one after the other and returns the first error. If any of the views is unknown it
+//returns repository.ErrNotFound without refreshing any of them.
+func (refresher *ViewRefresher) Refresh(views ...string) error {
+	for _, view := range views {
+		if !repository.IsMaterializedView(strings.ToLower(view)) {
+			return repository.ErrNotFound
+		}
+	}
+
+	refresher.refreshMu.Lock()
+	defer refresher.refreshMu.Unlock()
+
+	//Writes that happen while refreshing mark the views stale again
+	refresher.setStale(false)
+	var firstErr error
+	for _, view := range views {
+		if err := refresher.views.Refresh(strings.ToLower(view)); err != nil {
+			log.Printf("Got error when refreshing the materialized view %s: %s", view, err)
+			refresher.setStale(true)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+//Status returns when each of the views was last refreshed
+func (refresher *ViewRefresher) Status() ([]structs.ViewRefreshAPIModel, error) {
+	refreshes, err := refresher.views.LastRefreshed()
+	if err != nil {
+		return nil, err
+	}
+	stale := refresher.isStale()
+	statuses := []structs.ViewRefreshAPIModel{}
+	for _, refresh := range refreshes {
+		status := refresh.ConvertToAPIModel()
+		status.Stale = stale || refresh.RefreshedAt == nil
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (refresher *ViewRefresher) setStale(stale bool) {
+	refresher.staleMu.Lock()
+	defer refresher.staleMu.Unlock()
+	refresher.stale = stale
+}
+
+func (refresher *ViewRefresher) isStale() bool {
+	refresher.staleMu.Lock()
+	defer refresher.staleMu.Unlock()
+	return refresher.stale
+}
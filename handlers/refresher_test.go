@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+)
+
+func TestViewRefresher(t *testing.T) {
+	t.Run("Should report the views as stale until they are refreshed", func(t *testing.T) {
+		refresher := NewViewRefresher(repository.NewMemoryRepositories(repository.SeedFixtures()).Views)
+		statuses, err := refresher.Status()
+		if err != nil {
+			t.Fatalf("Expected no error but got %s", err.Error())
+		}
+		if len(statuses) != len(repository.MaterializedViews) {
+			t.Fatalf("Expected the status of %d views but got %+v", len(repository.MaterializedViews), statuses)
+		}
+		for _, status := range statuses {
+			if !status.Stale || status.LastRefreshedAt != nil {
+				t.Fatalf("Expected %s never to have been refreshed but got %+v", status.ViewName, status)
+			}
+		}
+
+		if err := refresher.RefreshAll(); err != nil {
+			t.Fatalf("Expected no error but got %s", err.Error())
+		}
+		statuses, _ = refresher.Status()
+		for _, status := range statuses {
+			if status.Stale || status.LastRefreshedAt == nil {
+				t.Fatalf("Expected %s to have been refreshed but got %+v", status.ViewName, status)
+			}
+		}
+	})
+
+	t.Run("Should refuse to refresh an unknown view", func(t *testing.T) {
+		refresher := NewViewRefresher(repository.NewMemoryRepositories(repository.SeedFixtures()).Views)
+		if err := refresher.Refresh("authors"); err == nil {
+			t.Fatalf("Expected an error when refreshing the authors table")
+		}
+		if err := refresher.Refresh(repository.SearchView, "authors"); err == nil {
+			t.Fatalf("Expected an error when refreshing the authors table")
+		}
+		statuses, _ := refresher.Status()
+		for _, status := range statuses {
+			if status.LastRefreshedAt != nil {
+				t.Fatalf("Expected none of the views to have been refreshed but got %+v", status)
+			}
+		}
+	})
+
+	t.Run("Should refresh the views shortly after a write", func(t *testing.T) {
+		refresher := NewViewRefresher(repository.NewMemoryRepositories(repository.SeedFixtures()).Views)
+		refresher.Start(time.Hour, 10*time.Millisecond)
+		defer refresher.Stop()
+
+		refresher.MarkStale()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			statuses, _ := refresher.Status()
+			if !statuses[0].Stale && statuses[0].LastRefreshedAt != nil {
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("Expected the views to be refreshed after the write")
+	})
+}
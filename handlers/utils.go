@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
+	"net"
 	"net/http"
-	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
 )
 
@@ -19,6 +23,18 @@ const maxPageSize = 200
 const maxQuotes = 50
 const defaultMaxQuotes = 1
 
+//The defaults and limits of the highlighting of search results, the same defaults as ts_headline
+const defaultHighlightStart = "<b>"
+const defaultHighlightStop = "</b>"
+const defaultHighlightMaxWords = 35
+const maxHighlightWords = 100
+const maxHighlightFragments = 10
+const maxHighlightMarkerLength = 32
+
+//The default and maximum number of topics and authors counted in the facets of a search
+const defaultFacetLimit = 10
+const maxFacetLimit = 50
+
 //returns error and the body as a string
 func getBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) (error, string) {
 	buf, _ := ioutil.ReadAll(r.Body)
@@ -37,9 +53,9 @@ func getBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Reque
 	return nil, string(buf)
 }
 
-// ValidateRequestApiKey checks if the ApiKey supplied exists and wether the user has finished his allowed request in the past
+// ValidateRequestApiKey checks if the ApiKey supplied exists and, if counted, wether the user has finished his allowed request in the past
 // hour. Also adds to the requestHistory... Maybe move that to the end of a request?
-func validateRequestApiKey(rw http.ResponseWriter, r *http.Request) error {
+func validateRequestApiKey(rw http.ResponseWriter, r *http.Request, repos *repository.Repositories, counted bool) error {
 	var requestBody structs.Request
 	err, bodyAsString := getBody(rw, r, &requestBody)
 	if err != nil {
@@ -54,13 +70,10 @@ func validateRequestApiKey(rw http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	var user structs.UserDBModel
-
-	err = Db.Table("users").Where("api_key = ?", requestBody.ApiKey).First(&user).Error
+	user, err := repos.Users.GetByApiKey(requestBody.ApiKey)
 	// Err==nil if user with given api_key does not exist or internal server error
 	if err != nil {
-		m1 := regexp.MustCompile(`record not found`)
-		if m1.Match([]byte(err.Error())) {
+		if errors.Is(err, repository.ErrNotFound) {
 			log.Printf("the api-key that the requester supplied does not exist")
 			err := errors.New("you need a valid ApiKey to access this resource. Create a user and get a free-tier ApiKey here: https://www.example.com")
 			rw.WriteHeader(http.StatusForbidden)
@@ -72,24 +85,29 @@ func validateRequestApiKey(rw http.ResponseWriter, r *http.Request) error {
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: InternalServerError})
 		return err
 	}
+	if !counted {
+		if ok, retryAfter := uncountedRequests.allow(user.ApiKey, UNCOUNTED_REQUESTS_PER_MINUTE[user.Tier]); !ok {
+			err := fmt.Errorf(
+				"you have used all the requests per minute to this resource that your tier %s allows for, i.e. %.0f requests per minute. Try again in %d seconds", user.Tier, UNCOUNTED_REQUESTS_PER_MINUTE[user.Tier], int(math.Ceil(retryAfter.Seconds())))
+			rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			rw.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusTooManyRequests})
+			return err
+		}
+		return nil
+	}
 
 	//Check if requests from this api-key the past hour are less than allowed for the users-tier (i.e. if this next request is
 	// allowed then save the request to request-history)
-	type countStruct struct {
-		Count int `json:"count"`
-	}
-	var count countStruct
-	if err := Db.Table("requesthistory").Select("count(*)").
-		Where("created_at >= (NOW() - INTERVAL '1 hour')").
-		Where("user_id = ?", user.Id).
-		First(&count).Error; err != nil {
+	count, err := repos.RequestHistory.CountLastHour(user.Id)
+	if err != nil {
 		log.Printf("error when counting request history: %s", err)
 		rw.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: InternalServerError})
 		return err
 	}
 
-	if float64(count.Count) >= REQUESTS_PER_HOUR[user.Tier] {
+	if float64(count) >= REQUESTS_PER_HOUR[user.Tier] {
 		err := fmt.Errorf(
 			"you have used all the requests per hour that your tier %s allows for, i.e. %f request per hour. See https://www.example.com for more info and pricing plans to upgrade your tier if necessary", user.Tier, REQUESTS_PER_HOUR[user.Tier])
 		rw.WriteHeader(http.StatusUnauthorized)
@@ -104,9 +122,8 @@ func validateRequestApiKey(rw http.ResponseWriter, r *http.Request) error {
 		Route:       r.URL.String(),
 		ApiKey:      user.ApiKey,
 	}
-	result := Db.Table("requesthistory").Create(&requestEvent)
-	if result.Error != nil {
-		log.Printf("error when inserting into requestHistory: %s", result.Error)
+	if err := repos.RequestHistory.Create(&requestEvent); err != nil {
+		log.Printf("error when inserting into requestHistory: %s", err)
 		rw.WriteHeader(http.StatusInternalServerError)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: InternalServerError})
 		return err
@@ -118,10 +135,53 @@ func validateRequestApiKey(rw http.ResponseWriter, r *http.Request) error {
 //ValidateRequestBody takes in the request and validates all the input fields, returns an error with reason for validation-failure
 //if validation fails.
 //TODO: Make validation better! i.e. make it "real"
-func GetRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) error {
-	if err := validateRequestApiKey(rw, r); err != nil {
+func GetRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, repos *repository.Repositories) error {
+	return getRequestBody(rw, r, requestBody, repos, true)
+}
+
+//GetUncountedRequestBody is GetRequestBody for the lightweight routes that are called on every keystroke, e.g. autocomplete,
+//the apiKey must be valid but the request neither counts against the user's hourly quota nor is saved to the request history.
+//Instead each apiKey may make UNCOUNTED_REQUESTS_PER_MINUTE of them per minute
+func GetUncountedRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, repos *repository.Repositories) error {
+	return getRequestBody(rw, r, requestBody, repos, false)
+}
+
+//GetPublicRequestBody is GetRequestBody for the routes anyone may call, e.g. for a public collection by its slug. A request
+//with an apiKey is validated and counted the same way, one without is neither but each address may only make
+//UNCOUNTED_REQUESTS_PER_MINUTE of the free tier of them per minute
+func GetPublicRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, repos *repository.Repositories) error {
+	var keyed structs.Request
+	if err, _ := getBody(rw, r, &keyed); err != nil {
+		return err
+	}
+	if keyed.ApiKey != "" {
+		return getRequestBody(rw, r, requestBody, repos, true)
+	}
+
+	address, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		address = r.RemoteAddr
+	}
+	if ok, retryAfter := uncountedRequests.allow("address "+address, UNCOUNTED_REQUESTS_PER_MINUTE["free"]); !ok {
+		err := fmt.Errorf(
+			"you have used all the requests per minute to this resource that are allowed without an apiKey, i.e. %.0f requests per minute. Try again in %d seconds", UNCOUNTED_REQUESTS_PER_MINUTE["free"], int(math.Ceil(retryAfter.Seconds())))
+		rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		rw.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusTooManyRequests})
 		return err
 	}
+	return readRequestBody(rw, r, requestBody)
+}
+
+func getRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, repos *repository.Repositories, counted bool) error {
+	if err := validateRequestApiKey(rw, r, repos, counted); err != nil {
+		return err
+	}
+	return readRequestBody(rw, r, requestBody)
+}
+
+//readRequestBody decodes the request body and sets the defaults of the fields that are left out
+func readRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) error {
 	if err, _ := getBody(rw, r, requestBody); err != nil {
 		return err
 	}
@@ -142,6 +202,26 @@ func GetRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *struct
 		requestBody.MaxQuotes = defaultMaxQuotes
 	}
 
+	if requestBody.Highlight != nil {
+		if err := setHighlightDefaults(requestBody.Highlight); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+			return err
+		}
+	}
+
+	if requestBody.Facets != nil {
+		if requestBody.Facets.Limit == 0 {
+			requestBody.Facets.Limit = defaultFacetLimit
+		}
+		if requestBody.Facets.Limit < 1 || requestBody.Facets.Limit > maxFacetLimit {
+			err := fmt.Errorf("the limit of the facets should be from 1 to %d", maxFacetLimit)
+			rw.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+			return err
+		}
+	}
+
 	const layout = "2006-01-02"
 	//Set date into correct format, if supplied, otherwise input today's date in the correct format for all qods
 	if len(requestBody.Qods) != 0 {
@@ -214,6 +294,32 @@ func GetRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *struct
 	return nil
 }
 
+//setHighlightDefaults fills in the defaults of the highlight config and validates it. The markers end up inside the
+//options of ts_headline, where a double quote would end the value, so they may not contain one
+func setHighlightDefaults(config *structs.HighlightConfig) error {
+	if config.Start == "" {
+		config.Start = defaultHighlightStart
+	}
+	if config.Stop == "" {
+		config.Stop = defaultHighlightStop
+	}
+	if config.MaxWords == 0 {
+		config.MaxWords = defaultHighlightMaxWords
+	}
+	for _, marker := range []string{config.Start, config.Stop} {
+		if len([]rune(marker)) > maxHighlightMarkerLength || strings.ContainsAny(marker, "\"\\\n\r\t") {
+			return fmt.Errorf("the highlight markers can be at most %d characters and can not contain quotes, backslashes or line breaks", maxHighlightMarkerLength)
+		}
+	}
+	if config.MaxWords < 2 || config.MaxWords > maxHighlightWords {
+		return fmt.Errorf("the maxWords of the highlight should be from 2 to %d", maxHighlightWords)
+	}
+	if config.MaxFragments < 0 || config.MaxFragments > maxHighlightFragments {
+		return fmt.Errorf("the maxFragments of the highlight should be from 0 to %d", maxHighlightFragments)
+	}
+	return nil
+}
+
 //ValidateUserRequestBody takes in the request and validates all the input fields, returns an error with reason for validation-failure
 //if validation fails.
 //TODO: Make validation better! i.e. make it "real"
@@ -285,21 +391,21 @@ func ValidateUserInformation(rw http.ResponseWriter, r *http.Request, requestBod
 }
 
 // Check whether user has GOD-tier permissions
-func AuthorizeGODApiKey(rw http.ResponseWriter, r *http.Request) error {
+func AuthorizeGODApiKey(rw http.ResponseWriter, r *http.Request, repos *repository.Repositories) error {
 	var requestBody structs.Request
 	if err, _ := getBody(rw, r, &requestBody); err != nil {
 		return err
 	}
 
-	var user structs.UserDBModel
-	if err := Db.Table("users").Where("api_key = ?", requestBody.ApiKey).First(&user).Error; err != nil {
+	user, err := repos.Users.GetByApiKey(requestBody.ApiKey)
+	if err != nil {
 		log.Printf("error when searching for user with the given api key in AuthorIzeGOD (api key validation): %s", err)
 		rw.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "You need special privileges to access this route."})
 		return err
 	}
 
-	if user.Tier != TIERS[len(TIERS)-1] {
+	if !IsGOD(user.Tier) {
 		err := errors.New("you do not have the authorization to perform this action. Is your name Bassi Maraj? This is not meant for you... Sorry for the inconvenience")
 		rw.WriteHeader(http.StatusUnauthorized)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error()})
@@ -308,3 +414,43 @@ func AuthorizeGODApiKey(rw http.ResponseWriter, r *http.Request) error {
 
 	return nil
 }
+
+// AuthorizeTier checks whether the user's tier is at least the given tier, see TIERS
+func AuthorizeTier(rw http.ResponseWriter, r *http.Request, repos *repository.Repositories, minimumTier string) error {
+	var requestBody structs.Request
+	if err, _ := getBody(rw, r, &requestBody); err != nil {
+		return err
+	}
+
+	user, err := repos.Users.GetByApiKey(requestBody.ApiKey)
+	if err != nil {
+		log.Printf("error when searching for user with the given api key in AuthorizeTier (api key validation): %s", err)
+		rw.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "You need special privileges to access this route."})
+		return err
+	}
+
+	if tierRank(user.Tier) < tierRank(minimumTier) {
+		err := fmt.Errorf("your tier %s does not give access to this resource, you need at least the %s tier. See https://www.example.com for more info and pricing plans to upgrade your tier", user.Tier, minimumTier)
+		rw.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error()})
+		return err
+	}
+
+	return nil
+}
+
+//IsGOD returns whether the tier is the highest one, see TIERS
+func IsGOD(tier string) bool {
+	return tier == TIERS[len(TIERS)-1]
+}
+
+//tierRank returns the position of the tier in TIERS, -1 for unknown tiers
+func tierRank(tier string) int {
+	for idx, t := range TIERS {
+		if t == tier {
+			return idx
+		}
+	}
+	return -1
+}
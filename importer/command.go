@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+)
+
+//Usage explains the import command
+const Usage = "usage: import [-format csv|jsonl] file..."
+
+//RunCommand runs the import command, i.e. `import [-format csv|jsonl] file...`, writes a line for each row to out and refreshes
+//the materialized views when something was inserted. The format is taken from the file extension if not given.
+func RunCommand(repos *repository.Repositories, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(out)
+	format := flags.String("format", "", "the format of the files, csv or jsonl")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New(Usage)
+	}
+
+	var total Summary
+	for _, path := range flags.Args() {
+		summary, err := importFile(repos.Quotes, path, *format, out)
+		total.Inserted += summary.Inserted
+		total.Skipped += summary.Skipped
+		total.Rejected += summary.Rejected
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	fmt.Fprintf(out, "inserted %d, skipped %d, rejected %d\n", total.Inserted, total.Skipped, total.Rejected)
+
+	if total.Inserted > 0 {
+		for _, view := range repository.AllViews() {
+			if err := repos.Views.Refresh(view); err != nil {
+				return fmt.Errorf("the rows were imported but refreshing %s failed: %w", view, err)
+			}
+		}
+	}
+	return nil
+}
+
+func importFile(quotes repository.QuoteRepository, path string, format string, out io.Writer) (Summary, error) {
+	if format == "" {
+		format = formatFromExtension(path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return Summary{}, err
+	}
+	defer file.Close()
+
+	return Import(quotes, file, format, func(result Result) {
+		fmt.Fprintf(out, "%s:%d\t%s\t%s\n", path, result.Line, result.Status, result.Message)
+	})
+}
+
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return FormatCSV
+	}
+}
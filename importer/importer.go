@@ -0,0 +1,199 @@
+//Package importer imports quotes, with their authors and topics, from CSV or JSONL files
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//The formats that can be imported
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+//The outcome of importing a single row
+const (
+	Inserted = "inserted"
+	Skipped  = "skipped"
+	Rejected = "rejected"
+)
+
+//topicSeparator separates the topics in the topics column of a CSV file
+const topicSeparator = ";"
+
+//Row is one quote in an import file. In a CSV file the header names the columns quote, author, language and topics
+//(separated by ";"), in a JSONL file each line is an object with the same keys where topics is a list.
+type Row struct {
+	Quote    string   `json:"quote"`
+	Author   string   `json:"author"`
+	Language string   `json:"language"`
+	Topics   []string `json:"topics"`
+}
+
+//Result is the outcome of importing a row, Line is the row's number in the file (the CSV header is row 1)
+type Result struct {
+	Line    int
+	Status  string
+	QuoteId int
+	Message string
+}
+
+//Summary counts the outcomes of an import
+type Summary struct {
+	Inserted int
+	Skipped  int
+	Rejected int
+}
+
+//Import reads the rows from reader, inserts them one by one and calls report with the outcome of each row
+func Import(quotes repository.QuoteRepository, reader io.Reader, format string, report func(Result)) (Summary, error) {
+	var summary Summary
+	handle := func(line int, row Row, parseErr error) {
+		result := importRow(quotes, line, row, parseErr)
+		switch result.Status {
+		case Inserted:
+			summary.Inserted++
+		case Skipped:
+			summary.Skipped++
+		default:
+			summary.Rejected++
+		}
+		report(result)
+	}
+
+	switch format {
+	case FormatCSV:
+		return summary, readCSV(reader, handle)
+	case FormatJSONL:
+		return summary, readJSONL(reader, handle)
+	}
+	return summary, fmt.Errorf("unknown import format %q, should be %s or %s", format, FormatCSV, FormatJSONL)
+}
+
+func importRow(quotes repository.QuoteRepository, line int, row Row, parseErr error) Result {
+	if parseErr != nil {
+		return Result{Line: line, Status: Rejected, Message: parseErr.Error()}
+	}
+	newQuote, err := row.toInsertModel()
+	if err != nil {
+		return Result{Line: line, Status: Rejected, Message: err.Error()}
+	}
+
+	quote, err := quotes.Insert(newQuote)
+	switch {
+	case errors.Is(err, repository.ErrQuoteExists):
+		return Result{Line: line, Status: Skipped, QuoteId: quote.Id, Message: fmt.Sprintf("the quote already exists with id %d", quote.Id)}
+	case errors.Is(err, repository.ErrAuthorDeleted):
+		return Result{Line: line, Status: Rejected, Message: fmt.Sprintf("the author %s is deleted, restore it to import its quotes", newQuote.Author)}
+	case errors.Is(err, repository.ErrTopicDeleted):
+		return Result{Line: line, Status: Rejected, Message: fmt.Sprintf("one of the topics %s is deleted, restore it to import quotes into it", strings.Join(newQuote.Topics, ", "))}
+	case err != nil:
+		return Result{Line: line, Status: Rejected, Message: err.Error()}
+	}
+	return Result{Line: line, Status: Inserted, QuoteId: quote.Id, Message: fmt.Sprintf("quote %d by %s", quote.Id, newQuote.Author)}
+}
+
+//toInsertModel validates the row
+func (row Row) toInsertModel() (structs.QuoteInsertModel, error) {
+	newQuote := structs.QuoteInsertModel{Quote: strings.TrimSpace(row.Quote), Author: strings.TrimSpace(row.Author)}
+	if newQuote.Quote == "" {
+		return newQuote, errors.New("the quote is empty")
+	}
+	if newQuote.Author == "" {
+		return newQuote, errors.New("the author is empty")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(row.Language)) {
+	case "", "english", "en":
+	case "icelandic", "is":
+		newQuote.IsIcelandic = true
+	default:
+		return newQuote, fmt.Errorf("unknown language %q, should be english or icelandic", row.Language)
+	}
+
+	for _, topic := range row.Topics {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			newQuote.Topics = append(newQuote.Topics, topic)
+		}
+	}
+	return newQuote, nil
+}
+
+//readCSV calls handle with each row of the CSV file, the first line must be the header
+func readCSV(reader io.Reader, handle func(line int, row Row, err error)) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err != nil {
+		return fmt.Errorf("could not read the CSV header: %w", err)
+	}
+
+	//The header is the first row
+	rowNr := 1
+	columns := map[string]int{}
+	for idx, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = idx
+	}
+	for _, required := range []string{"quote", "author"} {
+		if _, ok := columns[required]; !ok {
+			return fmt.Errorf("the CSV header needs a %s column", required)
+		}
+	}
+	column := func(record []string, name string) string {
+		if idx, ok := columns[name]; ok && idx < len(record) {
+			return record[idx]
+		}
+		return ""
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		rowNr++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			handle(rowNr, Row{}, err)
+			continue
+		}
+		row := Row{Quote: column(record, "quote"), Author: column(record, "author"), Language: column(record, "language")}
+		if topics := column(record, "topics"); topics != "" {
+			row.Topics = strings.Split(topics, topicSeparator)
+		}
+		handle(rowNr, row, nil)
+	}
+}
+
+//readJSONL calls handle with each line of the JSONL file, empty lines are ignored
+func readJSONL(reader io.Reader, handle func(line int, row Row, err error)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var row Row
+		if err := json.Unmarshal([]byte(text), &row); err != nil {
+			handle(line, Row{}, fmt.Errorf("the line is not a JSON object with quote, author, language and topics: %s", err))
+			continue
+		}
+		handle(line, row, nil)
+	}
+	return scanner.Err()
+}
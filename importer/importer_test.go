@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+func TestImport(t *testing.T) {
+	t.Run("should insert, skip and reject the rows of a CSV file", func(t *testing.T) {
+		repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+		csvFile := `quote,author,language,topics
+"We are all in the gutter, but some of us are looking at the stars.",Oscar Wilde,english,life;Humor
+"Float like a butterfly, sting like a bee.",Muhammad Ali,english,
+,Nobody,english,
+"Sá einn veit er víða ratar.",Hávamál,klingon,
+"Fall seven times, stand up eight.",Japanese Proverb,,motivational
+`
+		results := []Result{}
+		summary, err := Import(repos.Quotes, strings.NewReader(csvFile), FormatCSV, func(result Result) { results = append(results, result) })
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if summary != (Summary{Inserted: 2, Skipped: 1, Rejected: 2}) {
+			t.Fatalf("got summary %+v, want 2 inserted, 1 skipped and 2 rejected", summary)
+		}
+		expected := []string{Inserted, Skipped, Rejected, Rejected, Inserted}
+		for idx, result := range results {
+			if result.Status != expected[idx] || result.Line != idx+2 {
+				t.Fatalf("got %+v for row %d, want %s", result, idx+2, expected[idx])
+			}
+		}
+		if results[1].QuoteId != 1 {
+			t.Fatalf("got %+v, want the skipped row to point to the existing quote 1", results[1])
+		}
+
+		authors, _ := repos.Authors.GetByIds([]int{11})
+		if len(authors) != 1 || authors[0].NrOfEnglishQuotes != 3 {
+			t.Fatalf("got %+v, want Oscar Wilde to have 3 English quotes", authors)
+		}
+
+		topic, _ := repos.Topics.GetTopic(structs.Request{Topic: "humor", PageSize: 25})
+		if len(topic) != 1 || topic[0].QuoteId != results[0].QuoteId {
+			t.Fatalf("got %+v, want the new topic humor with the new quote", topic)
+		}
+		topic, _ = repos.Topics.GetTopic(structs.Request{Topic: "motivational", PageSize: 25})
+		if len(topic) != 4 || topic[0].Name != "Japanese Proverb" {
+			t.Fatalf("got %+v, want the new quote by the new author in motivational", topic)
+		}
+	})
+
+	t.Run("should import a JSONL file and mark the Icelandic authors", func(t *testing.T) {
+		repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+		jsonl := `{"quote":"Deyr fé, deyja frændr.","author":"Muhammad Ali","language":"icelandic","topics":["Speki"]}
+
+not json
+`
+		results := []Result{}
+		summary, err := Import(repos.Quotes, strings.NewReader(jsonl), FormatJSONL, func(result Result) { results = append(results, result) })
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if summary != (Summary{Inserted: 1, Rejected: 1}) || results[1].Line != 3 {
+			t.Fatalf("got summary %+v and results %+v, want 1 inserted and line 3 rejected", summary, results)
+		}
+		authors, _ := repos.Authors.GetByIds([]int{1})
+		if !authors[0].HasIcelandicQuotes || authors[0].NrOfIcelandicQuotes != 1 {
+			t.Fatalf("got %+v, want Muhammad Ali to have an Icelandic quote", authors[0])
+		}
+	})
+
+	t.Run("should reject the rows of a deleted author or topic", func(t *testing.T) {
+		repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+		repos.Deleted.Delete(repository.AuthorsTable, 6)
+		repos.Deleted.Delete(repository.TopicsTable, 2)
+		jsonl := `{"quote":"Death solves all problems.","author":"Joseph Stalin"}
+{"quote":"Love is a smoke made with the fume of sighs.","author":"William Shakespeare","topics":["Love"]}
+{"quote":"The course of true love never did run smooth.","author":"William Shakespeare","topics":["life"]}
+`
+		results := []Result{}
+		summary, err := Import(repos.Quotes, strings.NewReader(jsonl), FormatJSONL, func(result Result) { results = append(results, result) })
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if summary != (Summary{Inserted: 1, Rejected: 2}) || !strings.Contains(results[0].Message, "Joseph Stalin is deleted") || !strings.Contains(results[1].Message, "Love is deleted") {
+			t.Fatalf("got summary %+v and results %+v, want the rows of the deleted author and topic rejected", summary, results)
+		}
+		authors, _ := repos.Authors.GetByIds([]int{6})
+		if len(authors) != 0 {
+			t.Fatalf("got %+v, want Joseph Stalin to stay deleted", authors)
+		}
+	})
+
+	t.Run("should refuse a CSV file without quote and author columns", func(t *testing.T) {
+		repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+		_, err := Import(repos.Quotes, strings.NewReader("text,name\nHello,World\n"), FormatCSV, func(Result) {})
+		if err == nil {
+			t.Fatalf("expected an error for the missing columns")
+		}
+	})
+
+	t.Run("the command should report each row and refresh the views", func(t *testing.T) {
+		repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+		path := filepath.Join(t.TempDir(), "quotes.jsonl")
+		os.WriteFile(path, []byte(`{"quote":"Simplicity is the ultimate sophistication.","author":"Leonardo da Vinci"}`+"\n"), 0644)
+
+		var out bytes.Buffer
+		if err := RunCommand(repos, []string{path}, &out); err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if !strings.Contains(out.String(), path+":1\tinserted") || !strings.Contains(out.String(), "inserted 1, skipped 0, rejected 0") {
+			t.Fatalf("got output %q", out.String())
+		}
+		refreshes, _ := repos.Views.LastRefreshed()
+		for _, refresh := range refreshes {
+			if refresh.RefreshedAt == nil {
+				t.Fatalf("expected %s to be refreshed after the import", refresh.ViewName)
+			}
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//Usage explains the migrate command
+const Usage = "usage: migrate up|down|status"
+
+//RunCommand runs the migrate command, i.e. `migrate up`, `migrate down` or `migrate status`, and writes the result to out
+func RunCommand(db *gorm.DB, args []string, out io.Writer) error {
+	if len(args) != 1 {
+		return errors.New(Usage)
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			fmt.Fprintf(out, "applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "the database is up to date")
+		}
+	case "down":
+		migration, reverted, err := migrator.Down()
+		if err != nil {
+			return err
+		}
+		if !reverted {
+			fmt.Fprintln(out, "no migration to revert")
+			return nil
+		}
+		fmt.Fprintf(out, "reverted %d_%s\n", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(out, "%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q, %s", args[0], Usage)
+	}
+	return nil
+}
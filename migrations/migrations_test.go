@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	t.Run("should load the embedded migrations in order with both up and down", func(t *testing.T) {
+		migrations, err := Load()
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("expected embedded migrations")
+		}
+		for idx, migration := range migrations {
+			if migration.Version != int64(idx+1) {
+				t.Fatalf("got version %d at index %d, expected versions to be 1, 2, 3...", migration.Version, idx)
+			}
+			if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+				t.Fatalf("migration %d_%s is missing its up or down sql", migration.Version, migration.Name)
+			}
+		}
+	})
+
+	t.Run("should create the extensions, materialized views and indexes", func(t *testing.T) {
+		migrations, _ := Load()
+		all := ""
+		for _, migration := range migrations {
+			all += migration.Up
+		}
+		for _, expected := range []string{"CREATE EXTENSION if not exists pg_trgm", "MATERIALIZED VIEW if not exists searchView", "MATERIALIZED VIEW if not exists topicsView", "words_idx_authors"} {
+			if !strings.Contains(all, expected) {
+				t.Fatalf("expected the migrations to contain %q", expected)
+			}
+		}
+	})
+
+	t.Run("should refuse a migration without a down file", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"sql/0001_first.up.sql":   {Data: []byte("select 1;")},
+			"sql/0001_first.down.sql": {Data: []byte("select 1;")},
+			"sql/0002_second.up.sql":  {Data: []byte("select 2;")},
+		}
+		if _, err := load(fsys, "sql"); err == nil {
+			t.Fatalf("expected an error for the missing down file")
+		}
+	})
+
+	t.Run("should refuse badly named files", func(t *testing.T) {
+		fsys := fstest.MapFS{"sql/first.sql": {Data: []byte("select 1;")}}
+		if _, err := load(fsys, "sql"); err == nil {
+			t.Fatalf("expected an error for the badly named file")
+		}
+	})
+}
@@ -0,0 +1 @@
+DROP EXTENSION if exists pg_trgm;
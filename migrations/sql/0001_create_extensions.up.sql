@@ -0,0 +1 @@
+CREATE EXTENSION if not exists pg_trgm;
@@ -0,0 +1,4 @@
+DROP TABLE if exists topicstoquotes;
+DROP TABLE if exists topics;
+DROP TABLE if exists quotes;
+DROP TABLE if exists authors;
@@ -0,0 +1,45 @@
+CREATE TABLE if not exists authors(
+   id SERIAL PRIMARY KEY,
+   name VARCHAR NOT NULL UNIQUE,
+   count integer default 0,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   deleted_at timestamptz,
+   nr_of_english_quotes integer default 0,
+   nr_of_icelandic_quotes integer default 0,
+   has_icelandic_quotes boolean default false,
+   tsv tsvector
+);
+
+CREATE TABLE if not exists quotes(
+   id SERIAL PRIMARY KEY,
+   author_id integer not null,
+   quote text NOT NULL unique,
+   count integer default 0,
+   is_icelandic boolean default false,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   deleted_at timestamptz,
+   tsv tsvector,
+   FOREIGN KEY (author_id) REFERENCES authors(id) ON DELETE CASCADE
+);
+
+CREATE TABLE if not exists topics(
+   id SERIAL PRIMARY KEY,
+   name VARCHAR NOT NULL UNIQUE,
+   is_icelandic boolean default false,
+   count integer default 0,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   deleted_at timestamptz,
+   tsv tsvector
+);
+
+CREATE TABLE if not exists topicstoquotes(
+   id SERIAL PRIMARY KEY,
+   topic_id int,
+   quote_id int,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   deleted_at timestamptz
+);
@@ -0,0 +1,3 @@
+DROP TABLE if exists errorhistory;
+DROP TABLE if exists requesthistory;
+DROP TABLE if exists users;
@@ -0,0 +1,34 @@
+CREATE TABLE if not exists users(
+   id SERIAL PRIMARY KEY,
+   email varchar not null unique,
+   name VARCHAR NOT NULL,
+   api_key varchar not null unique,
+   password_hash text not null,
+   tier varchar not null default 'free',
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   deleted_at timestamptz
+);
+
+CREATE TABLE if not exists requesthistory (
+    id serial not null,
+    user_id integer not null,
+    api_key varchar not null,
+    route varchar not null,
+    request_body text not null,
+    request text not null,
+    created_at timestamptz default current_timestamp
+);
+
+CREATE TABLE if not exists errorhistory (
+    id serial not null,
+    user_id integer not null,
+    route varchar not null,
+    request_body text not null,
+    error_message text not null,
+    extra_info text,
+    created_at timestamptz default current_timestamp
+);
+
+create INDEX if not exists index_request_history_on_user_id on requesthistory(user_id);
+create INDEX if not exists index_request_history_on_created_at on requesthistory(created_at);
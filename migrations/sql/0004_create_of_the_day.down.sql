@@ -0,0 +1,8 @@
+DROP VIEW if exists aodiceview;
+DROP VIEW if exists aodview;
+DROP VIEW if exists qodiceview;
+DROP VIEW if exists qodview;
+DROP TABLE if exists aodice;
+DROP TABLE if exists aod;
+DROP TABLE if exists qodice;
+DROP TABLE if exists qod;
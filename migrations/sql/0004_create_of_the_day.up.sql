@@ -0,0 +1,72 @@
+CREATE TABLE if not exists qod (
+    id serial not null,
+    quote_id integer not null,
+    date date unique not null default current_date,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz
+);
+
+CREATE TABLE if not exists qodice (
+    id serial not null,
+    quote_id integer not null,
+    date date unique not null default current_date,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz
+);
+
+CREATE TABLE if not exists aod (
+    id serial not null,
+    author_id integer not null,
+    date date unique not null default current_date,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz
+);
+
+CREATE TABLE if not exists aodice (
+    id serial not null,
+    author_id integer not null,
+    date date unique not null default current_date,
+    created_at timestamptz default current_timestamp,
+    updated_at timestamptz
+);
+
+create or replace view qodview as 
+select q.id as quote_id,
+        authors.name as name,
+        q.quote as quote,
+       authors.id as author_id,
+       qod.date as date,
+       q.is_icelandic as is_icelandic
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join qod
+      on q.id = qod.quote_id;
+
+create or replace view qodiceview as 
+select q.id as quote_id,
+        authors.name as name,
+        q.quote as quote,
+       authors.id as author_id,
+       qodice.date as date
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join qodice
+      on q.id = qodice.quote_id;
+
+create or replace view aodview as 
+select a.id as id,
+        a.name as name,
+       aod.date as date
+from authors a
+   inner join aod
+      on aod.author_id = a.id;
+
+create or replace view aodiceview as 
+select a.id as id,
+        a.name as name,
+       aodice.date as date
+from authors a
+   inner join aodice
+      on aodice.author_id = a.id;
@@ -0,0 +1,6 @@
+DROP MATERIALIZED VIEW if exists unique_lexeme_authors;
+DROP MATERIALIZED VIEW if exists unique_lexeme_quotes;
+DROP MATERIALIZED VIEW if exists unique_lexeme;
+DROP VIEW if exists popularityView;
+DROP MATERIALIZED VIEW if exists topicsView;
+DROP MATERIALIZED VIEW if exists searchView;
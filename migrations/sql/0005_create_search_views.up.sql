@@ -0,0 +1,60 @@
+create MATERIALIZED VIEW if not exists searchView as 
+select authors.id as author_id,
+       authors.name,
+       quotes.id as quote_id,
+       quotes.quote as quote,
+       quotes.is_icelandic as is_icelandic,
+       authors.tsv || quotes.tsv  as tsv,
+       authors.tsv as name_tsv,
+       quotes.tsv as quote_tsv,
+       quotes.count as quote_count,
+       authors.count as author_count
+from authors
+   inner join quotes
+      on authors.id = quotes.author_id;
+
+CREATE MATERIALIZED VIEW if not exists topicsView as 
+select authors.id as author_id,
+       authors.name,
+       q.id as quote_id,
+       q.quote as quote,
+       q.is_icelandic as is_icelandic,
+       authors.tsv || q.tsv  as tsv,
+       authors.tsv as name_tsv,
+       q.tsv as quote_tsv,
+       t.name as topic_name,
+       t.id as topic_id
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join topicstoquotes ttq
+      on q.id = ttq.quote_id
+   inner join topics t
+      on t.id = ttq.topic_id;
+
+create or replace VIEW popularityView as 
+select authors.id as author_id,
+       authors.name,
+       quotes.id as quote_id,
+       quotes.quote as quote,
+       quotes.is_icelandic as is_icelandic,
+       quotes.count as quote_count,
+       authors.count as author_count
+from authors
+   inner join quotes
+      on authors.id = quotes.author_id;
+
+CREATE MATERIALIZED VIEW if not exists unique_lexeme AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote) || 
+    to_tsvector(''simple'', authors.name) 
+FROM quotes
+JOIN authors ON authors.id = quotes.author_id
+GROUP BY quotes.id, authors.id');
+
+CREATE MATERIALIZED VIEW if not exists unique_lexeme_quotes AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote)
+FROM quotes');
+
+CREATE MATERIALIZED VIEW if not exists unique_lexeme_authors AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', authors.name)
+FROM authors');
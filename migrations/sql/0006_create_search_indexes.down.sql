@@ -0,0 +1,22 @@
+DROP INDEX if exists words_idx_authors;
+DROP INDEX if exists words_idx_quotes;
+DROP INDEX if exists words_idx;
+
+DROP INDEX if exists index_topics_view_on_quote_id;
+DROP INDEX if exists index_topics_view_on_author_id;
+DROP INDEX if exists index_topics_view_on_tsv;
+DROP INDEX if exists index_topics_view_on_quote_tsv;
+DROP INDEX if exists index_topics_view_on_name_tsv;
+
+DROP INDEX if exists index_search_on_author_count;
+DROP INDEX if exists index_search_on_quote_count;
+DROP INDEX if exists index_search_on_quote_id;
+DROP INDEX if exists index_search_on_author_id;
+DROP INDEX if exists index_search_on_tsv;
+DROP INDEX if exists index_search_on_quote_tsv;
+DROP INDEX if exists index_search_on_name_tsv;
+
+DROP INDEX if exists index_quotes_on_count;
+DROP INDEX if exists index_quotes_on_author_id;
+DROP INDEX if exists index_quotes_on_quote;
+DROP INDEX if exists index_authors_on_name;
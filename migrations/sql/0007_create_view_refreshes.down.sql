@@ -0,0 +1,4 @@
+DROP TABLE if exists view_refreshes;
+DROP INDEX if exists index_topics_view_unique_on_topic_id_quote_id;
+DROP INDEX if exists index_search_unique_on_quote_id;
+ALTER TABLE topicstoquotes DROP CONSTRAINT if exists topicstoquotes_topic_id_quote_id_key;
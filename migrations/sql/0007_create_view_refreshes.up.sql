@@ -0,0 +1,22 @@
+-- A quote is linked to a topic at most once, otherwise topicsView has duplicate rows and can not be refreshed
+-- concurrently. The live link, or else the oldest one, of the duplicates is kept
+DELETE FROM topicstoquotes WHERE id in (
+   SELECT id FROM (
+      SELECT id, row_number() OVER (PARTITION BY topic_id, quote_id ORDER BY deleted_at is not null, id) AS nr
+      FROM topicstoquotes
+   ) AS links
+   WHERE nr > 1
+);
+ALTER TABLE topicstoquotes DROP CONSTRAINT if exists topicstoquotes_topic_id_quote_id_key;
+ALTER TABLE topicstoquotes ADD CONSTRAINT topicstoquotes_topic_id_quote_id_key UNIQUE (topic_id, quote_id);
+
+-- REFRESH MATERIALIZED VIEW CONCURRENTLY needs a unique index on the view
+CREATE UNIQUE INDEX if not exists index_search_unique_on_quote_id ON searchview(quote_id);
+CREATE UNIQUE INDEX if not exists index_topics_view_unique_on_topic_id_quote_id ON topicsView(topic_id, quote_id);
+
+CREATE TABLE if not exists view_refreshes(
+   view_name varchar PRIMARY KEY,
+   refreshed_at timestamptz,
+   duration_ms integer default 0,
+   error text
+);
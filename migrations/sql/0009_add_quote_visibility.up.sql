@@ -0,0 +1,100 @@
+-- Quotes created through the api can be private, i.e. only returned for the user that created them
+ALTER TABLE quotes ADD COLUMN if not exists is_private boolean not null default false;
+ALTER TABLE quotes ADD COLUMN if not exists user_id integer REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX if not exists index_quotes_on_user_id ON quotes(user_id) WHERE is_private;
+
+-- Leave the private quotes out of the views
+DROP MATERIALIZED VIEW if exists searchView;
+DROP MATERIALIZED VIEW if exists topicsView;
+
+create MATERIALIZED VIEW searchView as 
+select authors.id as author_id,
+       authors.name,
+       quotes.id as quote_id,
+       quotes.quote as quote,
+       quotes.is_icelandic as is_icelandic,
+       authors.tsv || quotes.tsv  as tsv,
+       authors.tsv as name_tsv,
+       quotes.tsv as quote_tsv,
+       quotes.count as quote_count,
+       authors.count as author_count
+from authors
+   inner join quotes
+      on authors.id = quotes.author_id
+where authors.deleted_at is null and quotes.deleted_at is null and not quotes.is_private;
+
+CREATE MATERIALIZED VIEW topicsView as 
+select authors.id as author_id,
+       authors.name,
+       q.id as quote_id,
+       q.quote as quote,
+       q.is_icelandic as is_icelandic,
+       authors.tsv || q.tsv  as tsv,
+       authors.tsv as name_tsv,
+       q.tsv as quote_tsv,
+       t.name as topic_name,
+       t.id as topic_id
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join topicstoquotes ttq
+      on q.id = ttq.quote_id
+   inner join topics t
+      on t.id = ttq.topic_id
+where authors.deleted_at is null and q.deleted_at is null and not q.is_private and t.deleted_at is null and ttq.deleted_at is null;
+
+CREATE INDEX if not exists index_search_on_name_tsv ON searchview using gin(name_tsv);
+CREATE INDEX if not exists index_search_on_quote_tsv ON searchview using gin(quote_tsv);
+CREATE INDEX if not exists index_search_on_tsv ON searchview using gin(tsv);
+CREATE INDEX if not exists index_search_on_author_id ON searchview(author_id);
+CREATE INDEX if not exists index_search_on_quote_id ON searchview(quote_id);
+CREATE INDEX if not exists index_search_on_quote_count ON searchview(quote_count);
+CREATE INDEX if not exists index_search_on_author_count ON searchview(author_count);
+CREATE UNIQUE INDEX if not exists index_search_unique_on_quote_id ON searchview(quote_id);
+
+CREATE INDEX if not exists index_topics_view_on_name_tsv ON topicsView using gin(name_tsv);
+CREATE INDEX if not exists index_topics_view_on_quote_tsv ON topicsView using gin(quote_tsv);
+CREATE INDEX if not exists index_topics_view_on_tsv ON topicsView using gin(tsv);
+CREATE INDEX if not exists index_topics_view_on_author_id ON topicsView(author_id);
+CREATE INDEX if not exists index_topics_view_on_quote_id ON topicsView(quote_id);
+CREATE UNIQUE INDEX if not exists index_topics_view_unique_on_topic_id_quote_id ON topicsView(topic_id, quote_id);
+
+create or replace VIEW popularityView as 
+select authors.id as author_id,
+       authors.name,
+       quotes.id as quote_id,
+       quotes.quote as quote,
+       quotes.is_icelandic as is_icelandic,
+       quotes.count as quote_count,
+       authors.count as author_count
+from authors
+   inner join quotes
+      on authors.id = quotes.author_id
+where authors.deleted_at is null and quotes.deleted_at is null and not quotes.is_private;
+
+create or replace view qodview as 
+select q.id as quote_id,
+        authors.name as name,
+        q.quote as quote,
+       authors.id as author_id,
+       qod.date as date,
+       q.is_icelandic as is_icelandic
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join qod
+      on q.id = qod.quote_id
+where authors.deleted_at is null and q.deleted_at is null and not q.is_private;
+
+create or replace view qodiceview as 
+select q.id as quote_id,
+        authors.name as name,
+        q.quote as quote,
+       authors.id as author_id,
+       qodice.date as date
+from authors
+   inner join quotes q
+      on authors.id = q.author_id
+   inner join qodice
+      on q.id = qodice.quote_id
+where authors.deleted_at is null and q.deleted_at is null and not q.is_private;
@@ -0,0 +1,2 @@
+-- Nothing to undo, the recomputed counters are correct either way
+SELECT 1;
@@ -0,0 +1,14 @@
+-- The counters and tsv of the authors are kept up to date on every write from now on, bring the existing ones up to date
+UPDATE authors SET
+   nr_of_english_quotes = counts.english,
+   nr_of_icelandic_quotes = counts.icelandic,
+   has_icelandic_quotes = counts.icelandic > 0,
+   tsv = setweight(to_tsvector('english', authors.name), 'A')
+FROM (
+   SELECT a.id,
+      count(q.id) FILTER (WHERE NOT q.is_icelandic) AS english,
+      count(q.id) FILTER (WHERE q.is_icelandic) AS icelandic
+   FROM authors a LEFT JOIN quotes q ON q.author_id = a.id AND q.deleted_at is null AND NOT q.is_private
+   GROUP BY a.id
+) AS counts
+WHERE authors.id = counts.id;
@@ -0,0 +1,3 @@
+DROP INDEX if exists index_topicstoquotes_on_quote_id;
+DROP INDEX if exists index_topicstoquotes_on_topic_id_quote_id;
+ALTER TABLE topics DROP COLUMN if exists nr_of_quotes;
@@ -0,0 +1,20 @@
+-- The number of live, public quotes in each topic, kept up to date on every write like the counters of the authors
+ALTER TABLE topics ADD COLUMN if not exists nr_of_quotes integer default 0;
+
+UPDATE topics SET
+   nr_of_quotes = counts.total,
+   is_icelandic = CASE WHEN counts.total > 0 THEN counts.total = counts.icelandic ELSE topics.is_icelandic END,
+   tsv = setweight(to_tsvector('english', topics.name), 'A')
+FROM (
+   SELECT t.id,
+      count(q.id) AS total,
+      count(q.id) FILTER (WHERE q.is_icelandic) AS icelandic
+   FROM topics t
+      LEFT JOIN topicstoquotes ttq ON ttq.topic_id = t.id AND ttq.deleted_at is null
+      LEFT JOIN quotes q ON q.id = ttq.quote_id AND q.deleted_at is null AND NOT q.is_private
+   GROUP BY t.id
+) AS counts
+WHERE topics.id = counts.id;
+
+CREATE INDEX if not exists index_topicstoquotes_on_topic_id_quote_id ON topicstoquotes(topic_id, quote_id);
+CREATE INDEX if not exists index_topicstoquotes_on_quote_id ON topicstoquotes(quote_id);
@@ -0,0 +1,3 @@
+DROP TABLE if exists collection_quotes;
+DROP TABLE if exists collections;
+DROP TABLE if exists favourites;
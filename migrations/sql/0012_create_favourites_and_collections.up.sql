@@ -0,0 +1,29 @@
+CREATE TABLE if not exists favourites(
+   user_id integer not null REFERENCES users(id) ON DELETE CASCADE,
+   quote_id integer not null REFERENCES quotes(id) ON DELETE CASCADE,
+   created_at timestamptz default current_timestamp,
+   PRIMARY KEY (user_id, quote_id)
+);
+
+CREATE TABLE if not exists collections(
+   id SERIAL PRIMARY KEY,
+   user_id integer not null REFERENCES users(id) ON DELETE CASCADE,
+   name VARCHAR NOT NULL,
+   slug VARCHAR NOT NULL UNIQUE,
+   is_public boolean not null default false,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz,
+   UNIQUE (user_id, name)
+);
+
+CREATE TABLE if not exists collection_quotes(
+   collection_id integer not null REFERENCES collections(id) ON DELETE CASCADE,
+   quote_id integer not null REFERENCES quotes(id) ON DELETE CASCADE,
+   position integer not null,
+   note text,
+   created_at timestamptz default current_timestamp,
+   PRIMARY KEY (collection_id, quote_id)
+);
+
+CREATE INDEX if not exists index_favourites_on_user_id_created_at ON favourites(user_id, created_at);
+CREATE INDEX if not exists index_collection_quotes_on_collection_id_position ON collection_quotes(collection_id, position);
@@ -0,0 +1,18 @@
+CREATE TABLE if not exists submissions(
+   id SERIAL PRIMARY KEY,
+   user_id integer not null REFERENCES users(id) ON DELETE CASCADE,
+   quote text not null,
+   author VARCHAR,
+   author_id integer REFERENCES authors(id) ON DELETE SET NULL,
+   is_icelandic boolean not null default false,
+   topics jsonb not null default '[]',
+   status VARCHAR not null default 'pending' CHECK (status in ('pending', 'approved', 'rejected')),
+   reason text,
+   quote_id integer REFERENCES quotes(id) ON DELETE SET NULL,
+   reviewed_by integer REFERENCES users(id) ON DELETE SET NULL,
+   created_at timestamptz default current_timestamp,
+   reviewed_at timestamptz
+);
+
+CREATE INDEX if not exists index_submissions_on_status_created_at ON submissions(status, created_at);
+CREATE INDEX if not exists index_submissions_on_user_id ON submissions(user_id);
@@ -0,0 +1,4 @@
+DROP INDEX if exists index_quotes_on_normalized_trgm;
+DROP INDEX if exists index_quotes_on_normalized;
+ALTER TABLE quotes DROP COLUMN if exists normalized;
+DROP FUNCTION if exists normalize_quote(text);
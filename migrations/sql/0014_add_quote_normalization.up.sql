@@ -0,0 +1,13 @@
+-- The quote without a trailing attribution (e.g. ' - Steve Jobs'), in lowercase and with the punctuation, curly quotes
+-- and dashes replaced by single spaces. Mirrored by normalizeQuote in repository/text.go
+CREATE OR REPLACE FUNCTION normalize_quote(quote text) RETURNS text AS $$
+   SELECT btrim(regexp_replace(
+      lower(regexp_replace(quote, '([[:space:]]+|[.!?"”’''])[-–—~]+[[:space:]]*[[:upper:]][^.!?,;:]{0,60}$', '\1')),
+      '[[:space:][:punct:]‘’“”„«»–—…´]+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE STRICT;
+
+ALTER TABLE quotes ADD COLUMN if not exists normalized text;
+UPDATE quotes SET normalized = normalize_quote(quote);
+
+CREATE INDEX if not exists index_quotes_on_normalized ON quotes USING hash(normalized);
+CREATE INDEX if not exists index_quotes_on_normalized_trgm ON quotes USING gin(normalized gin_trgm_ops);
@@ -0,0 +1,5 @@
+DROP INDEX if exists index_quotes_verified;
+DROP INDEX if exists index_quotes_on_source_id;
+ALTER TABLE quotes DROP COLUMN if exists source_status;
+ALTER TABLE quotes DROP COLUMN if exists source_id;
+DROP TABLE if exists sources;
@@ -0,0 +1,16 @@
+CREATE TABLE if not exists sources(
+   id SERIAL PRIMARY KEY,
+   title VARCHAR not null,
+   type VARCHAR not null default 'other' CHECK (type in ('book', 'speech', 'film', 'interview', 'other')),
+   year integer,
+   url VARCHAR,
+   created_at timestamptz default current_timestamp,
+   updated_at timestamptz default current_timestamp
+);
+
+ALTER TABLE quotes ADD COLUMN if not exists source_id integer REFERENCES sources(id) ON DELETE SET NULL;
+ALTER TABLE quotes ADD COLUMN if not exists source_status VARCHAR not null default 'unverified'
+   CHECK (source_status in ('unverified', 'verified', 'misattributed'));
+
+CREATE INDEX if not exists index_quotes_on_source_id ON quotes(source_id);
+CREATE INDEX if not exists index_quotes_verified ON quotes(id) WHERE source_status = 'verified';
@@ -0,0 +1,10 @@
+DROP INDEX if exists index_authors_on_professions;
+DROP INDEX if exists index_authors_on_birth_year;
+DROP INDEX if exists index_authors_on_nationality;
+DROP FUNCTION if exists date_year(VARCHAR);
+ALTER TABLE authors DROP COLUMN if exists links;
+ALTER TABLE authors DROP COLUMN if exists bio;
+ALTER TABLE authors DROP COLUMN if exists professions;
+ALTER TABLE authors DROP COLUMN if exists nationality;
+ALTER TABLE authors DROP COLUMN if exists death_date;
+ALTER TABLE authors DROP COLUMN if exists birth_date;
@@ -0,0 +1,18 @@
+-- Dates are ISO 8601, as precise as is known ('1844', '1844-10' or '1844-10-15'), with a minus sign for the years BC
+ALTER TABLE authors ADD COLUMN if not exists birth_date VARCHAR CHECK (birth_date ~ '^-?[0-9]{1,4}(-[0-9]{2}(-[0-9]{2})?)?$');
+ALTER TABLE authors ADD COLUMN if not exists death_date VARCHAR CHECK (death_date ~ '^-?[0-9]{1,4}(-[0-9]{2}(-[0-9]{2})?)?$');
+-- ISO 3166-1 alpha-2 country code, e.g. 'IS'
+ALTER TABLE authors ADD COLUMN if not exists nationality VARCHAR(2) CHECK (nationality ~ '^[A-Z]{2}$');
+-- lowercase names of the professions, e.g. '["philosopher", "poet"]'
+ALTER TABLE authors ADD COLUMN if not exists professions jsonb not null default '[]';
+ALTER TABLE authors ADD COLUMN if not exists bio text;
+-- the reference links, e.g. '[{"title": "Wikipedia", "url": "https://en.wikipedia.org/wiki/Friedrich_Nietzsche"}]'
+ALTER TABLE authors ADD COLUMN if not exists links jsonb not null default '[]';
+
+CREATE OR REPLACE FUNCTION date_year(date VARCHAR) RETURNS integer AS $$
+    SELECT substring(date from '^-?[0-9]+')::integer
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX if not exists index_authors_on_nationality ON authors(nationality);
+CREATE INDEX if not exists index_authors_on_birth_year ON authors(date_year(birth_date));
+CREATE INDEX if not exists index_authors_on_professions ON authors USING gin(professions jsonb_path_ops);
@@ -0,0 +1,11 @@
+-- Other names and spellings of the authors, e.g. 'Nietzsche' for 'Friedrich Nietzsche'. An alias belongs to a single author
+CREATE TABLE if not exists author_aliases(
+   id SERIAL PRIMARY KEY,
+   author_id integer not null REFERENCES authors(id) ON DELETE CASCADE,
+   alias VARCHAR not null,
+   created_at timestamptz default current_timestamp
+);
+
+CREATE UNIQUE INDEX if not exists index_author_aliases_on_lower_alias ON author_aliases(lower(alias));
+CREATE INDEX if not exists index_author_aliases_on_author_id ON author_aliases(author_id);
+CREATE INDEX if not exists index_author_aliases_on_alias_trgm ON author_aliases USING gin(alias gin_trgm_ops);
@@ -0,0 +1,22 @@
+DROP MATERIALIZED VIEW if exists unique_lexeme;
+DROP MATERIALIZED VIEW if exists unique_lexeme_quotes;
+DROP MATERIALIZED VIEW if exists unique_lexeme_authors;
+
+CREATE MATERIALIZED VIEW unique_lexeme AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote) || 
+    to_tsvector(''simple'', authors.name) 
+FROM quotes
+JOIN authors ON authors.id = quotes.author_id
+GROUP BY quotes.id, authors.id');
+
+CREATE MATERIALIZED VIEW unique_lexeme_quotes AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote)
+FROM quotes');
+
+CREATE MATERIALIZED VIEW unique_lexeme_authors AS
+SELECT word FROM ts_stat('SELECT to_tsvector(''simple'', authors.name)
+FROM authors');
+
+CREATE INDEX if not exists words_idx ON unique_lexeme USING gin(word gin_trgm_ops);
+CREATE INDEX if not exists words_idx_quotes ON unique_lexeme_quotes USING gin(word gin_trgm_ops);
+CREATE INDEX if not exists words_idx_authors ON unique_lexeme_authors USING gin(word gin_trgm_ops);
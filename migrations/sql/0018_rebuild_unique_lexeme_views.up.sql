@@ -0,0 +1,42 @@
+-- The word lists behind the "did you mean" suggestions. Only the live public quotes and the live authors, with their
+-- aliases, are in them so that no suggestion leaks a private or deleted quote. ndoc, the number of quotes / authors a
+-- word is in, breaks the ties between equally similar words
+DROP MATERIALIZED VIEW if exists unique_lexeme;
+DROP MATERIALIZED VIEW if exists unique_lexeme_quotes;
+DROP MATERIALIZED VIEW if exists unique_lexeme_authors;
+
+CREATE MATERIALIZED VIEW unique_lexeme AS
+SELECT word, ndoc FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote) || to_tsvector(''simple'', authors.name)
+FROM quotes
+JOIN authors ON authors.id = quotes.author_id
+WHERE authors.deleted_at is null AND quotes.deleted_at is null AND not quotes.is_private
+UNION ALL
+SELECT to_tsvector(''simple'', author_aliases.alias)
+FROM author_aliases
+JOIN authors ON authors.id = author_aliases.author_id
+WHERE authors.deleted_at is null');
+
+CREATE MATERIALIZED VIEW unique_lexeme_quotes AS
+SELECT word, ndoc FROM ts_stat('SELECT to_tsvector(''simple'', quotes.quote)
+FROM quotes
+JOIN authors ON authors.id = quotes.author_id
+WHERE authors.deleted_at is null AND quotes.deleted_at is null AND not quotes.is_private');
+
+CREATE MATERIALIZED VIEW unique_lexeme_authors AS
+SELECT word, ndoc FROM ts_stat('SELECT to_tsvector(''simple'', authors.name)
+FROM authors
+WHERE authors.deleted_at is null
+UNION ALL
+SELECT to_tsvector(''simple'', author_aliases.alias)
+FROM author_aliases
+JOIN authors ON authors.id = author_aliases.author_id
+WHERE authors.deleted_at is null');
+
+CREATE INDEX if not exists words_idx ON unique_lexeme USING gin(word gin_trgm_ops);
+CREATE INDEX if not exists words_idx_quotes ON unique_lexeme_quotes USING gin(word gin_trgm_ops);
+CREATE INDEX if not exists words_idx_authors ON unique_lexeme_authors USING gin(word gin_trgm_ops);
+
+-- REFRESH MATERIALIZED VIEW CONCURRENTLY needs a unique index on the view
+CREATE UNIQUE INDEX if not exists index_unique_lexeme_unique_on_word ON unique_lexeme(word);
+CREATE UNIQUE INDEX if not exists index_unique_lexeme_quotes_unique_on_word ON unique_lexeme_quotes(word);
+CREATE UNIQUE INDEX if not exists index_unique_lexeme_authors_unique_on_word ON unique_lexeme_authors(word);
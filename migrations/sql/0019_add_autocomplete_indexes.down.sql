@@ -0,0 +1,5 @@
+DROP INDEX if exists index_quotes_on_live_count;
+DROP INDEX if exists index_authors_on_live_count;
+DROP INDEX if exists index_quotes_on_simple_quote;
+DROP INDEX if exists index_topics_on_simple_name;
+DROP INDEX if exists index_authors_on_simple_name;
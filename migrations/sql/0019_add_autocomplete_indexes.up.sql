@@ -0,0 +1,8 @@
+-- Autocomplete matches the words of the names and the quotes by prefix, e.g. to_tsquery('simple', 'friedrich & nie:*'),
+-- and ranks the matches by popularity
+CREATE INDEX if not exists index_authors_on_simple_name ON authors USING gin(to_tsvector('simple', name)) WHERE deleted_at is null;
+CREATE INDEX if not exists index_topics_on_simple_name ON topics USING gin(to_tsvector('simple', name)) WHERE deleted_at is null;
+CREATE INDEX if not exists index_quotes_on_simple_quote ON quotes USING gin(to_tsvector('simple', quote)) WHERE deleted_at is null AND not is_private;
+
+CREATE INDEX if not exists index_authors_on_live_count ON authors(count DESC) WHERE deleted_at is null;
+CREATE INDEX if not exists index_quotes_on_live_count ON quotes(count DESC) WHERE deleted_at is null AND not is_private;
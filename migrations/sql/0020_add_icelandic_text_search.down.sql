@@ -0,0 +1,13 @@
+UPDATE quotes SET tsv = setweight(to_tsvector('english', quote), 'B') WHERE is_icelandic;
+UPDATE topics SET tsv = setweight(to_tsvector('english', name), 'A');
+UPDATE authors SET tsv = setweight(to_tsvector('english', authors.name), 'A') ||
+   setweight(to_tsvector('english', coalesce((SELECT string_agg(alias, ' ') FROM author_aliases WHERE author_id = authors.id), '')), 'B');
+
+REFRESH MATERIALIZED VIEW searchview;
+REFRESH MATERIALIZED VIEW topicsview;
+
+DROP FUNCTION if exists name_tsvector(text);
+DROP FUNCTION if exists search_config(boolean);
+DROP TEXT SEARCH CONFIGURATION if exists icelandic;
+DROP TEXT SEARCH DICTIONARY if exists icelandic_unaccent;
+DROP EXTENSION if exists unaccent;
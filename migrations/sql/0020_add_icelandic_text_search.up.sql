@@ -0,0 +1,28 @@
+-- Everything was indexed with the english configuration, which stems Icelandic as if it were English and can not match
+-- a search typed without þ, ð, æ or the accents. The icelandic configuration does not stem and folds those letters
+-- with the unaccent rules, þ -> th, ð -> d, æ -> ae, á -> a ..., so that e.g. thordur matches Þórður
+CREATE EXTENSION if not exists unaccent;
+
+DROP TEXT SEARCH CONFIGURATION if exists icelandic;
+DROP TEXT SEARCH DICTIONARY if exists icelandic_unaccent;
+CREATE TEXT SEARCH DICTIONARY icelandic_unaccent (TEMPLATE = unaccent, RULES = 'unaccent');
+CREATE TEXT SEARCH CONFIGURATION icelandic (COPY = simple);
+ALTER TEXT SEARCH CONFIGURATION icelandic ALTER MAPPING FOR hword, hword_part, word WITH icelandic_unaccent, simple;
+
+-- The configuration of a quote or topic in the given language
+CREATE OR REPLACE FUNCTION search_config(is_icelandic boolean) RETURNS regconfig AS $$
+   SELECT CASE WHEN is_icelandic THEN 'icelandic'::regconfig ELSE 'english'::regconfig END
+$$ LANGUAGE sql IMMUTABLE;
+
+-- A name reads the same in either language, so names are indexed in both configurations
+CREATE OR REPLACE FUNCTION name_tsvector(name text) RETURNS tsvector AS $$
+   SELECT to_tsvector('english', coalesce(name, '')) || to_tsvector('icelandic', coalesce(name, ''))
+$$ LANGUAGE sql IMMUTABLE;
+
+UPDATE quotes SET tsv = setweight(to_tsvector(search_config(is_icelandic), quote), 'B') WHERE is_icelandic;
+UPDATE topics SET tsv = setweight(to_tsvector(search_config(is_icelandic), name), 'A');
+UPDATE authors SET tsv = setweight(name_tsvector(authors.name), 'A') ||
+   setweight(name_tsvector((SELECT string_agg(alias, ' ') FROM author_aliases WHERE author_id = authors.id)), 'B');
+
+REFRESH MATERIALIZED VIEW searchview;
+REFRESH MATERIALIZED VIEW topicsview;
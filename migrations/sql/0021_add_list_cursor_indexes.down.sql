@@ -0,0 +1,5 @@
+DROP INDEX if exists index_authors_on_live_count_id;
+DROP INDEX if exists index_authors_on_live_initcap_name_id;
+
+DROP INDEX if exists index_search_on_length_quote_id;
+DROP INDEX if exists index_search_on_quote_count_quote_id;
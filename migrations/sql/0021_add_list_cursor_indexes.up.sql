@@ -0,0 +1,7 @@
+-- The quotes and authors lists seek to the row after their cursor, e.g. quote_count < 12 OR (quote_count = 12 AND
+-- quote_id > 3051), and read the page in the order of the list, the rows with the same key by id
+CREATE INDEX if not exists index_search_on_quote_count_quote_id ON searchview(quote_count DESC, quote_id);
+CREATE INDEX if not exists index_search_on_length_quote_id ON searchview(length(quote), quote_id);
+
+CREATE INDEX if not exists index_authors_on_live_initcap_name_id ON authors(initcap(name), id) WHERE deleted_at is null;
+CREATE INDEX if not exists index_authors_on_live_count_id ON authors(count DESC, id) WHERE deleted_at is null;
@@ -0,0 +1,12 @@
+-- The pairs of quotes whose normalized texts have a trigram similarity of at least 0.5 (MinDuplicateSimilarity), the
+-- lower id first. The duplicates report clusters them instead of comparing every quote with every other one. Each
+-- insert and restore records the pairs of its quote, and the pairs of the quotes that were there before are recorded
+-- in batches by `backfill duplicates`, since a self join of all the quotes is too slow for a migration
+CREATE TABLE if not exists quote_duplicates(
+   quote_id integer REFERENCES quotes(id) ON DELETE CASCADE,
+   duplicate_id integer REFERENCES quotes(id) ON DELETE CASCADE,
+   similarity real not null,
+   PRIMARY KEY (quote_id, duplicate_id),
+   CHECK (quote_id < duplicate_id)
+);
+CREATE INDEX if not exists index_quote_duplicates_on_duplicate_id ON quote_duplicates(duplicate_id);
@@ -0,0 +1,2 @@
+DROP INDEX if exists index_authors_on_live_initcap_name_c_id;
+CREATE INDEX if not exists index_authors_on_live_initcap_name_id ON authors(initcap(name), id) WHERE deleted_at is null;
@@ -0,0 +1,4 @@
+-- The authors list compares the names by their bytes (COLLATE "C"), i.e. by code points, so that the order and the
+-- cursors do not depend on the collation of the database and agree with the in-memory list
+DROP INDEX if exists index_authors_on_live_initcap_name_id;
+CREATE INDEX if not exists index_authors_on_live_initcap_name_c_id ON authors((initcap(name) COLLATE "C"), id) WHERE deleted_at is null;
@@ -0,0 +1,3 @@
+DROP INDEX if exists index_quotes_on_live_private_user_id_quote;
+DROP INDEX if exists index_quotes_on_live_public_quote;
+ALTER TABLE quotes ADD CONSTRAINT quotes_quote_key UNIQUE (quote);
@@ -0,0 +1,7 @@
+-- A quote is only a duplicate of the live quotes that are public or private to the same user, so a soft deleted quote
+-- or another user's private quote neither blocks a new quote nor gives itself away. The text stays unique where it is
+-- visible, i.e. among the live public quotes and among each user's live private quotes, and the duplicate check of an
+-- insert looks the text up with the predicate of each index
+ALTER TABLE quotes DROP CONSTRAINT if exists quotes_quote_key;
+CREATE UNIQUE INDEX if not exists index_quotes_on_live_public_quote ON quotes(quote) WHERE deleted_at is null AND NOT is_private;
+CREATE UNIQUE INDEX if not exists index_quotes_on_live_private_user_id_quote ON quotes(user_id, quote) WHERE deleted_at is null AND is_private;
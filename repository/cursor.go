@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//ErrInvalidCursor is returned when the cursor of a list was not made by the same list, i.e. with the same orderConfig
+//and language, or is not a cursor at all
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+//listCursor is the position in a list right after a row, i.e. the list's order and the row's sort key and id
+type listCursor struct {
+	Order string `json:"o"`
+	Key   string `json:"k"`
+	Id    int    `json:"i"`
+}
+
+//QuotesListCursor returns the cursor of the quotes list, with the request's orderConfig, right after the given quote
+func QuotesListCursor(request structs.Request, last structs.SearchViewDBModel) string {
+	column, orderDirection := quotesListOrder(request.OrderConfig)
+	return encodeCursor(listCursor{Order: "quotes " + column + " " + orderDirection, Key: quotesListKey(column, last), Id: last.QuoteId})
+}
+
+//AuthorsListCursor returns the cursor of the authors list, with the request's orderConfig, right after the given author
+func AuthorsListCursor(request structs.Request, last structs.AuthorDBModel) string {
+	column, orderDirection := authorsListOrder(request)
+	return encodeCursor(listCursor{Order: "authors " + column + " " + orderDirection, Key: authorsListKey(column, last), Id: last.Id})
+}
+
+//quotesListKey returns the value of the column the quotes are listed by for the quote
+func quotesListKey(column string, quote structs.SearchViewDBModel) string {
+	switch column {
+	case "quote_count":
+		return strconv.Itoa(quote.QuoteCount)
+	case "length(quote)":
+		return strconv.Itoa(utf8.RuneCountInString(quote.Quote))
+	}
+	return strconv.Itoa(quote.QuoteId)
+}
+
+//authorsListKey returns the value of the column the authors are listed by for the author, the name as is since it is
+//compared through initcap
+func authorsListKey(column string, author structs.AuthorDBModel) string {
+	switch column {
+	case "initcap(name)":
+		return author.Name
+	case "count":
+		return strconv.Itoa(author.Count)
+	case "nr_of_english_quotes":
+		return strconv.Itoa(author.NrOfEnglishQuotes)
+	case "nr_of_icelandic_quotes":
+		return strconv.Itoa(author.NrOfIcelandicQuotes)
+	}
+	return strconv.Itoa(author.NrOfIcelandicQuotes + author.NrOfEnglishQuotes)
+}
+
+func encodeCursor(cursor listCursor) string {
+	token, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+//decodeCursor returns the cursor of the token, ErrInvalidCursor if it is not a cursor of a list in the given order
+func decodeCursor(token string, order string) (listCursor, error) {
+	var cursor listCursor
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || json.Unmarshal(decoded, &cursor) != nil || cursor.Order != order {
+		return cursor, ErrInvalidCursor
+	}
+	if _, err := strconv.Atoi(cursor.Key); err != nil && !strings.HasPrefix(order, "authors initcap(name)") {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+//cursorSQL adds the condition that the rows come after the cursor in the list, i.e. the seek that replaces the offset
+//of the page, see 0021_add_list_cursor_indexes. The rows with the same key as the cursor's row are ordered by
+//idColumn ascending
+func cursorSQL(token string, list string, column string, orderDirection string, idColumn string, dbPointer *gorm.DB) (*gorm.DB, error) {
+	if token == "" {
+		return dbPointer, nil
+	}
+	cursor, err := decodeCursor(token, list+" "+column+" "+orderDirection)
+	if err != nil {
+		return dbPointer, err
+	}
+
+	operator := ">"
+	if orderDirection == "DESC" {
+		operator = "<"
+	}
+	if column == "initcap(name)" {
+		return dbPointer.Where(fmt.Sprintf("(%[1]s %[2]s initcap(?) OR (%[1]s = initcap(?) AND %[3]s > ?))", columnSQL(column), operator, idColumn), cursor.Key, cursor.Key, cursor.Id), nil
+	}
+	key, _ := strconv.Atoi(cursor.Key)
+	return dbPointer.Where(fmt.Sprintf("((%[1]s) %[2]s ? OR ((%[1]s) = ? AND %[3]s > ?))", column, operator, idColumn), key, key, cursor.Id), nil
+}
+
+//columnSQL returns the column a list is ordered by as SQL. The names are compared by their bytes (COLLATE "C"), i.e.
+//by code points, the same as strings.Compare does in isAfterCursor and the in-memory lists, whatever the collation of
+//the database is, see 0023_collate_author_name_cursor_index
+func columnSQL(column string) string {
+	if column == "initcap(name)" {
+		return `(initcap(name) COLLATE "C")`
+	}
+	return column
+}
+
+//isAfterCursor mirrors cursorSQL, whether the row with the given key and id comes after the cursor in the list
+func isAfterCursor(cursor listCursor, column string, orderDirection string, key string, id int) bool {
+	var compared int
+	if column == "initcap(name)" {
+		compared = strings.Compare(initcap(key), initcap(cursor.Key))
+	} else {
+		a, _ := strconv.Atoi(key)
+		b, _ := strconv.Atoi(cursor.Key)
+		compared = a - b
+	}
+	if compared == 0 {
+		return id > cursor.Id
+	}
+	return (compared > 0) == (orderDirection == "ASC")
+}
+
+//afterCursor returns the request for the page right after its cursor, with a cursor the page does not offset the list
+func afterCursor(request structs.Request) structs.Request {
+	if request.Cursor != "" {
+		request.Page = 0
+	}
+	return request
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//MinDuplicateSimilarity is the least similarity of the pairs of near duplicates kept in quote_duplicates, the
+//duplicates report can not ask for less similar quotes, see 0022_create_quote_duplicates
+const MinDuplicateSimilarity = 0.5
+
+//duplicateCandidate is a quote, in the searchview shape, with its normalized text
+type duplicateCandidate struct {
+	structs.SearchViewDBModel
+	Normalized string
+}
+
+//duplicatePair is two quotes whose normalized texts are similar
+type duplicatePair struct {
+	QuoteId     int
+	DuplicateId int
+}
+
+//duplicateMember is a quote in the cluster of near duplicates with the given id, the lowest id of the quotes in it
+type duplicateMember struct {
+	QuoteId   int
+	ClusterId int
+}
+
+//groupMembers groups the candidates by the clusters of the members, which are ordered by the cluster, and leaves out
+//the members that are not candidates
+func groupMembers(members []duplicateMember, candidates []duplicateCandidate) [][]duplicateCandidate {
+	byId := map[int]duplicateCandidate{}
+	for _, candidate := range candidates {
+		byId[candidate.QuoteId] = candidate
+	}
+	groups := [][]duplicateCandidate{}
+	clusterId := 0
+	for _, member := range members {
+		candidate, ok := byId[member.QuoteId]
+		if !ok {
+			continue
+		}
+		if len(groups) == 0 || member.ClusterId != clusterId {
+			groups = append(groups, []duplicateCandidate{})
+			clusterId = member.ClusterId
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], candidate)
+	}
+	return groups
+}
+
+//clusterIds groups the quotes linked by the pairs, each group ordered by id and the groups by their lowest id
+func clusterIds(pairs []duplicatePair) [][]int {
+	parent := map[int]int{}
+	var find func(id int) int
+	find = func(id int) int {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, pair := range pairs {
+		rootA, rootB := find(pair.QuoteId), find(pair.DuplicateId)
+		if rootA < rootB {
+			parent[rootB] = rootA
+		} else if rootB < rootA {
+			parent[rootA] = rootB
+		}
+	}
+
+	groups := map[int][]int{}
+	for id := range parent {
+		root := find(id)
+		groups[root] = append(groups[root], id)
+	}
+	clusters := [][]int{}
+	for _, group := range groups {
+		sort.Ints(group)
+		clusters = append(clusters, group)
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i][0] < clusters[j][0] })
+	return clusters
+}
+
+//buildCluster orders the quotes with the most popular one, the suggested survivor, first and sets the similarity of
+//each of the others to it
+func buildCluster(candidates []duplicateCandidate) structs.DuplicateClusterDBModel {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].QuoteCount != candidates[j].QuoteCount {
+			return candidates[i].QuoteCount > candidates[j].QuoteCount
+		}
+		return candidates[i].QuoteId < candidates[j].QuoteId
+	})
+	cluster := structs.DuplicateClusterDBModel{Quotes: []structs.SimilarQuoteDBModel{}}
+	for idx, candidate := range candidates {
+		quote := structs.SimilarQuoteDBModel{SearchViewDBModel: candidate.SearchViewDBModel, Similarity: 1}
+		if idx > 0 {
+			quote.Similarity = similarity(candidates[0].Normalized, candidate.Normalized)
+		}
+		cluster.Quotes = append(cluster.Quotes, quote)
+	}
+	return cluster
+}
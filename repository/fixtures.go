@@ -0,0 +1,86 @@
+package repository
+
+import "github.com/Skjaldbaka17/quotes-api/structs"
+
+//The api keys of the users in the seed fixtures
+const FixtureApiKey = "3c1ad32f-1d3b-4c8b-9e3a-7a2a4b0f6c01"
+const FixtureGodApiKey = "5f2e9f7c-8e4d-4b59-a2b1-0d9c3e6a7b42"
+
+//Fixtures is the data the in-memory repositories are seeded with
+type Fixtures struct {
+	Authors        []structs.AuthorDBModel
+	Quotes         []structs.QuoteDBModel
+	Topics         []structs.TopicDBModel
+	TopicsToQuotes []TopicToQuote
+	Users          []structs.UserDBModel
+}
+
+//TopicToQuote is a row in the topicstoquotes table
+type TopicToQuote struct {
+	TopicId int
+	QuoteId int
+}
+
+//SeedFixtures returns a small corpus of English and Icelandic quotes, topics and two users, a free-tier one and a GOD-tier one.
+//The denormalized counters of the authors are computed from the quotes when seeding.
+func SeedFixtures() Fixtures {
+	return Fixtures{
+		Authors: []structs.AuthorDBModel{
+			{Id: 1, Name: "Muhammad Ali", Count: 120, BirthDate: "1942-01-17", DeathDate: "2016-06-03", Nationality: "US", Professions: `["boxer","activist"]`},
+			{Id: 2, Name: "Friedrich Nietzsche", Count: 300, BirthDate: "1844-10-15", DeathDate: "1900-08-25", Nationality: "DE", Professions: `["philosopher","poet"]`,
+				Links: `[{"title":"Wikipedia","url":"https://en.wikipedia.org/wiki/Friedrich_Nietzsche"}]`},
+			{Id: 3, Name: "Democritus", Count: 15, BirthDate: "-460", DeathDate: "-370", Nationality: "GR", Professions: `["philosopher"]`},
+			{Id: 4, Name: "Martin Luther", Count: 40},
+			{Id: 5, Name: "Michael Jordan", Count: 90},
+			{Id: 6, Name: "Joseph Stalin", Count: 25},
+			{Id: 7, Name: "Hávamál", Count: 60},
+			{Id: 8, Name: "Jónas Hallgrímsson", Count: 35, BirthDate: "1807-11-16", DeathDate: "1845-05-26", Nationality: "IS", Professions: `["poet","naturalist"]`},
+			{Id: 9, Name: "William Shakespeare", Count: 250},
+			{Id: 10, Name: "John Lennon", Count: 80},
+			{Id: 11, Name: "Oscar Wilde", Count: 200},
+		},
+		Quotes: []structs.QuoteDBModel{
+			{Id: 1, AuthorId: 1, Quote: "Float like a butterfly, sting like a bee.", Count: 500},
+			{Id: 2, AuthorId: 1, Quote: "Service to others is the rent you pay for your room here on earth.", Count: 20},
+			{Id: 3, AuthorId: 1, Quote: "Don't count the days, make the days count.", Count: 75},
+			{Id: 4, AuthorId: 2, Quote: "That which does not kill us makes us stronger.", Count: 400},
+			{Id: 5, AuthorId: 2, Quote: "Without music, life would be a mistake.", Count: 150},
+			{Id: 6, AuthorId: 2, Quote: "He who has a why to live can bear almost any how.", Count: 60},
+			{Id: 7, AuthorId: 2, Quote: "Það sem drepur mig ekki gerir mig sterkari.", Count: 30, IsIcelandic: true},
+			{Id: 8, AuthorId: 3, Quote: "Happiness resides not in possessions, and not in gold, happiness dwells in the soul.", Count: 45},
+			{Id: 9, AuthorId: 4, Quote: "If you are not allowed to laugh in heaven, I don't want to go there.", Count: 35},
+			{Id: 10, AuthorId: 4, Quote: "Even if I knew that tomorrow the world would go to pieces, I would still plant my apple tree.", Count: 10},
+			{Id: 11, AuthorId: 5, Quote: "I've failed over and over and over again in my life. And that is why I succeed.", Count: 220},
+			{Id: 12, AuthorId: 5, Quote: "Talent wins games, but teamwork and intelligence win championships.", Count: 95},
+			{Id: 13, AuthorId: 6, Quote: "Ideas are more powerful than guns.", Count: 40},
+			{Id: 14, AuthorId: 7, Quote: "Deyr fé, deyja frændur, deyr sjálfur hið sama.", Count: 70, IsIcelandic: true},
+			{Id: 15, AuthorId: 7, Quote: "Betri byrði ber ekki maður á braut en mannvit mikið.", Count: 25, IsIcelandic: true},
+			{Id: 16, AuthorId: 8, Quote: "Ísland, farsælda frón og hagsælda hrímhvíta móðir!", Count: 50, IsIcelandic: true},
+			{Id: 17, AuthorId: 9, Quote: "To be, or not to be, that is the question.", Count: 600},
+			{Id: 18, AuthorId: 9, Quote: "Love all, trust a few, do wrong to none.", Count: 110},
+			{Id: 19, AuthorId: 10, Quote: "Life is what happens when you're busy making other plans.", Count: 300},
+			{Id: 20, AuthorId: 11, Quote: "Be yourself; everyone else is already taken.", Count: 450},
+			{Id: 21, AuthorId: 11, Quote: "I can resist everything except temptation.", Count: 130},
+		},
+		Topics: []structs.TopicDBModel{
+			{Id: 1, Name: "inspirational"},
+			{Id: 2, Name: "love"},
+			{Id: 3, Name: "life"},
+			{Id: 4, Name: "motivational"},
+			{Id: 5, Name: "Speki", IsIcelandic: true},
+			{Id: 6, Name: "Ísland", IsIcelandic: true},
+		},
+		TopicsToQuotes: []TopicToQuote{
+			{TopicId: 1, QuoteId: 1}, {TopicId: 1, QuoteId: 4}, {TopicId: 1, QuoteId: 8}, {TopicId: 1, QuoteId: 11}, {TopicId: 1, QuoteId: 12},
+			{TopicId: 2, QuoteId: 18}, {TopicId: 2, QuoteId: 20},
+			{TopicId: 3, QuoteId: 5}, {TopicId: 3, QuoteId: 6}, {TopicId: 3, QuoteId: 17}, {TopicId: 3, QuoteId: 19},
+			{TopicId: 4, QuoteId: 3}, {TopicId: 4, QuoteId: 11}, {TopicId: 4, QuoteId: 13},
+			{TopicId: 5, QuoteId: 7}, {TopicId: 5, QuoteId: 14}, {TopicId: 5, QuoteId: 15},
+			{TopicId: 6, QuoteId: 16},
+		},
+		Users: []structs.UserDBModel{
+			{Id: 1, Name: "Free Tier", Email: "free@example.com", ApiKey: FixtureApiKey, Tier: "free"},
+			{Id: 2, Name: "God Tier", Email: "god@example.com", ApiKey: FixtureGodApiKey, Tier: "GOD"},
+		},
+	}
+}
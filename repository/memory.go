@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+const dateLayout = "2006-01-02"
+
+// memoryStore holds the tables shared by the in-memory repositories, rows are kept sorted by id
+type memoryStore struct {
+	mu             sync.RWMutex
+	authors        []*structs.AuthorDBModel
+	quotes         []*structs.QuoteDBModel
+	topics         []*structs.TopicDBModel
+	topicCounts    map[int]int
+	topicsToQuotes []TopicToQuote
+	users          []*structs.UserDBModel
+	requestHistory []memoryRequestEvent
+	//quote / author of the day, language -> date -> id
+	qods map[string]map[string]int
+	aods map[string]map[string]int
+	//the in-memory views are always up to date, only the refreshes are recorded
+	viewRefreshes map[string]structs.ViewRefreshDBModel
+	//deleted_at of the soft deleted rows, table -> id -> deleted_at
+	deleted map[string]map[int]time.Time
+	//the favourite quotes of each user, in the order they were added
+	favourites       map[int][]int
+	collections      []*structs.CollectionDBModel
+	collectionQuotes []*memoryCollectionQuote
+	submissions      []*structs.SubmissionDBModel
+	sources          []*structs.SourceDBModel
+	//the source_id / source_status of the cited quotes, quote id -> citation
+	citations map[int]memoryCitation
+	aliases   []*structs.AuthorAliasDBModel
+}
+
+type memoryRequestEvent struct {
+	event     structs.RequestEvent
+	createdAt time.Time
+}
+
+// NewMemoryRepositories returns repositories that keep everything in memory, seeded with the given fixtures. Meant for tests.
+func NewMemoryRepositories(fixtures Fixtures) *Repositories {
+	store := &memoryStore{
+		topicCounts:   map[int]int{},
+		qods:          map[string]map[string]int{"english": {}, "icelandic": {}},
+		aods:          map[string]map[string]int{"english": {}, "icelandic": {}},
+		viewRefreshes: map[string]structs.ViewRefreshDBModel{},
+		deleted:       map[string]map[int]time.Time{AuthorsTable: {}, QuotesTable: {}, TopicsTable: {}},
+		favourites:    map[int][]int{},
+		citations:     map[int]memoryCitation{},
+	}
+	for i := range fixtures.Authors {
+		author := fixtures.Authors[i]
+		store.authors = append(store.authors, &author)
+	}
+	for i := range fixtures.Quotes {
+		quote := fixtures.Quotes[i]
+		store.quotes = append(store.quotes, &quote)
+	}
+	for i := range fixtures.Topics {
+		topic := fixtures.Topics[i]
+		store.topics = append(store.topics, &topic)
+	}
+	for i := range fixtures.Users {
+		user := fixtures.Users[i]
+		store.users = append(store.users, &user)
+	}
+	store.topicsToQuotes = append(store.topicsToQuotes, fixtures.TopicsToQuotes...)
+	sort.Slice(store.authors, func(i, j int) bool { return store.authors[i].Id < store.authors[j].Id })
+	sort.Slice(store.quotes, func(i, j int) bool { return store.quotes[i].Id < store.quotes[j].Id })
+	sort.Slice(store.topics, func(i, j int) bool { return store.topics[i].Id < store.topics[j].Id })
+	store.recomputeAuthorCounters()
+	store.recomputeTopicCounters()
+
+	return &Repositories{
+		Quotes:         &quotesMemory{store},
+		Authors:        &authorsMemory{store},
+		Topics:         &topicsMemory{store},
+		OfTheDay:       &ofTheDayMemory{store},
+		Users:          &usersMemory{store},
+		RequestHistory: &requestHistoryMemory{store},
+		Views:          &viewsMemory{store},
+		Deleted:        &deletedMemory{store},
+		Collections:    &collectionsMemory{store},
+		Submissions:    &submissionsMemory{store},
+		Duplicates:     &duplicatesMemory{store},
+		Sources:        &sourcesMemory{store},
+		Suggestions:    &suggestionsMemory{store},
+		Completions:    &completionsMemory{store},
+	}
+}
+
+// recomputeAuthorCounters sets nr_of_english_quotes, nr_of_icelandic_quotes and has_icelandic_quotes from the counted
+// quotes, a deleted author has none
+func (store *memoryStore) recomputeAuthorCounters() {
+	for _, author := range store.authors {
+		author.NrOfEnglishQuotes = 0
+		author.NrOfIcelandicQuotes = 0
+	}
+	for _, quote := range store.countedQuotes() {
+		author := store.author(quote.AuthorId)
+		if author == nil {
+			continue
+		}
+		if quote.IsIcelandic {
+			author.NrOfIcelandicQuotes++
+		} else {
+			author.NrOfEnglishQuotes++
+		}
+	}
+	for _, author := range store.authors {
+		author.HasIcelandicQuotes = author.NrOfIcelandicQuotes > 0
+	}
+}
+
+// recomputeTopicCounters sets nr_of_quotes and is_icelandic of the topics from their counted quotes, a topic is Icelandic
+// if all its quotes are and a topic without quotes keeps its language
+func (store *memoryStore) recomputeTopicCounters() {
+	for _, topic := range store.topics {
+		total, icelandic := 0, 0
+		for _, quote := range store.countedQuotes() {
+			if store.isInTopic(topic.Id, quote.Id) {
+				total++
+				if quote.IsIcelandic {
+					icelandic++
+				}
+			}
+		}
+		topic.NrOfQuotes = total
+		if total > 0 {
+			topic.IsIcelandic = total == icelandic
+		}
+	}
+}
+
+func (store *memoryStore) author(id int) *structs.AuthorDBModel {
+	for _, author := range store.authors {
+		if author.Id == id {
+			return author
+		}
+	}
+	return nil
+}
+
+func (store *memoryStore) quote(id int) *structs.QuoteDBModel {
+	for _, quote := range store.quotes {
+		if quote.Id == id {
+			return quote
+		}
+	}
+	return nil
+}
+
+// searchView returns the rows of the searchview, ordered by quote id
+func (store *memoryStore) searchView() []structs.SearchViewDBModel {
+	rows := []structs.SearchViewDBModel{}
+	for _, quote := range store.publicQuotes() {
+		author := store.author(quote.AuthorId)
+		if author == nil || store.isDeleted(AuthorsTable, author.Id) {
+			continue
+		}
+		rows = append(rows, structs.SearchViewDBModel{
+			AuthorId:    author.Id,
+			Name:        author.Name,
+			QuoteId:     quote.Id,
+			Quote:       quote.Quote,
+			IsIcelandic: quote.IsIcelandic,
+			QuoteCount:  quote.Count,
+			AuthorCount: author.Count,
+		})
+	}
+	return rows
+}
+
+// topicsView returns the rows of the topicsview, ordered by topic id and then quote id
+func (store *memoryStore) topicsView() []structs.TopicViewDBModel {
+	rows := []structs.TopicViewDBModel{}
+	for _, topic := range store.liveTopics() {
+		for _, quote := range store.publicQuotes() {
+			if !store.isInTopic(topic.Id, quote.Id) {
+				continue
+			}
+			author := store.author(quote.AuthorId)
+			if author == nil || store.isDeleted(AuthorsTable, author.Id) {
+				continue
+			}
+			rows = append(rows, structs.TopicViewDBModel{
+				AuthorId:    author.Id,
+				Name:        author.Name,
+				QuoteId:     quote.Id,
+				Quote:       quote.Quote,
+				IsIcelandic: quote.IsIcelandic,
+				TopicName:   topic.Name,
+				TopicId:     topic.Id,
+			})
+		}
+	}
+	return rows
+}
+
+func (store *memoryStore) isDeleted(table string, id int) bool {
+	_, ok := store.deleted[table][id]
+	return ok
+}
+
+// liveAuthors returns the authors that have not been deleted
+func (store *memoryStore) liveAuthors() []*structs.AuthorDBModel {
+	authors := []*structs.AuthorDBModel{}
+	for _, author := range store.authors {
+		if !store.isDeleted(AuthorsTable, author.Id) {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
+// liveQuotes returns the quotes that have not been deleted
+func (store *memoryStore) liveQuotes() []*structs.QuoteDBModel {
+	quotes := []*structs.QuoteDBModel{}
+	for _, quote := range store.quotes {
+		if !store.isDeleted(QuotesTable, quote.Id) {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+// publicQuotes returns the quotes that have not been deleted and are not private, i.e. the quotes in the views
+func (store *memoryStore) publicQuotes() []*structs.QuoteDBModel {
+	quotes := []*structs.QuoteDBModel{}
+	for _, quote := range store.liveQuotes() {
+		if !quote.IsPrivate {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+// countedQuotes returns the public quotes of the live authors, i.e. the quotes the counters of the authors and topics
+// count
+func (store *memoryStore) countedQuotes() []*structs.QuoteDBModel {
+	quotes := []*structs.QuoteDBModel{}
+	for _, quote := range store.publicQuotes() {
+		if !store.isDeleted(AuthorsTable, quote.AuthorId) {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes
+}
+
+// liveTopics returns the topics that have not been deleted
+func (store *memoryStore) liveTopics() []*structs.TopicDBModel {
+	topics := []*structs.TopicDBModel{}
+	for _, topic := range store.topics {
+		if !store.isDeleted(TopicsTable, topic.Id) {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
+}
+
+func (store *memoryStore) isInTopic(topicId int, quoteId int) bool {
+	for _, link := range store.topicsToQuotes {
+		if link.TopicId == topicId && link.QuoteId == quoteId {
+			return true
+		}
+	}
+	return false
+}
+
+// searchViewAsTopicView returns the searchview rows in the topicsview shape
+func searchViewAsTopicView(rows []structs.SearchViewDBModel) []structs.TopicViewDBModel {
+	views := []structs.TopicViewDBModel{}
+	for _, row := range rows {
+		views = append(views, structs.TopicViewDBModel{
+			AuthorId:    row.AuthorId,
+			Name:        row.Name,
+			QuoteId:     row.QuoteId,
+			Quote:       row.Quote,
+			IsIcelandic: row.IsIcelandic,
+		})
+	}
+	return views
+}
+
+// matchesLanguage mirrors quoteLanguageSQL / authorLanguageSQL
+func matchesLanguage(language string, isIcelandic bool) bool {
+	switch strings.ToLower(language) {
+	case "english":
+		return !isIcelandic
+	case "icelandic":
+		return isIcelandic
+	}
+	return true
+}
+
+// languageKey returns the key of the of-the-day tables for the language, english is the default
+func languageKey(language string) string {
+	if strings.ToLower(language) == "icelandic" {
+		return "icelandic"
+	}
+	return "english"
+}
+
+// pageBounds returns the start and end of the requested page in a list of the given length
+func pageBounds(request structs.Request, length int) (int, int) {
+	start := request.Page * request.PageSize
+	if start > length {
+		start = length
+	}
+	end := start + request.PageSize
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+//memoryTotal mirrors countTotal, a total above maxExactTotal would have been estimated
+func memoryTotal(count int) structs.TotalDBModel {
+	return structs.TotalDBModel{Total: count, Estimated: count > maxExactTotal}
+}
+
+func today() string {
+	return time.Now().Format(dateLayout)
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+func (repo *authorsMemory) Resolve(name string) (structs.AuthorDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, author := range repo.store.liveAuthors() {
+		if strings.EqualFold(author.Name, name) {
+			return *author, nil
+		}
+	}
+	if author := repo.store.aliasAuthor(name); author != nil {
+		return *author, nil
+	}
+	return structs.AuthorDBModel{}, ErrNotFound
+}
+
+func (repo *authorsMemory) Aliases(authorIds []int) ([]structs.AuthorAliasDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	aliases := []structs.AuthorAliasDBModel{}
+	for _, alias := range repo.store.aliases {
+		if containsId(authorIds, alias.AuthorId) {
+			aliases = append(aliases, *alias)
+		}
+	}
+	sort.SliceStable(aliases, func(i, j int) bool { return strings.ToLower(aliases[i].Alias) < strings.ToLower(aliases[j].Alias) })
+	return aliases, nil
+}
+
+func (repo *authorsMemory) AddAliases(authorId int, aliases []string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.author(authorId) == nil || repo.store.isDeleted(AuthorsTable, authorId) {
+		return ErrNotFound
+	}
+	for _, alias := range aliases {
+		for _, author := range repo.store.liveAuthors() {
+			if author.Id != authorId && strings.EqualFold(author.Name, alias) {
+				return ErrAliasTaken
+			}
+		}
+		if existing := repo.store.alias(alias); existing != nil && existing.AuthorId != authorId {
+			return ErrAliasTaken
+		}
+	}
+	for _, alias := range aliases {
+		//The alias may already be the author's
+		if repo.store.alias(alias) == nil {
+			repo.store.addAlias(authorId, alias)
+		}
+	}
+	return nil
+}
+
+func (repo *authorsMemory) RemoveAliases(authorId int, aliases []string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.author(authorId) == nil || repo.store.isDeleted(AuthorsTable, authorId) {
+		return ErrNotFound
+	}
+	kept := []*structs.AuthorAliasDBModel{}
+	for _, alias := range repo.store.aliases {
+		removed := false
+		for _, name := range aliases {
+			if alias.AuthorId == authorId && strings.EqualFold(alias.Alias, name) {
+				removed = true
+			}
+		}
+		if !removed {
+			kept = append(kept, alias)
+		}
+	}
+	repo.store.aliases = kept
+	return nil
+}
+
+func (repo *authorsMemory) Merge(survivorId int, authorIds []int) (structs.AuthorDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, id := range append([]int{survivorId}, authorIds...) {
+		if repo.store.author(id) == nil || repo.store.isDeleted(AuthorsTable, id) {
+			return structs.AuthorDBModel{}, ErrNotFound
+		}
+	}
+
+	survivor := repo.store.author(survivorId)
+	for _, alias := range repo.store.aliases {
+		if containsId(authorIds, alias.AuthorId) {
+			alias.AuthorId = survivorId
+		}
+	}
+	for _, quote := range repo.store.quotes {
+		if containsId(authorIds, quote.AuthorId) {
+			quote.AuthorId = survivorId
+		}
+	}
+	for _, submission := range repo.store.submissions {
+		if containsId(authorIds, submission.AuthorId) {
+			submission.AuthorId = survivorId
+		}
+	}
+	for _, dates := range repo.store.aods {
+		for date, authorId := range dates {
+			if containsId(authorIds, authorId) {
+				dates[date] = survivorId
+			}
+		}
+	}
+
+	for _, authorId := range authorIds {
+		merged := repo.store.author(authorId)
+		//The names of the merged authors live on as aliases of the survivor
+		if repo.store.alias(merged.Name) == nil {
+			repo.store.addAlias(survivorId, merged.Name)
+		}
+		survivor.Count += merged.Count
+		survivor.SetProfile(mergeProfiles(*survivor, *merged))
+		repo.store.deleted[AuthorsTable][authorId] = time.Now()
+	}
+	repo.store.recomputeAuthorCounters()
+	return *survivor, nil
+}
+
+//mergeProfiles fills the gaps in the survivor's profile from the merged author's, and takes the union of their
+//professions and links
+func mergeProfiles(survivor structs.AuthorDBModel, merged structs.AuthorDBModel) structs.AuthorProfile {
+	profile := structs.AuthorProfile{
+		BirthDate:   survivor.BirthDate,
+		DeathDate:   survivor.DeathDate,
+		Nationality: survivor.Nationality,
+		Professions: survivor.ProfessionNames(),
+		Bio:         survivor.Bio,
+		Links:       survivor.ReferenceLinks(),
+	}
+	if profile.BirthDate == "" {
+		profile.BirthDate = merged.BirthDate
+	}
+	if profile.DeathDate == "" {
+		profile.DeathDate = merged.DeathDate
+	}
+	if profile.Nationality == "" {
+		profile.Nationality = merged.Nationality
+	}
+	if profile.Bio == "" {
+		profile.Bio = merged.Bio
+	}
+	for _, profession := range merged.ProfessionNames() {
+		if !contains(profile.Professions, profession) {
+			profile.Professions = append(profile.Professions, profession)
+		}
+	}
+	for _, link := range merged.ReferenceLinks() {
+		known := false
+		for _, existing := range profile.Links {
+			known = known || existing == link
+		}
+		if !known {
+			profile.Links = append(profile.Links, link)
+		}
+	}
+	return profile
+}
+
+//alias returns the alias (case insensitive), nil if no author has it
+func (store *memoryStore) alias(name string) *structs.AuthorAliasDBModel {
+	for _, alias := range store.aliases {
+		if strings.EqualFold(alias.Alias, name) {
+			return alias
+		}
+	}
+	return nil
+}
+
+//aliasAuthor mirrors aliasAuthorId, the live author that has the alias
+func (store *memoryStore) aliasAuthor(name string) *structs.AuthorDBModel {
+	alias := store.alias(name)
+	if alias == nil || store.isDeleted(AuthorsTable, alias.AuthorId) {
+		return nil
+	}
+	return store.author(alias.AuthorId)
+}
+
+func (store *memoryStore) addAlias(authorId int, name string) {
+	id := 1
+	for _, alias := range store.aliases {
+		if alias.Id >= id {
+			id = alias.Id + 1
+		}
+	}
+	store.aliases = append(store.aliases, &structs.AuthorAliasDBModel{Id: id, AuthorId: authorId, Alias: name, CreatedAt: time.Now()})
+}
+
+//authorAliases returns the aliases of the author
+func (store *memoryStore) authorAliases(authorId int) []string {
+	aliases := []string{}
+	for _, alias := range store.aliases {
+		if alias.AuthorId == authorId {
+			aliases = append(aliases, alias.Alias)
+		}
+	}
+	return aliases
+}
@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+var dateYearRegex = regexp.MustCompile(`^-?[0-9]+`)
+
+type authorsMemory struct {
+	store *memoryStore
+}
+
+func (repo *authorsMemory) GetByIds(ids []int) ([]structs.AuthorDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authors := []structs.AuthorDBModel{}
+	for _, author := range repo.store.liveAuthors() {
+		if containsId(ids, author.Id) {
+			authors = append(authors, *author)
+		}
+	}
+	return authors, nil
+}
+
+func (repo *authorsMemory) List(request structs.Request) ([]structs.AuthorDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authors, err := repo.listed(request)
+	if err != nil {
+		return authors, err
+	}
+	start, end := pageBounds(afterCursor(request), len(authors))
+	return authors[start:end], nil
+}
+
+func (repo *authorsMemory) ListTotal(request structs.Request) (structs.TotalDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authors, err := repo.listed(request)
+	return memoryTotal(len(authors)), err
+}
+
+//listed returns all the authors List pages through, after the cursor, in the order of the list
+func (repo *authorsMemory) listed(request structs.Request) ([]structs.AuthorDBModel, error) {
+	authors := []structs.AuthorDBModel{}
+	for _, author := range repo.store.liveAuthors() {
+		if matchesAuthorLanguage(request.Language, *author) && matchesAuthorProfile(request, *author) {
+			authors = append(authors, *author)
+		}
+	}
+
+	descending := request.OrderConfig.Reverse
+	var less func(a, b structs.AuthorDBModel) bool
+	switch strings.ToLower(request.OrderConfig.OrderBy) {
+	case "popularity":
+		descending = !request.OrderConfig.Reverse
+		less = func(a, b structs.AuthorDBModel) bool { return a.Count < b.Count }
+	case "nrofquotes":
+		nrOfQuotes := func(author structs.AuthorDBModel) int {
+			switch strings.ToLower(request.Language) {
+			case "english":
+				return author.NrOfEnglishQuotes
+			case "icelandic":
+				return author.NrOfIcelandicQuotes
+			default:
+				return author.NrOfIcelandicQuotes + author.NrOfEnglishQuotes
+			}
+		}
+		filtered := []structs.AuthorDBModel{}
+		for _, author := range authors {
+			if withinMaxMin(request.OrderConfig, nrOfQuotes(author)) {
+				filtered = append(filtered, author)
+			}
+		}
+		authors = filtered
+		less = func(a, b structs.AuthorDBModel) bool { return nrOfQuotes(a) < nrOfQuotes(b) }
+	default:
+		filtered := []structs.AuthorDBModel{}
+		for _, author := range authors {
+			//Minimum / maximum letter to start with (i.e. start / end at the given letter of the alphabet)
+			if request.OrderConfig.Minimum != "" && initcap(author.Name) < strings.ToUpper(request.OrderConfig.Minimum) {
+				continue
+			}
+			if request.OrderConfig.Maximum != "" && initcap(author.Name) > strings.ToUpper(request.OrderConfig.Maximum) {
+				continue
+			}
+			filtered = append(filtered, author)
+		}
+		authors = filtered
+		less = func(a, b structs.AuthorDBModel) bool { return initcap(a.Name) < initcap(b.Name) }
+	}
+
+	sort.SliceStable(authors, func(i, j int) bool {
+		if less(authors[i], authors[j]) || less(authors[j], authors[i]) {
+			return less(authors[i], authors[j]) != descending
+		}
+		return authors[i].Id < authors[j].Id
+	})
+
+	if request.Cursor == "" {
+		return authors, nil
+	}
+	column, orderDirection := authorsListOrder(request)
+	cursor, err := decodeCursor(request.Cursor, "authors "+column+" "+orderDirection)
+	if err != nil {
+		return nil, err
+	}
+	for i, author := range authors {
+		if isAfterCursor(cursor, column, orderDirection, authorsListKey(column, author), author.Id) {
+			return authors[i:], nil
+		}
+	}
+	return []structs.AuthorDBModel{}, nil
+}
+
+func (repo *authorsMemory) Random(language string) (structs.AuthorDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	candidates := []structs.AuthorDBModel{}
+	for _, author := range repo.store.liveAuthors() {
+		if matchesAuthorLanguage(language, *author) {
+			candidates = append(candidates, *author)
+		}
+	}
+	if len(candidates) == 0 {
+		return structs.AuthorDBModel{}, ErrNotFound
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+func (repo *authorsMemory) Search(request structs.Request) ([]structs.AuthorDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authors := repo.searched(request)
+	start, end := pageBounds(request, len(authors))
+	return authors[start:end], nil
+}
+
+func (repo *authorsMemory) SearchTotal(request structs.Request) (structs.TotalDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	return memoryTotal(len(repo.searched(request))), nil
+}
+
+//searched returns all the authors Search matches, the most similar first
+func (repo *authorsMemory) searched(request structs.Request) []structs.AuthorDBModel {
+	query := lexemes(request.SearchString)
+	type rankedAuthor struct {
+		author     structs.AuthorDBModel
+		similarity float64
+	}
+	ranked := []rankedAuthor{}
+	for _, author := range repo.store.liveAuthors() {
+		if !matchesAuthorLanguage(request.Language, *author) {
+			continue
+		}
+		aliases := repo.store.authorAliases(author.Id)
+		matches := matchesAll(query, nameLexemes(strings.Join(append([]string{author.Name}, aliases...), " ")))
+		//% is same as SIMILARITY but with default threshold 0.3
+		for _, word := range strings.Split(author.Name, " ") {
+			if similarity(request.SearchString, word) >= similarityThreshold {
+				matches = true
+			}
+		}
+		rank := similarity(author.Name, request.SearchString)
+		for _, alias := range aliases {
+			aliasSimilarity := similarity(alias, request.SearchString)
+			if aliasSimilarity >= similarityThreshold {
+				matches = true
+			}
+			if aliasSimilarity > rank {
+				rank = aliasSimilarity
+			}
+		}
+		if matches {
+			ranked = append(ranked, rankedAuthor{author: *author, similarity: rank})
+		}
+	}
+
+	//Order by authorid to have definitive order (when for example some names rank the same for similarity)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].similarity != ranked[j].similarity {
+			return ranked[i].similarity > ranked[j].similarity
+		}
+		return ranked[i].author.Id > ranked[j].author.Id
+	})
+
+	results := []structs.AuthorDBModel{}
+	for _, rank := range ranked {
+		results = append(results, rank.author)
+	}
+	return results
+}
+
+func (repo *authorsMemory) IncrementCount(authorIds []int, by int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, author := range repo.store.authors {
+		if containsId(authorIds, author.Id) {
+			author.Count += by
+		}
+	}
+	return nil
+}
+
+//matchesAuthorProfile mirrors authorProfileSQL
+func matchesAuthorProfile(request structs.Request, author structs.AuthorDBModel) bool {
+	if request.Profession != "" && !contains(author.ProfessionNames(), strings.ToLower(strings.TrimSpace(request.Profession))) {
+		return false
+	}
+	if request.Nationality != "" && author.Nationality != strings.ToUpper(strings.TrimSpace(request.Nationality)) {
+		return false
+	}
+	if request.BornBefore != 0 || request.BornAfter != 0 {
+		year, known := dateYear(author.BirthDate)
+		if !known || (request.BornBefore != 0 && year >= request.BornBefore) || (request.BornAfter != 0 && year <= request.BornAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+//dateYear mirrors the date_year function, the year of an ISO 8601 date
+func dateYear(date string) (int, bool) {
+	match := dateYearRegex.FindString(date)
+	if match == "" {
+		return 0, false
+	}
+	year, err := strconv.Atoi(match)
+	return year, err == nil
+}
+
+//matchesAuthorLanguage mirrors authorLanguageSQL
+func matchesAuthorLanguage(language string, author structs.AuthorDBModel) bool {
+	return matchesLanguage(language, author.HasIcelandicQuotes)
+}
+
+func (repo *authorsMemory) Create(name string) (structs.AuthorDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if author, err := repo.store.takenName(name, 0); err != nil {
+		return author, err
+	}
+	//Nor can an author be created with another author's alias
+	if author := repo.store.aliasAuthor(name); author != nil {
+		return *author, ErrAuthorExists
+	}
+	author, err := repo.store.upsertAuthor(name)
+	if err != nil {
+		return structs.AuthorDBModel{}, err
+	}
+	return *author, nil
+}
+
+func (repo *authorsMemory) Update(id int, name string) (structs.AuthorDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	author := repo.store.author(id)
+	if author == nil || repo.store.isDeleted(AuthorsTable, id) {
+		return structs.AuthorDBModel{}, ErrNotFound
+	}
+	if _, err := repo.store.takenName(name, id); err != nil {
+		return structs.AuthorDBModel{}, err
+	}
+	if other := repo.store.aliasAuthor(name); other != nil && other.Id != id {
+		return structs.AuthorDBModel{}, ErrAuthorExists
+	}
+	author.Name = name
+	repo.store.recomputeAuthorCounters()
+	return *author, nil
+}
+
+//takenName mirrors the takenName of Postgres
+func (store *memoryStore) takenName(name string, id int) (structs.AuthorDBModel, error) {
+	var err error
+	for _, author := range store.authors {
+		if author.Name != name || author.Id == id {
+			continue
+		}
+		if !store.isDeleted(AuthorsTable, author.Id) {
+			return *author, ErrAuthorExists
+		}
+		err = ErrAuthorDeleted
+	}
+	return structs.AuthorDBModel{}, err
+}
+
+func (repo *authorsMemory) UpdateProfile(id int, profile structs.AuthorProfile) (structs.AuthorDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	author := repo.store.author(id)
+	if author == nil || repo.store.isDeleted(AuthorsTable, id) {
+		return structs.AuthorDBModel{}, ErrNotFound
+	}
+	author.SetProfile(profile)
+	return *author, nil
+}
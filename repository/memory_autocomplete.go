@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type completionsMemory struct {
+	store *memoryStore
+}
+
+func (repo *completionsMemory) Complete(prefix string, language string, limit int) (structs.AutocompleteDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	completions := structs.AutocompleteDBModel{Authors: []structs.AuthorDBModel{}, Topics: []structs.TopicDBModel{}, Quotes: []structs.SearchViewDBModel{}}
+	prefixes := words(prefix)
+	if len(prefixes) == 0 {
+		return completions, nil
+	}
+
+	for _, author := range repo.store.liveAuthors() {
+		if matchesAuthorLanguage(language, *author) && matchesPrefixes(prefixes, author.Name) {
+			completions.Authors = append(completions.Authors, *author)
+		}
+	}
+	sort.SliceStable(completions.Authors, func(i, j int) bool { return completions.Authors[i].Count > completions.Authors[j].Count })
+
+	for _, topic := range repo.store.liveTopics() {
+		if matchesLanguage(language, topic.IsIcelandic) && matchesPrefixes(prefixes, topic.Name) {
+			completions.Topics = append(completions.Topics, *topic)
+		}
+	}
+	sort.SliceStable(completions.Topics, func(i, j int) bool {
+		return repo.store.topicCounts[completions.Topics[i].Id] > repo.store.topicCounts[completions.Topics[j].Id]
+	})
+
+	for _, row := range repo.store.searchView() {
+		if matchesLanguage(language, row.IsIcelandic) && matchesPrefixes(prefixes, row.Quote) {
+			completions.Quotes = append(completions.Quotes, row)
+		}
+	}
+	sort.SliceStable(completions.Quotes, func(i, j int) bool { return completions.Quotes[i].QuoteCount > completions.Quotes[j].QuoteCount })
+
+	if len(completions.Authors) > limit {
+		completions.Authors = completions.Authors[:limit]
+	}
+	if len(completions.Topics) > limit {
+		completions.Topics = completions.Topics[:limit]
+	}
+	if len(completions.Quotes) > limit {
+		completions.Quotes = completions.Quotes[:limit]
+	}
+	return completions, nil
+}
+
+//matchesPrefixes mirrors prefixTsquery, i.e. each of the prefixes starts a word of the text
+func matchesPrefixes(prefixes []string, text string) bool {
+	textWords := words(text)
+	for _, prefix := range prefixes {
+		found := false
+		for _, word := range textWords {
+			found = found || strings.HasPrefix(word, prefix)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type memoryCollectionQuote struct {
+	collectionId int
+	quoteId      int
+	position     int
+	note         string
+}
+
+type collectionsMemory struct {
+	store *memoryStore
+}
+
+func (repo *collectionsMemory) Favourites(userId int, request structs.Request) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.SearchViewDBModel{}
+	favourites := repo.store.favourites[userId]
+	//The most recently added first
+	for idx := len(favourites) - 1; idx >= 0; idx-- {
+		if row, ok := repo.store.visibleQuote(userId, favourites[idx]); ok {
+			quotes = append(quotes, row)
+		}
+	}
+	start, end := pageBounds(request, len(quotes))
+	return quotes[start:end], nil
+}
+
+func (repo *collectionsMemory) AddFavourites(userId int, quoteIds []int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, quoteId := range quoteIds {
+		if _, ok := repo.store.visibleQuote(userId, quoteId); !ok {
+			return ErrNotFound
+		}
+	}
+	for _, quoteId := range quoteIds {
+		if !containsId(repo.store.favourites[userId], quoteId) {
+			repo.store.favourites[userId] = append(repo.store.favourites[userId], quoteId)
+		}
+	}
+	return nil
+}
+
+func (repo *collectionsMemory) RemoveFavourites(userId int, quoteIds []int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	favourites := []int{}
+	for _, quoteId := range repo.store.favourites[userId] {
+		if !containsId(quoteIds, quoteId) {
+			favourites = append(favourites, quoteId)
+		}
+	}
+	repo.store.favourites[userId] = favourites
+	return nil
+}
+
+func (repo *collectionsMemory) List(userId int) ([]structs.CollectionDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	collections := []structs.CollectionDBModel{}
+	for _, collection := range repo.store.collections {
+		if collection.UserId == userId {
+			collections = append(collections, repo.store.withNrOfQuotes(*collection))
+		}
+	}
+	sort.SliceStable(collections, func(i, j int) bool { return collections[i].Name < collections[j].Name })
+	return collections, nil
+}
+
+func (repo *collectionsMemory) Get(id int, slug string) (structs.CollectionDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, collection := range repo.store.collections {
+		if (id > 0 && collection.Id == id) || (id <= 0 && collection.Slug == slug) {
+			return repo.store.withNrOfQuotes(*collection), nil
+		}
+	}
+	return structs.CollectionDBModel{}, ErrNotFound
+}
+
+func (repo *collectionsMemory) Create(collection *structs.CollectionDBModel) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	id := 1
+	for _, existing := range repo.store.collections {
+		if existing.UserId == collection.UserId && existing.Name == collection.Name {
+			return ErrCollectionExists
+		}
+		if existing.Id >= id {
+			id = existing.Id + 1
+		}
+	}
+	collection.Id = id
+	collection.CreatedAt = time.Now()
+	created := *collection
+	repo.store.collections = append(repo.store.collections, &created)
+	return nil
+}
+
+func (repo *collectionsMemory) Update(collection structs.CollectionDBModel) (structs.CollectionDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	existing := repo.store.collection(collection.UserId, collection.Id)
+	if existing == nil {
+		return collection, ErrNotFound
+	}
+	for _, other := range repo.store.collections {
+		if other.UserId == collection.UserId && other.Name == collection.Name && other.Id != collection.Id {
+			return collection, ErrCollectionExists
+		}
+	}
+	existing.Name = collection.Name
+	existing.IsPublic = collection.IsPublic
+	return repo.store.withNrOfQuotes(*existing), nil
+}
+
+func (repo *collectionsMemory) Delete(userId int, collectionId int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.collection(userId, collectionId) == nil {
+		return ErrNotFound
+	}
+	collections := []*structs.CollectionDBModel{}
+	for _, collection := range repo.store.collections {
+		if collection.Id != collectionId {
+			collections = append(collections, collection)
+		}
+	}
+	repo.store.collections = collections
+	repo.store.removeCollectionQuotes(collectionId, nil)
+	return nil
+}
+
+func (repo *collectionsMemory) Quotes(collectionId int, viewerId int) ([]structs.CollectionQuoteDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.CollectionQuoteDBModel{}
+	for _, entry := range repo.store.collectionQuotes {
+		if entry.collectionId != collectionId {
+			continue
+		}
+		if row, ok := repo.store.visibleQuote(viewerId, entry.quoteId); ok {
+			quotes = append(quotes, structs.CollectionQuoteDBModel{SearchViewDBModel: row, Position: entry.position, Note: entry.note})
+		}
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if quotes[i].Position != quotes[j].Position {
+			return quotes[i].Position < quotes[j].Position
+		}
+		return quotes[i].QuoteId < quotes[j].QuoteId
+	})
+	return quotes, nil
+}
+
+func (repo *collectionsMemory) SetQuote(userId int, collectionId int, quoteId int, note string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.collection(userId, collectionId) == nil {
+		return ErrNotFound
+	}
+	if _, ok := repo.store.visibleQuote(userId, quoteId); !ok {
+		return ErrNotFound
+	}
+	position := 0
+	for _, entry := range repo.store.collectionQuotes {
+		if entry.collectionId != collectionId {
+			continue
+		}
+		if entry.quoteId == quoteId {
+			entry.note = note
+			return nil
+		}
+		if entry.position > position {
+			position = entry.position
+		}
+	}
+	repo.store.collectionQuotes = append(repo.store.collectionQuotes, &memoryCollectionQuote{collectionId: collectionId, quoteId: quoteId, position: position + 1, note: note})
+	return nil
+}
+
+func (repo *collectionsMemory) RemoveQuotes(userId int, collectionId int, quoteIds []int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.collection(userId, collectionId) == nil {
+		return ErrNotFound
+	}
+	repo.store.removeCollectionQuotes(collectionId, quoteIds)
+	return nil
+}
+
+func (repo *collectionsMemory) Reorder(userId int, collectionId int, quoteIds []int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.collection(userId, collectionId) == nil {
+		return ErrNotFound
+	}
+	entries := []*memoryCollectionQuote{}
+	for _, entry := range repo.store.collectionQuotes {
+		if entry.collectionId == collectionId {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].position != entries[j].position {
+			return entries[i].position < entries[j].position
+		}
+		return entries[i].quoteId < entries[j].quoteId
+	})
+	current := []int{}
+	for _, entry := range entries {
+		current = append(current, entry.quoteId)
+	}
+	for idx, quoteId := range reordered(current, quoteIds) {
+		for _, entry := range entries {
+			if entry.quoteId == quoteId {
+				entry.position = idx + 1
+			}
+		}
+	}
+	return nil
+}
+
+//visibleQuote returns the quote, in the searchview shape, if it is live and visible to the user
+func (store *memoryStore) visibleQuote(userId int, quoteId int) (structs.SearchViewDBModel, bool) {
+	quote := store.quote(quoteId)
+	if quote == nil || store.isDeleted(QuotesTable, quoteId) || (quote.IsPrivate && quote.UserId != userId) {
+		return structs.SearchViewDBModel{}, false
+	}
+	author := store.author(quote.AuthorId)
+	if author == nil || store.isDeleted(AuthorsTable, author.Id) {
+		return structs.SearchViewDBModel{}, false
+	}
+	return structs.SearchViewDBModel{
+		AuthorId:    author.Id,
+		Name:        author.Name,
+		QuoteId:     quote.Id,
+		Quote:       quote.Quote,
+		IsIcelandic: quote.IsIcelandic,
+		QuoteCount:  quote.Count,
+		AuthorCount: author.Count,
+	}, true
+}
+
+//collection returns the user's collection with the given id, nil if the user has no such collection
+func (store *memoryStore) collection(userId int, collectionId int) *structs.CollectionDBModel {
+	for _, collection := range store.collections {
+		if collection.Id == collectionId && collection.UserId == userId {
+			return collection
+		}
+	}
+	return nil
+}
+
+func (store *memoryStore) withNrOfQuotes(collection structs.CollectionDBModel) structs.CollectionDBModel {
+	collection.NrOfQuotes = 0
+	for _, entry := range store.collectionQuotes {
+		if entry.collectionId == collection.Id {
+			collection.NrOfQuotes++
+		}
+	}
+	return collection
+}
+
+//removeCollectionQuotes removes the given quotes, or all of them if quoteIds is nil, from the collection
+func (store *memoryStore) removeCollectionQuotes(collectionId int, quoteIds []int) {
+	entries := []*memoryCollectionQuote{}
+	for _, entry := range store.collectionQuotes {
+		if entry.collectionId != collectionId || (quoteIds != nil && !containsId(quoteIds, entry.quoteId)) {
+			entries = append(entries, entry)
+		}
+	}
+	store.collectionQuotes = entries
+}
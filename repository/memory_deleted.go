@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type deletedMemory struct {
+	store *memoryStore
+}
+
+func (repo *deletedMemory) Delete(table string, id int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if !isDeletableTable(table) || !repo.store.exists(table, id) || repo.store.isDeleted(table, id) {
+		return ErrNotFound
+	}
+	repo.store.deleted[table][id] = time.Now()
+	repo.store.recomputeAuthorCounters()
+	repo.store.recomputeTopicCounters()
+	return nil
+}
+
+func (repo *deletedMemory) Restore(table string, id int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if !isDeletableTable(table) || !repo.store.isDeleted(table, id) {
+		return ErrNotFound
+	}
+	if table == QuotesTable && repo.store.liveDuplicate(*repo.store.quote(id)) {
+		return ErrQuoteExists
+	}
+	delete(repo.store.deleted[table], id)
+	repo.store.recomputeAuthorCounters()
+	repo.store.recomputeTopicCounters()
+	return nil
+}
+
+func (repo *deletedMemory) List(table string, request structs.Request) ([]structs.DeletedDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if !isDeletableTable(table) {
+		return nil, ErrNotFound
+	}
+	deleted := []structs.DeletedDBModel{}
+	for id, deletedAt := range repo.store.deleted[table] {
+		item := structs.DeletedDBModel{Id: id, DeletedAt: deletedAt}
+		switch table {
+		case AuthorsTable:
+			item.Name = repo.store.author(id).Name
+		case QuotesTable:
+			quote := repo.store.quote(id)
+			item.Quote, item.AuthorId = quote.Quote, quote.AuthorId
+			if author := repo.store.author(quote.AuthorId); author != nil {
+				item.Name = author.Name
+			}
+		case TopicsTable:
+			item.Name = repo.store.topic(id).Name
+		}
+		deleted = append(deleted, item)
+	}
+
+	sort.Slice(deleted, func(i, j int) bool {
+		if !deleted[i].DeletedAt.Equal(deleted[j].DeletedAt) {
+			return deleted[i].DeletedAt.After(deleted[j].DeletedAt)
+		}
+		return deleted[i].Id > deleted[j].Id
+	})
+	start, end := pageBounds(request, len(deleted))
+	return deleted[start:end], nil
+}
+
+//exists is whether the table has a row, deleted or not, with the given id
+func (store *memoryStore) exists(table string, id int) bool {
+	switch table {
+	case AuthorsTable:
+		return store.author(id) != nil
+	case QuotesTable:
+		return store.quote(id) != nil
+	case TopicsTable:
+		return store.topic(id) != nil
+	}
+	return false
+}
+
+func (store *memoryStore) topic(id int) *structs.TopicDBModel {
+	for _, topic := range store.topics {
+		if topic.Id == id {
+			return topic
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type duplicatesMemory struct {
+	store *memoryStore
+}
+
+func (repo *duplicatesMemory) Similar(quote string, userId int, minimum float64, limit int) ([]structs.SimilarQuoteDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	normalized := normalizeQuote(quote)
+	quotes := []structs.SimilarQuoteDBModel{}
+	for _, existing := range repo.store.quotes {
+		row, ok := repo.store.visibleQuote(userId, existing.Id)
+		if !ok {
+			continue
+		}
+		if score := similarity(normalizeQuote(existing.Quote), normalized); score >= minimum {
+			quotes = append(quotes, structs.SimilarQuoteDBModel{SearchViewDBModel: row, Similarity: score})
+		}
+	}
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if quotes[i].Similarity != quotes[j].Similarity {
+			return quotes[i].Similarity > quotes[j].Similarity
+		}
+		return quotes[i].QuoteId < quotes[j].QuoteId
+	})
+	if len(quotes) > limit {
+		quotes = quotes[:limit]
+	}
+	return quotes, nil
+}
+
+func (repo *duplicatesMemory) Clusters(minimum float64, request structs.Request) ([]structs.DuplicateClusterDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	candidates := map[int]duplicateCandidate{}
+	ids := []int{}
+	for _, quote := range repo.store.publicQuotes() {
+		if row, ok := repo.store.visibleQuote(0, quote.Id); ok {
+			candidates[quote.Id] = duplicateCandidate{SearchViewDBModel: row, Normalized: normalizeQuote(quote.Quote)}
+			ids = append(ids, quote.Id)
+		}
+	}
+	pairs := []duplicatePair{}
+	for i, quoteId := range ids {
+		for _, duplicateId := range ids[i+1:] {
+			first, second := candidates[quoteId], candidates[duplicateId]
+			if first.Normalized != "" && similarity(first.Normalized, second.Normalized) >= minimum {
+				pairs = append(pairs, duplicatePair{QuoteId: quoteId, DuplicateId: duplicateId})
+			}
+		}
+	}
+
+	groups := clusterIds(pairs)
+	start, end := pageBounds(request, len(groups))
+	clusters := []structs.DuplicateClusterDBModel{}
+	for _, group := range groups[start:end] {
+		members := []duplicateCandidate{}
+		for _, quoteId := range group {
+			members = append(members, candidates[quoteId])
+		}
+		clusters = append(clusters, buildCluster(members))
+	}
+	return clusters, nil
+}
+
+//RecordPairs only pages through the quotes, the in-memory store compares them when they are clustered
+func (repo *duplicatesMemory) RecordPairs(afterId int, limit int) (int, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	lastId, recorded := 0, 0
+	for _, quote := range repo.store.quotes {
+		if quote.Id > afterId && recorded < limit {
+			lastId, recorded = quote.Id, recorded+1
+		}
+	}
+	return lastId, nil
+}
+
+func (repo *duplicatesMemory) Merge(survivorId int, duplicateIds []int) (structs.QuoteDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	duplicateIds = uniqueIds(duplicateIds)
+	for _, quoteId := range append([]int{survivorId}, duplicateIds...) {
+		if _, ok := repo.store.visibleQuote(0, quoteId); !ok {
+			return structs.QuoteDBModel{}, ErrNotFound
+		}
+	}
+	survivor := repo.store.quote(survivorId)
+
+	for _, link := range repo.store.topicsToQuotes {
+		if containsId(duplicateIds, link.QuoteId) && !repo.store.isInTopic(link.TopicId, survivorId) {
+			repo.store.topicsToQuotes = append(repo.store.topicsToQuotes, TopicToQuote{TopicId: link.TopicId, QuoteId: survivorId})
+		}
+	}
+	for userId, favourites := range repo.store.favourites {
+		moved := []int{}
+		for _, quoteId := range favourites {
+			if containsId(duplicateIds, quoteId) {
+				quoteId = survivorId
+			}
+			if !containsId(moved, quoteId) {
+				moved = append(moved, quoteId)
+			}
+		}
+		repo.store.favourites[userId] = moved
+	}
+	//The survivor keeps its own entry, and note, in the collections it is already in
+	entries := []*memoryCollectionQuote{}
+	moved := []*memoryCollectionQuote{}
+	for _, entry := range repo.store.collectionQuotes {
+		if containsId(duplicateIds, entry.quoteId) {
+			moved = append(moved, entry)
+		} else {
+			entries = append(entries, entry)
+		}
+	}
+	for _, entry := range moved {
+		if !hasCollectionQuote(entries, entry.collectionId, survivorId) {
+			entry.quoteId = survivorId
+			entries = append(entries, entry)
+		}
+	}
+	repo.store.collectionQuotes = entries
+	repo.store.repointOfTheDay(survivorId, duplicateIds)
+	repo.store.mergeCitations(survivorId, duplicateIds)
+
+	for _, quoteId := range duplicateIds {
+		survivor.Count += repo.store.quote(quoteId).Count
+		repo.store.deleted[QuotesTable][quoteId] = time.Now()
+	}
+	repo.store.recomputeAuthorCounters()
+	repo.store.recomputeTopicCounters()
+	return *survivor, nil
+}
+
+func hasCollectionQuote(entries []*memoryCollectionQuote, collectionId int, quoteId int) bool {
+	for _, entry := range entries {
+		if entry.collectionId == collectionId && entry.quoteId == quoteId {
+			return true
+		}
+	}
+	return false
+}
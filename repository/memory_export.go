@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+func (repo *quotesMemory) Export(request structs.Request, callbacks ExportCallbacks) error {
+	//Copy the rows so that the callbacks do not run while holding the lock
+	repo.store.mu.RLock()
+	snapshot := time.Now()
+	authors := []structs.AuthorDBModel{}
+	for _, author := range repo.store.liveAuthors() {
+		authors = append(authors, *author)
+	}
+	topics := []structs.TopicDBModel{}
+	for _, topic := range repo.store.liveTopics() {
+		topics = append(topics, *topic)
+	}
+	var rows []structs.TopicViewDBModel
+	if ExportsTopics(request) {
+		rows = repo.store.topicsView()
+	} else {
+		rows = searchViewAsTopicView(repo.store.searchView())
+	}
+	repo.store.mu.RUnlock()
+
+	if err := callbacks.Begin(snapshot); err != nil {
+		return err
+	}
+	if callbacks.Author != nil {
+		for _, author := range authors {
+			if err := callbacks.Author(author); err != nil {
+				return err
+			}
+		}
+	}
+	if callbacks.Topic != nil {
+		for _, topic := range topics {
+			if !matchesLanguage(request.Language, topic.IsIcelandic) || !matchesExportTopic(request, topic.Id, topic.Name) {
+				continue
+			}
+			if err := callbacks.Topic(topic); err != nil {
+				return err
+			}
+		}
+	}
+	if callbacks.Quote == nil {
+		return nil
+	}
+	for _, row := range rows {
+		if !matchesLanguage(request.Language, row.IsIcelandic) || !matchesExportTopic(request, row.TopicId, row.TopicName) {
+			continue
+		}
+		if err := callbacks.Quote(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//matchesExportTopic is true if the topic is the one the export asks for, by id or else by name, or if it asks for none
+func matchesExportTopic(request structs.Request, topicId int, topicName string) bool {
+	if request.TopicId > 0 {
+		return topicId == request.TopicId
+	}
+	return request.Topic == "" || strings.EqualFold(topicName, request.Topic)
+}
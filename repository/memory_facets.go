@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//matchesFacetFilters mirrors facetFiltersSQL, the row's author is one of the authorIds and its quote is in one of the
+//topicIds
+func (store *memoryStore) matchesFacetFilters(request structs.Request, row structs.TopicViewDBModel) bool {
+	if len(request.AuthorIds) > 0 && !containsId(request.AuthorIds, row.AuthorId) {
+		return false
+	}
+	if len(request.TopicIds) == 0 {
+		return true
+	}
+	for _, topicId := range request.TopicIds {
+		if store.isInTopic(topicId, row.QuoteId) {
+			return true
+		}
+	}
+	return false
+}
+
+//facets mirrors the facets of the Postgres repository, the matched quotes by language and the limit topics and
+//authors with the most of them
+func (store *memoryStore) facets(ranked []rankedTopicView, limit int) structs.FacetsDBModel {
+	facets := structs.FacetsDBModel{}
+	quoteIds := []int{}
+	authors := map[int]*structs.FacetDBModel{}
+	for _, rank := range ranked {
+		if containsId(quoteIds, rank.row.QuoteId) {
+			continue
+		}
+		quoteIds = append(quoteIds, rank.row.QuoteId)
+		if rank.row.IsIcelandic {
+			facets.Icelandic++
+		} else {
+			facets.English++
+		}
+		if authors[rank.row.AuthorId] == nil {
+			authors[rank.row.AuthorId] = &structs.FacetDBModel{Id: rank.row.AuthorId, Name: rank.row.Name}
+		}
+		authors[rank.row.AuthorId].Count++
+	}
+
+	topics := map[int]*structs.FacetDBModel{}
+	for _, row := range store.topicsView() {
+		if !containsId(quoteIds, row.QuoteId) {
+			continue
+		}
+		if topics[row.TopicId] == nil {
+			topics[row.TopicId] = &structs.FacetDBModel{Id: row.TopicId, Name: row.TopicName}
+		}
+		topics[row.TopicId].Count++
+	}
+
+	facets.Topics = topFacets(topics, limit)
+	facets.Authors = topFacets(authors, limit)
+	return facets
+}
+
+//topFacets returns the limit facets with the highest counts, the lowest id first when they are tied
+func topFacets(counts map[int]*structs.FacetDBModel, limit int) []structs.FacetDBModel {
+	facets := []structs.FacetDBModel{}
+	for _, facet := range counts {
+		facets = append(facets, *facet)
+	}
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Id < facets[j].Id
+	})
+	if len(facets) > limit {
+		facets = facets[:limit]
+	}
+	return facets
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+func (repo *quotesMemory) Insert(newQuote structs.QuoteInsertModel) (structs.QuoteDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	return repo.store.insertQuote(newQuote)
+}
+
+//insertQuote is the insert of Insert, the caller holds the lock
+func (store *memoryStore) insertQuote(newQuote structs.QuoteInsertModel) (structs.QuoteDBModel, error) {
+	if existing := store.existingQuote(newQuote.Quote, newQuote.UserId); existing != nil {
+		return *existing, ErrQuoteExists
+	}
+
+	//Nothing is changed until the author and the topics are known to be live, there is no transaction to roll back
+	for _, topicName := range newQuote.Topics {
+		if topic := store.topicByName(topicName); topic != nil && store.isDeleted(TopicsTable, topic.Id) {
+			return structs.QuoteDBModel{}, ErrTopicDeleted
+		}
+	}
+	var author *structs.AuthorDBModel
+	if newQuote.AuthorId > 0 {
+		if author = store.author(newQuote.AuthorId); author == nil || store.isDeleted(AuthorsTable, author.Id) {
+			return structs.QuoteDBModel{}, ErrNotFound
+		}
+	} else {
+		var err error
+		if author, err = store.upsertAuthor(newQuote.Author); err != nil {
+			return structs.QuoteDBModel{}, err
+		}
+	}
+	quote := &structs.QuoteDBModel{Id: store.nextQuoteId(), AuthorId: author.Id, Quote: newQuote.Quote, IsIcelandic: newQuote.IsIcelandic, IsPrivate: newQuote.IsPrivate, UserId: newQuote.UserId}
+	store.quotes = append(store.quotes, quote)
+
+	for _, topicName := range newQuote.Topics {
+		store.linkTopic(topicName, *quote)
+	}
+	store.recomputeAuthorCounters()
+	store.recomputeTopicCounters()
+	return *quote, nil
+}
+
+//existingQuote returns the live quote, public or private to the user, with the same text or, failing that, the first
+//one with the same normalized text. Soft deleted quotes and other users' private quotes are not duplicates
+func (store *memoryStore) existingQuote(text string, userId int) *structs.QuoteDBModel {
+	live := []*structs.QuoteDBModel{}
+	for _, quote := range store.quotes {
+		if !store.isDeleted(QuotesTable, quote.Id) && (!quote.IsPrivate || quote.UserId == userId) {
+			live = append(live, quote)
+		}
+	}
+	for _, quote := range live {
+		if quote.Quote == text {
+			return quote
+		}
+	}
+	normalized := normalizeQuote(text)
+	for _, quote := range live {
+		if normalized != "" && normalizeQuote(quote.Quote) == normalized {
+			return quote
+		}
+	}
+	return nil
+}
+
+//liveDuplicate reports whether another live quote has the same text as the quote and is public like it or private to
+//the same user, i.e. whether the quote would break the unique indexes of 0024_unique_live_visible_quotes
+func (store *memoryStore) liveDuplicate(quote structs.QuoteDBModel) bool {
+	for _, other := range store.quotes {
+		if other.Id != quote.Id && other.Quote == quote.Quote && !store.isDeleted(QuotesTable, other.Id) &&
+			other.IsPrivate == quote.IsPrivate && (!quote.IsPrivate || other.UserId == quote.UserId) {
+			return true
+		}
+	}
+	return false
+}
+
+//upsertAuthor returns the live author with the given name or alias, inserting the author if needed. ErrAuthorDeleted
+//if only a soft deleted author has the name
+func (store *memoryStore) upsertAuthor(name string) (*structs.AuthorDBModel, error) {
+	if author := store.aliasAuthor(name); author != nil {
+		return author, nil
+	}
+	id := 1
+	for _, author := range store.authors {
+		if author.Name == name && store.isDeleted(AuthorsTable, author.Id) {
+			return nil, ErrAuthorDeleted
+		}
+		if author.Name == name {
+			return author, nil
+		}
+		if author.Id >= id {
+			id = author.Id + 1
+		}
+	}
+	author := &structs.AuthorDBModel{Id: id, Name: name}
+	store.authors = append(store.authors, author)
+	return author, nil
+}
+
+//topicByName returns the topic with the given name (case insensitive), live or deleted
+func (store *memoryStore) topicByName(name string) *structs.TopicDBModel {
+	name = strings.TrimSpace(name)
+	for _, topic := range store.topics {
+		if strings.EqualFold(topic.Name, name) {
+			return topic
+		}
+	}
+	return nil
+}
+
+func (store *memoryStore) nextQuoteId() int {
+	id := 1
+	for _, quote := range store.quotes {
+		if quote.Id >= id {
+			id = quote.Id + 1
+		}
+	}
+	return id
+}
+
+//linkTopic links the quote to the topic with the given name (case insensitive), creating the topic if needed
+func (store *memoryStore) linkTopic(topicName string, quote structs.QuoteDBModel) {
+	topicName = strings.TrimSpace(topicName)
+	if topicName == "" {
+		return
+	}
+	topic := store.topicByName(topicName)
+	if topic == nil {
+		id := 1
+		for _, existing := range store.topics {
+			if existing.Id >= id {
+				id = existing.Id + 1
+			}
+		}
+		topic = &structs.TopicDBModel{Id: id, Name: topicName, IsIcelandic: quote.IsIcelandic}
+		store.topics = append(store.topics, topic)
+	}
+	if !store.isInTopic(topic.Id, quote.Id) {
+		store.topicsToQuotes = append(store.topicsToQuotes, TopicToQuote{TopicId: topic.Id, QuoteId: quote.Id})
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//ofTheDayDateFormat is how Postgres returns the date column of the of-the-day views
+const ofTheDayDateFormat = "2006-01-02T00:00:00Z"
+
+type ofTheDayMemory struct {
+	store *memoryStore
+}
+
+func (repo *ofTheDayMemory) GetQuoteOfTheDay(language string) (structs.QodViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quoteId, ok := repo.store.qods[languageKey(language)][today()]
+	if !ok {
+		return structs.QodViewDBModel{}, nil
+	}
+	return repo.qodView(quoteId, today()), nil
+}
+
+func (repo *ofTheDayMemory) QuoteOfTheDayHistory(language string, minimum string) ([]structs.QodViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.QodViewDBModel{}
+	for _, date := range historyDates(repo.store.qods[languageKey(language)], minimum) {
+		if quote := repo.qodView(repo.store.qods[languageKey(language)][date], date); quote.QuoteId != 0 {
+			quotes = append(quotes, quote)
+		}
+	}
+	return quotes, nil
+}
+
+func (repo *ofTheDayMemory) SetQuoteOfTheDay(language string, date string, quoteId int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	return repo.setQuoteOfTheDay(language, date, quoteId)
+}
+
+func (repo *ofTheDayMemory) SetRandomQuoteOfTheDay(language string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	candidates := []int{}
+	for _, quote := range repo.store.publicQuotes() {
+		if matchesLanguage(language, quote.IsIcelandic) {
+			candidates = append(candidates, quote.Id)
+		}
+	}
+	if len(candidates) == 0 {
+		return ErrNotFound
+	}
+	return repo.setQuoteOfTheDay(language, today(), candidates[rand.Intn(len(candidates))])
+}
+
+func (repo *ofTheDayMemory) GetAuthorOfTheDay(language string) (structs.AodDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authorId, ok := repo.store.aods[languageKey(language)][today()]
+	if !ok {
+		return structs.AodDBModel{}, nil
+	}
+	return repo.aodView(authorId, today()), nil
+}
+
+func (repo *ofTheDayMemory) AuthorOfTheDayHistory(language string, minimum string) ([]structs.AodDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	authors := []structs.AodDBModel{}
+	for _, date := range historyDates(repo.store.aods[languageKey(language)], minimum) {
+		if author := repo.aodView(repo.store.aods[languageKey(language)][date], date); author.Id != 0 {
+			authors = append(authors, author)
+		}
+	}
+	return authors, nil
+}
+
+func (repo *ofTheDayMemory) SetAuthorOfTheDay(language string, date string, authorId int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	return repo.setAuthorOfTheDay(language, date, authorId)
+}
+
+func (repo *ofTheDayMemory) SetRandomAuthorOfTheDay(language string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	candidates := []int{}
+	for _, author := range repo.store.liveAuthors() {
+		if matchesAuthorLanguage(languageKey(language), *author) {
+			candidates = append(candidates, author.Id)
+		}
+	}
+	if len(candidates) == 0 {
+		return ErrNotFound
+	}
+	return repo.setAuthorOfTheDay(language, today(), candidates[rand.Intn(len(candidates))])
+}
+
+//repointOfTheDay mirrors the update of qod / qodice when quotes are merged, the days of the duplicates become the
+//survivor's
+func (store *memoryStore) repointOfTheDay(survivorId int, duplicateIds []int) {
+	for _, qods := range store.qods {
+		for date, quoteId := range qods {
+			if containsId(duplicateIds, quoteId) {
+				qods[date] = survivorId
+			}
+		}
+	}
+}
+
+//setQuoteOfTheDay mirrors the insert into qod / qodice, the quote must exist and be in the given language
+func (repo *ofTheDayMemory) setQuoteOfTheDay(language string, date string, quoteId int) error {
+	quote := repo.store.quote(quoteId)
+	if quote == nil || quote.IsPrivate || repo.store.isDeleted(QuotesTable, quoteId) || !matchesLanguage(languageKey(language), quote.IsIcelandic) {
+		return ErrNotFound
+	}
+	repo.store.qods[languageKey(language)][date] = quoteId
+	return nil
+}
+
+//setAuthorOfTheDay mirrors the insert into aod / aodice, the author must have quotes in the given language
+func (repo *ofTheDayMemory) setAuthorOfTheDay(language string, date string, authorId int) error {
+	author := repo.store.author(authorId)
+	if author == nil || repo.store.isDeleted(AuthorsTable, authorId) || !matchesAuthorLanguage(languageKey(language), *author) {
+		return ErrNotFound
+	}
+	repo.store.aods[languageKey(language)][date] = authorId
+	return nil
+}
+
+func (repo *ofTheDayMemory) qodView(quoteId int, date string) structs.QodViewDBModel {
+	//Like qodview the deleted quotes, and quotes of deleted authors, are left out
+	quote := repo.store.quote(quoteId)
+	if quote == nil || quote.IsPrivate || repo.store.isDeleted(QuotesTable, quoteId) || repo.store.isDeleted(AuthorsTable, quote.AuthorId) {
+		return structs.QodViewDBModel{}
+	}
+	view := structs.QodViewDBModel{QuoteId: quote.Id, Quote: quote.Quote, AuthorId: quote.AuthorId, IsIcelandic: quote.IsIcelandic, Date: ofTheDayDate(date)}
+	if author := repo.store.author(quote.AuthorId); author != nil {
+		view.Name = author.Name
+	}
+	return view
+}
+
+func (repo *ofTheDayMemory) aodView(authorId int, date string) structs.AodDBModel {
+	author := repo.store.author(authorId)
+	if author == nil || repo.store.isDeleted(AuthorsTable, authorId) {
+		return structs.AodDBModel{}
+	}
+	return structs.AodDBModel{Id: author.Id, Name: author.Name, Date: ofTheDayDate(date)}
+}
+
+//historyDates returns the dates, between minimum (if set) and today, newest first
+func historyDates(days map[string]int, minimum string) []string {
+	dates := []string{}
+	for date := range days {
+		if (minimum == "" || date >= minimum) && date <= today() {
+			dates = append(dates, date)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+	return dates
+}
+
+func ofTheDayDate(date string) string {
+	return date + ofTheDayDateFormat[len(dateLayout):]
+}
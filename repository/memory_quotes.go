@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type quotesMemory struct {
+	store *memoryStore
+}
+
+func (repo *quotesMemory) GetQuotes(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.SearchViewDBModel{}
+	for _, row := range repo.store.searchView() {
+		if request.AuthorId > 0 && row.AuthorId == request.AuthorId {
+			quotes = append(quotes, row)
+		} else if request.AuthorId <= 0 && containsId(request.Ids, row.QuoteId) {
+			quotes = append(quotes, row)
+		}
+	}
+	if request.AuthorId > 0 {
+		start, end := pageBounds(request, len(quotes))
+		quotes = quotes[start:end]
+	}
+	return quotes, nil
+}
+
+func (repo *quotesMemory) GetPrivateQuotes(userId int, request structs.Request) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.SearchViewDBModel{}
+	for _, quote := range repo.store.liveQuotes() {
+		author := repo.store.author(quote.AuthorId)
+		if !quote.IsPrivate || quote.UserId != userId || author == nil || repo.store.isDeleted(AuthorsTable, author.Id) {
+			continue
+		}
+		if (request.AuthorId > 0 && author.Id == request.AuthorId) || (request.AuthorId <= 0 && containsId(request.Ids, quote.Id)) {
+			quotes = append(quotes, structs.SearchViewDBModel{
+				AuthorId:    author.Id,
+				Name:        author.Name,
+				QuoteId:     quote.Id,
+				Quote:       quote.Quote,
+				IsIcelandic: quote.IsIcelandic,
+				QuoteCount:  quote.Count,
+				AuthorCount: author.Count,
+			})
+		}
+	}
+	if request.AuthorId > 0 {
+		start, end := pageBounds(request, len(quotes))
+		quotes = quotes[start:end]
+	}
+	return quotes, nil
+}
+
+func (repo *quotesMemory) GetByAuthor(authorId int, language string, limit int) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes := []structs.SearchViewDBModel{}
+	for _, row := range repo.store.searchView() {
+		if row.AuthorId == authorId && matchesLanguage(language, row.IsIcelandic) && len(quotes) < limit {
+			quotes = append(quotes, row)
+		}
+	}
+	return quotes, nil
+}
+
+func (repo *quotesMemory) List(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes, err := repo.listed(request)
+	if err != nil {
+		return quotes, err
+	}
+	start, end := pageBounds(afterCursor(request), len(quotes))
+	return quotes[start:end], nil
+}
+
+func (repo *quotesMemory) ListTotal(request structs.Request) (structs.TotalDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	quotes, err := repo.listed(request)
+	return memoryTotal(len(quotes)), err
+}
+
+//listed returns all the quotes List pages through, after the cursor, in the order of the list
+func (repo *quotesMemory) listed(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	quotes := []structs.SearchViewDBModel{}
+	var key func(row structs.SearchViewDBModel) int
+	descending := request.OrderConfig.Reverse
+	switch strings.ToLower(request.OrderConfig.OrderBy) {
+	case "popularity":
+		key = func(row structs.SearchViewDBModel) int { return row.QuoteCount }
+		descending = !request.OrderConfig.Reverse
+	case "length":
+		key = func(row structs.SearchViewDBModel) int { return utf8.RuneCountInString(row.Quote) }
+	default:
+		key = func(row structs.SearchViewDBModel) int { return row.QuoteId }
+	}
+
+	for _, row := range repo.store.searchView() {
+		if !matchesLanguage(request.Language, row.IsIcelandic) || !repo.store.matchesSource(request.Verified, row.QuoteId) {
+			continue
+		}
+		if strings.ToLower(request.OrderConfig.OrderBy) != "popularity" && !withinMaxMin(request.OrderConfig, key(row)) {
+			continue
+		}
+		quotes = append(quotes, row)
+	}
+
+	sort.SliceStable(quotes, func(i, j int) bool {
+		if key(quotes[i]) != key(quotes[j]) {
+			return (key(quotes[i]) < key(quotes[j])) != descending
+		}
+		return quotes[i].QuoteId < quotes[j].QuoteId
+	})
+
+	if request.Cursor == "" {
+		return quotes, nil
+	}
+	column, orderDirection := quotesListOrder(request.OrderConfig)
+	cursor, err := decodeCursor(request.Cursor, "quotes "+column+" "+orderDirection)
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range quotes {
+		if isAfterCursor(cursor, column, orderDirection, quotesListKey(column, row), row.QuoteId) {
+			return quotes[i:], nil
+		}
+	}
+	return []structs.SearchViewDBModel{}, nil
+}
+
+func (repo *quotesMemory) Random(request structs.Request) (structs.TopicViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	var rows []structs.TopicViewDBModel
+	if request.TopicId > 0 {
+		rows = repo.store.topicsView()
+	} else {
+		rows = searchViewAsTopicView(repo.store.searchView())
+	}
+
+	query, err := parseSearchQuery(request.SearchString)
+	if err != nil {
+		return structs.TopicViewDBModel{}, err
+	}
+	candidates := []structs.TopicViewDBModel{}
+	for _, row := range rows {
+		if request.TopicId > 0 && row.TopicId != request.TopicId {
+			continue
+		}
+		if request.AuthorId > 0 && row.AuthorId != request.AuthorId {
+			continue
+		}
+		if !matchesLanguage(request.Language, row.IsIcelandic) || !repo.store.matchesSource(request.Verified, row.QuoteId) {
+			continue
+		}
+		rowQuery, quote := query.forRow(row), quoteLexemes(row)
+		if len(query.groups) > 0 && !rowQuery.matchesPlain(quote) && !rowQuery.matchesPhrases(quote) {
+			continue
+		}
+		if !repo.store.matchesQueryFilters(rowQuery, row, quote) {
+			continue
+		}
+		candidates = append(candidates, row)
+	}
+
+	if len(candidates) == 0 {
+		return structs.TopicViewDBModel{}, nil
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+//rankedTopicView is a row of the search results along with what it is ordered by
+type rankedTopicView struct {
+	row         structs.TopicViewDBModel
+	plainRank   float64
+	phraseRank  float64
+	generalRank float64
+	similarity  float64
+}
+
+func (repo *quotesMemory) Search(request structs.Request) (structs.SearchResultsDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	ranked, query, err := repo.searchMatches(request)
+	if err != nil {
+		return structs.SearchResultsDBModel{Results: []structs.TopicViewDBModel{}, Strategy: StrategyFullText}, err
+	}
+	return repo.searchResults(request, ranked, query), nil
+}
+
+func (repo *quotesMemory) SearchQuotes(request structs.Request) (structs.SearchResultsDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	ranked, query, err := repo.searchQuotesMatches(request)
+	if err != nil {
+		return structs.SearchResultsDBModel{Results: []structs.TopicViewDBModel{}, Strategy: StrategyFullText}, err
+	}
+	return repo.searchResults(request, ranked, query), nil
+}
+
+//searchResults mirrors quotesPostgres.searchResults, the page of the ranked rows along with, if the request asks for
+//them, the total and the facets of all of them
+func (repo *quotesMemory) searchResults(request structs.Request, ranked []rankedTopicView, query searchQuery) structs.SearchResultsDBModel {
+	results := structs.SearchResultsDBModel{Results: withHighlights(pageOfRanked(request, ranked), query, request.Highlight)}
+	results.Strategy = resultsStrategy(results.Results)
+	if request.WithPagination {
+		results.Total = memoryTotal(len(ranked))
+	}
+	if request.Facets != nil {
+		results.Facets = repo.store.facets(ranked, request.Facets.Limit)
+	}
+	return results
+}
+
+//searchMatches returns all the rows the general search matches, in the order of the search, each with the strategy it
+//is matched with
+func (repo *quotesMemory) searchMatches(request structs.Request) ([]rankedTopicView, searchQuery, error) {
+	query, err := parseSearchQuery(request.SearchString)
+	if err != nil || query.isEmpty() {
+		return []rankedTopicView{}, query, err
+	}
+	rows := []structs.TopicViewDBModel{}
+	for _, row := range repo.searchRows(request) {
+		if repo.store.matchesQueryFilters(query.forRow(row), row, append(nameLexemes(row.Name), quoteLexemes(row)...)) {
+			rows = append(rows, row)
+		}
+	}
+	if ranked, fellBack := fallbackRanked(query, searchConfigs(request.Language, query), rows); fellBack {
+		return ranked, query, nil
+	}
+
+	ranked := []rankedTopicView{}
+	for _, row := range rows {
+		rowQuery, text := query.forRow(row), append(nameLexemes(row.Name), quoteLexemes(row)...)
+		rank := rankRow(row, rowQuery, text)
+		rank.row.Strategy = StrategyFullText
+		rank.similarity = similarity(row.Name, query.text())
+
+		nameWordMatch := false
+		for _, word := range strings.Split(row.Name, " ") {
+			if similarity(query.text(), word) >= similarityThreshold {
+				nameWordMatch = true
+			}
+		}
+		if len(query.groups) > 0 && rank.plainRank == 0 && rank.phraseRank == 0 && rank.generalRank == 0 && !nameWordMatch {
+			continue
+		}
+		ranked = append(ranked, rank)
+	}
+
+	//Order by authorid to have definitive order (when for example some quotes rank the same for plain, phrase, general and similarity)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.phraseRank != b.phraseRank {
+			return a.phraseRank > b.phraseRank
+		}
+		if a.similarity != b.similarity {
+			return a.similarity > b.similarity
+		}
+		if a.plainRank != b.plainRank {
+			return a.plainRank > b.plainRank
+		}
+		if a.generalRank != b.generalRank {
+			return a.generalRank > b.generalRank
+		}
+		return a.row.AuthorId > b.row.AuthorId
+	})
+	return ranked, query, nil
+}
+
+//searchQuotesMatches returns all the rows the quotes search matches, in the order of the search, each with the
+//strategy it is matched with
+func (repo *quotesMemory) searchQuotesMatches(request structs.Request) ([]rankedTopicView, searchQuery, error) {
+	query, err := parseSearchQuery(request.SearchString)
+	if err != nil || query.isEmpty() {
+		return []rankedTopicView{}, query, err
+	}
+	rows := []structs.TopicViewDBModel{}
+	for _, row := range repo.searchRows(request) {
+		if request.AuthorId > 0 && row.AuthorId != request.AuthorId {
+			continue
+		}
+		if repo.store.matchesQueryFilters(query.forRow(row), row, quoteLexemes(row)) {
+			rows = append(rows, row)
+		}
+	}
+	if ranked, fellBack := fallbackRanked(query, searchConfigs(request.Language, query), rows); fellBack {
+		return ranked, query, nil
+	}
+
+	ranked := []rankedTopicView{}
+	for _, row := range rows {
+		rowQuery, text := query.forRow(row), quoteLexemes(row)
+		rank := rankRow(row, rowQuery, text)
+		rank.row.Strategy = StrategyFullText
+		if len(query.groups) > 0 && rank.plainRank == 0 && rank.phraseRank == 0 && rank.generalRank == 0 {
+			continue
+		}
+		ranked = append(ranked, rank)
+	}
+
+	//Order by quote_id to have definitive order (when for example some quotes rank the same for plain, phrase and general)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.plainRank != b.plainRank {
+			return a.plainRank > b.plainRank
+		}
+		if a.phraseRank != b.phraseRank {
+			return a.phraseRank > b.phraseRank
+		}
+		if a.generalRank != b.generalRank {
+			return a.generalRank > b.generalRank
+		}
+		return a.row.QuoteId > b.row.QuoteId
+	})
+	return ranked, query, nil
+}
+
+func (repo *quotesMemory) IncrementCount(quoteIds []int, by int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, quote := range repo.store.quotes {
+		if containsId(quoteIds, quote.Id) {
+			quote.Count += by
+		}
+	}
+	return nil
+}
+
+//searchRows returns the rows to search through, the topicsview rows of the topic if topicId is set otherwise the searchview
+func (repo *quotesMemory) searchRows(request structs.Request) []structs.TopicViewDBModel {
+	rows := []structs.TopicViewDBModel{}
+	var all []structs.TopicViewDBModel
+	if request.TopicId > 0 {
+		all = repo.store.topicsView()
+	} else {
+		all = searchViewAsTopicView(repo.store.searchView())
+	}
+	for _, row := range all {
+		if request.TopicId > 0 && row.TopicId != request.TopicId {
+			continue
+		}
+		if !matchesLanguage(request.Language, row.IsIcelandic) || !repo.store.matchesSource(request.Verified, row.QuoteId) {
+			continue
+		}
+		if !repo.store.matchesFacetFilters(request, row) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+//rankRow mirrors the plain, phrase and general ranks of the row
+func rankRow(row structs.TopicViewDBModel, query searchQuery, text []string) rankedTopicView {
+	rank := rankedTopicView{row: row, generalRank: query.generalRatio(text)}
+	if query.matchesPlain(text) {
+		rank.plainRank = 1
+	}
+	if query.matchesPhrases(text) {
+		rank.phraseRank = 1
+	}
+	return rank
+}
+
+func pageOfRanked(request structs.Request, ranked []rankedTopicView) []structs.TopicViewDBModel {
+	start, end := pageBounds(request, len(ranked))
+	results := []structs.TopicViewDBModel{}
+	for _, rank := range ranked[start:end] {
+		results = append(results, rank.row)
+	}
+	return results
+}
+
+//withinMaxMin mirrors maxMinNumberSQL, the orderConfig's minimum / maximum are only used if they are numbers
+func withinMaxMin(orderConfig structs.OrderConfig, value int) bool {
+	if nr, err := strconv.Atoi(orderConfig.Maximum); err == nil && value > nr {
+		return false
+	}
+	if nr, err := strconv.Atoi(orderConfig.Minimum); err == nil && value < nr {
+		return false
+	}
+	return true
+}
+
+func containsId(ids []int, id int) bool {
+	for _, listId := range ids {
+		if listId == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//Related mirrors the Postgres repository, the quotes are scored with the same weights by their lexemes, topics and
+//author
+func (repo *quotesMemory) Related(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	rows := repo.store.searchView()
+	var quote *structs.SearchViewDBModel
+	for i := range rows {
+		if rows[i].QuoteId == request.Id {
+			quote = &rows[i]
+		}
+	}
+	if quote == nil {
+		return nil, ErrNotFound
+	}
+	targetLexemes := distinctLexemes(*quote)
+	targetTopics := repo.store.quoteTopicIds(quote.QuoteId)
+
+	type scoredQuote struct {
+		row   structs.SearchViewDBModel
+		score float64
+	}
+	scored := []scoredQuote{}
+	for _, row := range rows {
+		if row.QuoteId == quote.QuoteId || !matchesLanguage(request.Language, row.IsIcelandic) {
+			continue
+		}
+		sameAuthor := row.AuthorId == quote.AuthorId
+		if request.ExcludeSameAuthor && sameAuthor {
+			continue
+		}
+
+		lexemes := distinctLexemes(row)
+		sharedLexemes := 0
+		for lexeme := range lexemes {
+			if targetLexemes[lexeme] {
+				sharedLexemes++
+			}
+		}
+		sharedTopics := 0
+		for _, topicId := range repo.store.quoteTopicIds(row.QuoteId) {
+			if containsId(targetTopics, topicId) {
+				sharedTopics++
+			}
+		}
+		if sharedLexemes == 0 && sharedTopics == 0 && !(request.SameAuthor && sameAuthor) {
+			continue
+		}
+
+		score := 0.0
+		if union := len(targetLexemes) + len(lexemes) - sharedLexemes; union > 0 {
+			score += relatedLexemeWeight * float64(sharedLexemes) / float64(union)
+		}
+		if len(targetTopics) > 0 {
+			score += relatedTopicWeight * float64(sharedTopics) / float64(len(targetTopics))
+		}
+		if request.SameAuthor && sameAuthor {
+			score += relatedAuthorWeight
+		}
+		scored = append(scored, scoredQuote{row: row, score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].row.QuoteId < scored[j].row.QuoteId
+	})
+	quotes := []structs.SearchViewDBModel{}
+	for _, score := range scored {
+		quotes = append(quotes, score.row)
+	}
+	start, end := pageBounds(request, len(quotes))
+	return quotes[start:end], nil
+}
+
+//distinctLexemes mirrors tsvector_to_array of the row's quote_tsv
+func distinctLexemes(row structs.SearchViewDBModel) map[string]bool {
+	distinct := map[string]bool{}
+	for _, lexeme := range quoteLexemes(structs.TopicViewDBModel{Quote: row.Quote, IsIcelandic: row.IsIcelandic}) {
+		distinct[lexeme] = true
+	}
+	return distinct
+}
+
+//quoteTopicIds returns the ids of the topics the quote is in
+func (store *memoryStore) quoteTopicIds(quoteId int) []int {
+	topicIds := []int{}
+	for _, link := range store.topicsToQuotes {
+		if link.QuoteId == quoteId && !containsId(topicIds, link.TopicId) {
+			topicIds = append(topicIds, link.TopicId)
+		}
+	}
+	return topicIds
+}
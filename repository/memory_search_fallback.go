@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//wordSimilarityThreshold is pg_trgm's default word_similarity_threshold, the least similarity of <%
+const wordSimilarityThreshold = 0.6
+
+//fallbackRanked mirrors matchSQL, strategySQL and fallbackOrderSQL among the rows that pass the query's filters. If
+//the query is only stop words it returns the rows similar by trigrams to its phrase, the ones with the whole phrase
+//first, and true, otherwise false and the rows are matched by the full text search
+func fallbackRanked(query searchQuery, configs []string, rows []structs.TopicViewDBModel) ([]rankedTopicView, bool) {
+	if !memoryStopWords(query, configs) {
+		return []rankedTopicView{}, false
+	}
+	phrase := query.phrase()
+	ranked := []rankedTopicView{}
+	for _, row := range rows {
+		normalized := normalizeQuote(row.Quote)
+		row.Strategy = StrategyTrigram
+		if strings.Contains(" "+normalized+" ", " "+phrase+" ") {
+			row.Strategy = StrategyPhrase
+		} else if wordSimilarity(phrase, normalized) < wordSimilarityThreshold {
+			continue
+		}
+		ranked = append(ranked, rankedTopicView{row: row, similarity: similarity(row.Quote, phrase)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.row.Strategy != b.row.Strategy {
+			return a.row.Strategy == StrategyPhrase
+		}
+		if a.similarity != b.similarity {
+			return a.similarity > b.similarity
+		}
+		return a.row.QuoteId > b.row.QuoteId
+	})
+	return ranked, true
+}
+
+//memoryStopWords mirrors stopWordsSQL with the stop words of the in-memory stand-in for the english configuration
+//and none for the icelandic one, whose lexemes are the distinct words
+func memoryStopWords(query searchQuery, configs []string) bool {
+	if query.hasOperators() || len(query.groups) == 0 {
+		return false
+	}
+	for _, config := range configs {
+		kept := lexemes(query.text())
+		if config == icelandicSearchConfig {
+			kept = distinctWords(words(query.text()))
+		}
+		if len(kept) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func distinctWords(all []string) []string {
+	seen := map[string]bool{}
+	distinct := []string{}
+	for _, word := range all {
+		if !seen[word] {
+			seen[word] = true
+			distinct = append(distinct, word)
+		}
+	}
+	return distinct
+}
+
+//wordSimilarity approximates pg_trgm's word_similarity, the greatest similarity of the phrase to a run of as many
+//words of the text
+func wordSimilarity(phrase string, text string) float64 {
+	phraseWords, textWords := words(phrase), words(text)
+	greatest := 0.0
+	for i := 0; i+len(phraseWords) <= len(textWords); i++ {
+		if s := similarity(phrase, strings.Join(textWords[i:i+len(phraseWords)], " ")); s > greatest {
+			greatest = s
+		}
+	}
+	return greatest
+}
+
+func filterRows(rows []structs.TopicViewDBModel, keep func(structs.TopicViewDBModel) bool) []structs.TopicViewDBModel {
+	kept := []structs.TopicViewDBModel{}
+	for _, row := range rows {
+		if keep(row) {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//stopWords are the in-memory stand-in for the stop words of the english dictionary of Postgres. Only the in-memory
+//repositories use them, the Postgres ones ask the text search configurations
+var stopWords = map[string]bool{
+	"a": true, "about": true, "above": true, "after": true, "again": true, "against": true, "all": true, "am": true,
+	"an": true, "and": true, "any": true, "are": true, "as": true, "at": true, "be": true, "because": true,
+	"been": true, "before": true, "being": true, "below": true, "between": true, "both": true, "but": true, "by": true,
+	"can": true, "did": true, "do": true, "does": true, "doing": true, "down": true, "during": true, "each": true,
+	"few": true, "for": true, "from": true, "further": true, "had": true, "has": true, "have": true, "having": true,
+	"he": true, "her": true, "here": true, "hers": true, "herself": true, "him": true, "himself": true, "his": true,
+	"how": true, "i": true, "if": true, "in": true, "into": true, "is": true, "it": true, "its": true,
+	"itself": true, "just": true, "me": true, "more": true, "most": true, "my": true, "myself": true, "no": true,
+	"nor": true, "not": true, "now": true, "of": true, "off": true, "on": true, "once": true, "only": true,
+	"or": true, "other": true, "our": true, "ours": true, "ourselves": true, "out": true, "over": true, "own": true,
+	"same": true, "she": true, "should": true, "so": true, "some": true, "such": true, "than": true, "that": true,
+	"the": true, "their": true, "theirs": true, "them": true, "themselves": true, "then": true, "there": true, "these": true,
+	"they": true, "this": true, "those": true, "through": true, "to": true, "too": true, "under": true, "until": true,
+	"up": true, "very": true, "was": true, "we": true, "were": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "who": true, "whom": true, "why": true, "will": true, "with": true, "you": true,
+	"your": true, "yours": true, "yourself": true, "yourselves": true, "s": true, "t": true, "don": true,
+}
+
+//lexemes returns the words of the text without the stop words, a rough stand-in for to_tsvector / plainto_tsquery
+func lexemes(text string) []string {
+	result := []string{}
+	for _, word := range words(text) {
+		if !stopWords[word] {
+			result = append(result, word)
+		}
+	}
+	return result
+}
+
+//forRow returns the query the way it is matched against the row. An Icelandic quote is in the icelandic text search
+//configuration, which folds the words of both the quote and the query
+func (query searchQuery) forRow(row structs.TopicViewDBModel) searchQuery {
+	if !row.IsIcelandic {
+		return query
+	}
+	fold := func(terms []queryTerm) []queryTerm {
+		folded := []queryTerm{}
+		for _, term := range terms {
+			folded = append(folded, queryTerm{text: foldIcelandic(term.text), phrase: term.phrase})
+		}
+		return folded
+	}
+	folded := searchQuery{excluded: fold(query.excluded), filters: query.filters, folded: true}
+	for _, group := range query.groups {
+		folded.groups = append(folded.groups, fold(group))
+	}
+	return folded
+}
+
+//quoteLexemes mirrors the quote_tsv of the row, which is in the configuration of the quote's language
+func quoteLexemes(row structs.TopicViewDBModel) []string {
+	if row.IsIcelandic {
+		return lexemes(foldIcelandic(row.Quote))
+	}
+	return lexemes(row.Quote)
+}
+
+//nameLexemes mirrors the tsv of the author's name, which is in both the english and the icelandic configuration
+func nameLexemes(name string) []string {
+	result := lexemes(name)
+	if folded := foldIcelandic(name); folded != strings.ToLower(name) {
+		result = append(result, lexemes(folded)...)
+	}
+	return result
+}
+
+//matchesTerm is true if the term is in the text, a phrase only if its words follow each other. A term of only stop
+//words is empty, like its tsquery, and matches nothing
+func matchesTerm(term queryTerm, text []string) bool {
+	if term.phrase {
+		return matchesPhrase(lexemes(term.text), text)
+	}
+	return matchesAll(lexemes(term.text), text)
+}
+
+//isEmptyTerm is true for a term of only stop words, which plainto_tsquery / phraseto_tsquery reduce to nothing
+func isEmptyTerm(term queryTerm) bool {
+	return len(lexemes(term.text)) == 0
+}
+
+//matchesPlain mirrors plainq, i.e. a term of each group is in the text. The empty terms are left out the same way
+//&& and || leave out an empty tsquery, a query of only empty terms matches nothing
+func (query searchQuery) matchesPlain(text []string) bool {
+	matched := false
+	for _, group := range query.groups {
+		groupMatched, groupEmpty := false, true
+		for _, term := range group {
+			if isEmptyTerm(term) {
+				continue
+			}
+			groupEmpty = false
+			groupMatched = groupMatched || matchesTerm(term, text)
+		}
+		if groupEmpty {
+			continue
+		}
+		if !groupMatched {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+//matchesPhrases mirrors phraseq, i.e. one of the phrases of the query is in the text
+func (query searchQuery) matchesPhrases(text []string) bool {
+	for _, phrase := range query.phrases() {
+		if matchesPhrase(lexemes(phrase), text) {
+			return true
+		}
+	}
+	return false
+}
+
+//generalRatio mirrors generalq, the fraction of the terms that are in the text
+func (query searchQuery) generalRatio(text []string) float64 {
+	terms, matched := 0, 0
+	for _, term := range query.terms() {
+		if isEmptyTerm(term) {
+			continue
+		}
+		terms++
+		if matchesTerm(term, text) {
+			matched++
+		}
+	}
+	if terms == 0 {
+		return 0
+	}
+	return float64(matched) / float64(terms)
+}
+
+//excludes is true if any of the excluded terms is in the text
+func (query searchQuery) excludes(text []string) bool {
+	for _, term := range query.excluded {
+		if !isEmptyTerm(term) && matchesTerm(term, text) {
+			return true
+		}
+	}
+	return false
+}
+
+//matchesQueryFilters mirrors searchQueryFiltersSQL, the excluded terms are matched against the text
+func (store *memoryStore) matchesQueryFilters(query searchQuery, row structs.TopicViewDBModel, text []string) bool {
+	if query.excludes(text) {
+		return false
+	}
+	for _, filter := range query.filters {
+		var matches bool
+		switch filter.field {
+		case queryFieldAuthor:
+			author := store.author(row.AuthorId)
+			matches = strings.EqualFold(author.Name, filter.value)
+			if alias := store.alias(filter.value); alias != nil && alias.AuthorId == row.AuthorId {
+				matches = true
+			}
+		case queryFieldTopic:
+			for _, topic := range store.liveTopics() {
+				if strings.EqualFold(topic.Name, filter.value) && store.isInTopic(topic.Id, row.QuoteId) {
+					matches = true
+				}
+			}
+		case queryFieldLanguage:
+			//The language of a negated filter is already the other language
+			if !matchesLanguage(filter.language(), row.IsIcelandic) {
+				return false
+			}
+			continue
+		}
+		if matches == filter.negated {
+			return false
+		}
+	}
+	return true
+}
+
+//headline mirrors ts_headline, the words of the quote that are in the terms of the query are put between the markers.
+//A quote of at most maxWords words is returned whole, otherwise each fragment starts at a matched word and has at most
+//maxWords words. A quote without any matched words is cut after half of maxWords words, like MinWords
+func (query searchQuery) headline(quote string, config structs.HighlightConfig) string {
+	matched := map[string]bool{}
+	for _, term := range query.terms() {
+		for _, lexeme := range lexemes(term.text) {
+			matched[lexeme] = true
+		}
+	}
+	//isMatched is true if the word is a lexeme of the query, folded if the query is
+	isMatched := func(word string) bool {
+		if query.folded {
+			return matched[foldIcelandic(word)]
+		}
+		return matched[strings.ToLower(word)]
+	}
+
+	//The quote in words and the separators between them, the positions of the words and which of them matched
+	tokens := []string{}
+	wordTokens := []int{}
+	matches := []int{}
+	runes := []rune(quote)
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && isWordRune(runes[end]) == isWordRune(runes[start]) {
+			end++
+		}
+		if isWordRune(runes[start]) {
+			if isMatched(string(runes[start:end])) {
+				matches = append(matches, len(wordTokens))
+			}
+			wordTokens = append(wordTokens, len(tokens))
+		}
+		tokens = append(tokens, string(runes[start:end]))
+		start = end
+	}
+
+	//render returns the tokens from (inclusive) to (inclusive)
+	render := func(from int, to int) string {
+		var builder strings.Builder
+		for idx := from; idx <= to; idx++ {
+			if isMatched(tokens[idx]) {
+				builder.WriteString(config.Start + tokens[idx] + config.Stop)
+			} else {
+				builder.WriteString(tokens[idx])
+			}
+		}
+		return builder.String()
+	}
+
+	nrOfWords := len(wordTokens)
+	switch {
+	case nrOfWords == 0:
+		return quote
+	case nrOfWords <= config.MaxWords:
+		return render(0, len(tokens)-1)
+	case len(matches) == 0:
+		return render(wordTokens[0], wordTokens[config.MaxWords/2-1])
+	}
+
+	maxFragments := config.MaxFragments
+	if maxFragments == 0 {
+		maxFragments = 1
+	}
+	fragments := []string{}
+	next := 0
+	for _, match := range matches {
+		if len(fragments) == maxFragments {
+			break
+		}
+		if match < next {
+			continue
+		}
+		start := match
+		//A single fragment is moved back to have maxWords words if the match is close to the end
+		if config.MaxFragments == 0 && start+config.MaxWords > nrOfWords {
+			start = nrOfWords - config.MaxWords
+		}
+		end := start + config.MaxWords
+		if end > nrOfWords {
+			end = nrOfWords
+		}
+		fragments = append(fragments, render(wordTokens[start], wordTokens[end-1]))
+		next = end
+	}
+	return strings.Join(fragments, " ... ")
+}
+
+//withHighlights sets the highlight of each of the rows if it is asked for
+func withHighlights(rows []structs.TopicViewDBModel, query searchQuery, config *structs.HighlightConfig) []structs.TopicViewDBModel {
+	if config == nil {
+		return rows
+	}
+	for idx := range rows {
+		rows[idx].Highlight = query.forRow(rows[idx]).headline(rows[idx].Quote, *config)
+	}
+	return rows
+}
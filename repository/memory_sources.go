@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// memoryCitation is the source_id and source_status of a quote, quotes without one are unverified and have no source
+type memoryCitation struct {
+	sourceId int
+	status   string
+}
+
+type sourcesMemory struct {
+	store *memoryStore
+}
+
+func (repo *sourcesMemory) Create(source *structs.SourceDBModel) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	source.Id = len(repo.store.sources) + 1
+	source.CreatedAt = time.Now()
+	created := *source
+	repo.store.sources = append(repo.store.sources, &created)
+	return nil
+}
+
+func (repo *sourcesMemory) Update(source structs.SourceDBModel) (structs.SourceDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	existing := repo.store.source(source.Id)
+	if existing == nil {
+		return source, ErrNotFound
+	}
+	existing.Title = source.Title
+	existing.Type = source.Type
+	existing.Year = source.Year
+	existing.Url = source.Url
+	return *existing, nil
+}
+
+func (repo *sourcesMemory) List(request structs.Request) ([]structs.SourceDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	sources := []structs.SourceDBModel{}
+	for _, source := range repo.store.sources {
+		if request.SearchString != "" && !strings.Contains(strings.ToLower(source.Title), strings.ToLower(request.SearchString)) {
+			continue
+		}
+		if request.Type != "" && source.Type != request.Type {
+			continue
+		}
+		sources = append(sources, *source)
+	}
+	sort.SliceStable(sources, func(i, j int) bool { return strings.ToLower(sources[i].Title) < strings.ToLower(sources[j].Title) })
+	start, end := pageBounds(request, len(sources))
+	return sources[start:end], nil
+}
+
+func (repo *sourcesMemory) Cite(quoteId int, sourceId int, status string) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if sourceId > 0 && repo.store.source(sourceId) == nil {
+		return ErrNotFound
+	}
+	if repo.store.quote(quoteId) == nil || repo.store.isDeleted(QuotesTable, quoteId) {
+		return ErrNotFound
+	}
+	repo.store.citations[quoteId] = memoryCitation{sourceId: sourceId, status: status}
+	return nil
+}
+
+func (repo *sourcesMemory) ForQuotes(quoteIds []int) ([]structs.CitationDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	citations := []structs.CitationDBModel{}
+	for _, quoteId := range quoteIds {
+		citation, ok := repo.store.citations[quoteId]
+		if !ok || (citation.sourceId == 0 && citation.status == structs.SourceUnverified) {
+			continue
+		}
+		row := structs.CitationDBModel{QuoteId: quoteId, Status: citation.status}
+		if source := repo.store.source(citation.sourceId); source != nil {
+			row.SourceId = source.Id
+			row.Title = source.Title
+			row.Type = source.Type
+			row.Year = source.Year
+			row.Url = source.Url
+		}
+		citations = append(citations, row)
+	}
+	return citations, nil
+}
+
+func (store *memoryStore) source(id int) *structs.SourceDBModel {
+	for _, source := range store.sources {
+		if source.Id == id {
+			return source
+		}
+	}
+	return nil
+}
+
+// mergeCitations mirrors the merge of the citations in the Postgres repository, the survivor keeps its own citation or
+// otherwise gets the best one of the duplicates, verified first, and the duplicates are left without
+func (store *memoryStore) mergeCitations(survivorId int, duplicateIds []int) {
+	best, bestId, cited := store.citations[survivorId], 0, isCited(store.citations[survivorId])
+	for _, quoteId := range duplicateIds {
+		citation := store.citations[quoteId]
+		rank, bestRank := citationRank(citation), citationRank(best)
+		if !cited && isCited(citation) && (rank < bestRank || (rank == bestRank && quoteId < bestId)) {
+			best, bestId = citation, quoteId
+		}
+		delete(store.citations, quoteId)
+	}
+	if isCited(best) {
+		store.citations[survivorId] = best
+	}
+}
+
+// isCited is whether the quote with the citation has a source or a status other than unverified
+func isCited(citation memoryCitation) bool {
+	return citation.sourceId > 0 || (citation.status != "" && citation.status != structs.SourceUnverified)
+}
+
+func citationRank(citation memoryCitation) int {
+	switch {
+	case !isCited(citation):
+		return 3
+	case citation.status == structs.SourceVerified:
+		return 0
+	case citation.status == structs.SourceMisattributed:
+		return 1
+	}
+	return 2
+}
+
+// matchesSource mirrors quoteSourceSQL
+func (store *memoryStore) matchesSource(verified bool, quoteId int) bool {
+	return !verified || store.citations[quoteId].status == structs.SourceVerified
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type submissionsMemory struct {
+	store *memoryStore
+}
+
+func (repo *submissionsMemory) Create(submission *structs.SubmissionDBModel) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.store.existingQuote(submission.Quote, submission.UserId) != nil {
+		return ErrQuoteExists
+	}
+	if submission.AuthorId > 0 {
+		if author := repo.store.author(submission.AuthorId); author == nil || repo.store.isDeleted(AuthorsTable, author.Id) {
+			return ErrNotFound
+		}
+		submission.Author = ""
+	}
+
+	submission.Id = len(repo.store.submissions) + 1
+	submission.Status = structs.SubmissionPending
+	submission.CreatedAt = time.Now()
+	if submission.Topics == "" {
+		submission.SetTopicNames(nil)
+	}
+	created := *submission
+	repo.store.submissions = append(repo.store.submissions, &created)
+	return nil
+}
+
+func (repo *submissionsMemory) Get(id int) (structs.SubmissionDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	if submission := repo.store.submission(id); submission != nil {
+		return *submission, nil
+	}
+	return structs.SubmissionDBModel{}, ErrNotFound
+}
+
+func (repo *submissionsMemory) List(userId int, status string, request structs.Request) ([]structs.SubmissionDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	//The submissions are appended in the order they were created
+	submissions := []structs.SubmissionDBModel{}
+	for _, submission := range repo.store.submissions {
+		if (userId <= 0 || submission.UserId == userId) && (status == "" || submission.Status == status) {
+			submissions = append(submissions, *submission)
+		}
+	}
+	start, end := pageBounds(request, len(submissions))
+	return submissions[start:end], nil
+}
+
+func (repo *submissionsMemory) Review(submission structs.SubmissionDBModel) (structs.SubmissionDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if err := repo.store.pendingSubmission(submission.Id); err != nil {
+		return submission, err
+	}
+	return repo.store.reviewSubmission(submission), nil
+}
+
+func (repo *submissionsMemory) Approve(submission structs.SubmissionDBModel, newQuote structs.QuoteInsertModel) (structs.SubmissionDBModel, structs.QuoteDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if err := repo.store.pendingSubmission(submission.Id); err != nil {
+		return submission, structs.QuoteDBModel{}, err
+	}
+	quote, err := repo.store.insertQuote(newQuote)
+	if err != nil {
+		return submission, quote, err
+	}
+	submission.Status, submission.QuoteId = structs.SubmissionApproved, quote.Id
+	return repo.store.reviewSubmission(submission), quote, nil
+}
+
+//pendingSubmission returns ErrNotFound if the submission does not exist and ErrSubmissionReviewed if it is no longer
+//pending
+func (store *memoryStore) pendingSubmission(id int) error {
+	existing := store.submission(id)
+	if existing == nil {
+		return ErrNotFound
+	}
+	if existing.Status != structs.SubmissionPending {
+		return ErrSubmissionReviewed
+	}
+	return nil
+}
+
+//reviewSubmission saves the pending submission as reviewed, the caller holds the lock
+func (store *memoryStore) reviewSubmission(submission structs.SubmissionDBModel) structs.SubmissionDBModel {
+	existing := store.submission(submission.Id)
+	reviewedAt := time.Now()
+	submission.UserId = existing.UserId
+	submission.CreatedAt = existing.CreatedAt
+	submission.ReviewedAt = &reviewedAt
+	*existing = submission
+	return submission
+}
+
+func (store *memoryStore) submission(id int) *structs.SubmissionDBModel {
+	for _, submission := range store.submissions {
+		if submission.Id == id {
+			return submission
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"sort"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type suggestionsMemory struct {
+	store *memoryStore
+}
+
+func (repo *suggestionsMemory) Suggest(searchString string, view string, limit int) ([]structs.SuggestionDBModel, error) {
+	if !isLexemeView(view) {
+		return nil, ErrNotFound
+	}
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	return suggest(searchString, limit, repo.store.lexemes(view))
+}
+
+//memoryLexemes mirrors a lexeme view, i.e. each word with the number of quotes / authors it is in (ndoc)
+type memoryLexemes map[string]int
+
+func (lexemes memoryLexemes) known(words []string) ([]string, error) {
+	known := []string{}
+	for _, word := range words {
+		if _, ok := lexemes[word]; ok {
+			known = append(known, word)
+		}
+	}
+	return known, nil
+}
+
+func (lexemes memoryLexemes) similar(word string, limit int) ([]similarWord, error) {
+	similar := []similarWord{}
+	for lexeme := range lexemes {
+		if wordSimilarity := similarity(word, lexeme); wordSimilarity >= similarityThreshold {
+			similar = append(similar, similarWord{Word: lexeme, Similarity: wordSimilarity})
+		}
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Similarity != similar[j].Similarity {
+			return similar[i].Similarity > similar[j].Similarity
+		}
+		if lexemes[similar[i].Word] != lexemes[similar[j].Word] {
+			return lexemes[similar[i].Word] > lexemes[similar[j].Word]
+		}
+		return similar[i].Word < similar[j].Word
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
+
+//lexemes builds the lexeme view from the live public quotes and the live authors with their aliases, the in-memory
+//views are always up to date
+func (store *memoryStore) lexemes(view string) memoryLexemes {
+	lexemes := memoryLexemes{}
+	addDocument := func(text string) {
+		seen := map[string]bool{}
+		for _, word := range words(text) {
+			if !seen[word] {
+				seen[word] = true
+				lexemes[word]++
+			}
+		}
+	}
+
+	switch view {
+	case LexemeView:
+		for _, row := range store.searchView() {
+			addDocument(row.Quote + " " + row.Name)
+		}
+	case QuotesLexemeView:
+		for _, row := range store.searchView() {
+			addDocument(row.Quote)
+		}
+	case AuthorsLexemeView:
+		for _, author := range store.liveAuthors() {
+			addDocument(author.Name)
+		}
+	}
+	if view != QuotesLexemeView {
+		for _, alias := range store.aliases {
+			if !store.isDeleted(AuthorsTable, alias.AuthorId) {
+				addDocument(alias.Alias)
+			}
+		}
+	}
+	return lexemes
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type topicsMemory struct {
+	store *memoryStore
+}
+
+func (repo *topicsMemory) List(language string) ([]structs.TopicDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	topics := []structs.TopicDBModel{}
+	for _, topic := range repo.store.liveTopics() {
+		if matchesLanguage(language, topic.IsIcelandic) {
+			topics = append(topics, *topic)
+		}
+	}
+	return topics, nil
+}
+
+func (repo *topicsMemory) GetTopic(request structs.Request) ([]structs.TopicViewDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	results := repo.topicRows(request)
+	start, end := pageBounds(request, len(results))
+	return results[start:end], nil
+}
+
+func (repo *topicsMemory) GetTopicTotal(request structs.Request) (structs.TotalDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	return memoryTotal(len(repo.topicRows(request))), nil
+}
+
+//topicRows returns all the quotes of the topic with the given id or name, the newest first
+func (repo *topicsMemory) topicRows(request structs.Request) []structs.TopicViewDBModel {
+	results := []structs.TopicViewDBModel{}
+	for _, row := range repo.store.topicsView() {
+		if request.Topic != "" && strings.ToLower(row.TopicName) != strings.ToLower(request.Topic) {
+			continue
+		}
+		if request.Topic == "" && row.TopicId != request.Id {
+			continue
+		}
+		if !repo.store.matchesSource(request.Verified, row.QuoteId) {
+			continue
+		}
+		results = append(results, row)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool { return results[i].QuoteId > results[j].QuoteId })
+	return results
+}
+
+func (repo *topicsMemory) IncrementCount(topicId int, topicName string, by int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, topic := range repo.store.topics {
+		if topic.Id == topicId || strings.ToLower(topic.Name) == strings.ToLower(topicName) {
+			repo.store.topicCounts[topic.Id] += by
+		}
+	}
+	return nil
+}
+
+func (repo *topicsMemory) Create(name string, isIcelandic bool) (structs.TopicDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	id := 1
+	for _, topic := range repo.store.topics {
+		if strings.EqualFold(topic.Name, name) {
+			return *topic, ErrTopicExists
+		}
+		if topic.Id >= id {
+			id = topic.Id + 1
+		}
+	}
+	topic := &structs.TopicDBModel{Id: id, Name: name, IsIcelandic: isIcelandic}
+	repo.store.topics = append(repo.store.topics, topic)
+	return *topic, nil
+}
+
+func (repo *topicsMemory) Rename(id int, name string) (structs.TopicDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	topic := repo.store.topic(id)
+	if topic == nil || repo.store.isDeleted(TopicsTable, id) {
+		return structs.TopicDBModel{}, ErrNotFound
+	}
+	for _, other := range repo.store.topics {
+		if strings.EqualFold(other.Name, name) && other.Id != id {
+			return structs.TopicDBModel{}, ErrTopicExists
+		}
+	}
+	topic.Name = name
+	return *topic, nil
+}
+
+func (repo *topicsMemory) AddQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	topic := repo.store.topic(topicId)
+	if topic == nil || repo.store.isDeleted(TopicsTable, topicId) {
+		return structs.TopicDBModel{}, ErrNotFound
+	}
+	for _, quoteId := range quoteIds {
+		if repo.store.quote(quoteId) == nil || repo.store.isDeleted(QuotesTable, quoteId) {
+			return structs.TopicDBModel{}, ErrNotFound
+		}
+	}
+	for _, quoteId := range quoteIds {
+		if !repo.store.isInTopic(topicId, quoteId) {
+			repo.store.topicsToQuotes = append(repo.store.topicsToQuotes, TopicToQuote{TopicId: topicId, QuoteId: quoteId})
+		}
+	}
+	repo.store.recomputeTopicCounters()
+	return *topic, nil
+}
+
+func (repo *topicsMemory) RemoveQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	topic := repo.store.topic(topicId)
+	if topic == nil || repo.store.isDeleted(TopicsTable, topicId) {
+		return structs.TopicDBModel{}, ErrNotFound
+	}
+	links := []TopicToQuote{}
+	for _, link := range repo.store.topicsToQuotes {
+		if link.TopicId != topicId || !containsId(quoteIds, link.QuoteId) {
+			links = append(links, link)
+		}
+	}
+	repo.store.topicsToQuotes = links
+	repo.store.recomputeTopicCounters()
+	return *topic, nil
+}
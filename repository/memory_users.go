@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type usersMemory struct {
+	store *memoryStore
+}
+
+func (repo *usersMemory) GetByApiKey(apiKey string) (structs.UserDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, user := range repo.store.users {
+		if user.ApiKey == apiKey {
+			return *user, nil
+		}
+	}
+	return structs.UserDBModel{}, ErrNotFound
+}
+
+func (repo *usersMemory) GetByEmail(email string) (structs.UserDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, user := range repo.store.users {
+		if user.Email == email {
+			return *user, nil
+		}
+	}
+	return structs.UserDBModel{}, ErrNotFound
+}
+
+func (repo *usersMemory) Create(user *structs.UserDBModel) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	id := 1
+	for _, existing := range repo.store.users {
+		if existing.Email == user.Email {
+			return ErrEmailTaken
+		}
+		if existing.Id >= id {
+			id = existing.Id + 1
+		}
+	}
+	user.Id = id
+	created := *user
+	repo.store.users = append(repo.store.users, &created)
+	return nil
+}
+
+type requestHistoryMemory struct {
+	store *memoryStore
+}
+
+func (repo *requestHistoryMemory) CountLastHour(userId int) (int, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	count := 0
+	hourAgo := time.Now().Add(-time.Hour)
+	for _, request := range repo.store.requestHistory {
+		if request.event.UserId == userId && !request.createdAt.Before(hourAgo) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (repo *requestHistoryMemory) Create(event *structs.RequestEvent) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	event.Id = len(repo.store.requestHistory) + 1
+	repo.store.requestHistory = append(repo.store.requestHistory, memoryRequestEvent{event: *event, createdAt: time.Now()})
+	return nil
+}
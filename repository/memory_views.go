@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+type viewsMemory struct {
+	store *memoryStore
+}
+
+func (repo *viewsMemory) Refresh(view string) error {
+	if !IsMaterializedView(view) {
+		return ErrNotFound
+	}
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	now := time.Now()
+	repo.store.viewRefreshes[view] = structs.ViewRefreshDBModel{ViewName: view, RefreshedAt: &now}
+	return nil
+}
+
+func (repo *viewsMemory) LastRefreshed() ([]structs.ViewRefreshDBModel, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	refreshes := []structs.ViewRefreshDBModel{}
+	for _, view := range MaterializedViews {
+		refresh, ok := repo.store.viewRefreshes[view]
+		if !ok {
+			refresh = structs.ViewRefreshDBModel{ViewName: view}
+		}
+		refreshes = append(refreshes, refresh)
+	}
+	return refreshes, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//NewPostgresRepositories returns the repositories backed by the given Postgres connection
+func NewPostgresRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Quotes:         &quotesPostgres{db: db},
+		Authors:        &authorsPostgres{db: db},
+		Topics:         &topicsPostgres{db: db},
+		OfTheDay:       &ofTheDayPostgres{db: db},
+		Users:          &usersPostgres{db: db},
+		RequestHistory: &requestHistoryPostgres{db: db},
+		Views:          &viewsPostgres{db: db},
+		Deleted:        &deletedPostgres{db: db},
+		Collections:    &collectionsPostgres{db: db},
+		Submissions:    &submissionsPostgres{db: db},
+		Duplicates:     &duplicatesPostgres{db: db},
+		Sources:        &sourcesPostgres{db: db},
+		Suggestions:    &suggestionsPostgres{db: db},
+		Completions:    &completionsPostgres{db: db},
+	}
+}
+
+func pagination(requestBody structs.Request, dbPointer *gorm.DB) *gorm.DB {
+	return dbPointer.Limit(requestBody.PageSize).
+		Offset(requestBody.Page * requestBody.PageSize)
+}
+
+//authorLanguageSQL adds to the sql query for the authors db a condition of whether the authors to be fetched have quotes in a particular language
+func authorLanguageSQL(language string, dbPointer *gorm.DB) *gorm.DB {
+	if language != "" {
+		switch strings.ToLower(language) {
+		case "english":
+			dbPointer = dbPointer.Not("has_icelandic_quotes")
+		case "icelandic":
+			dbPointer = dbPointer.Where("has_icelandic_quotes")
+		}
+	}
+	return dbPointer
+}
+
+//quoteLanguageSQL adds to the sql query for the quotes db a condition of whether the quotes to be fetched are in a particular language
+func quoteLanguageSQL(language string, dbPointer *gorm.DB) *gorm.DB {
+	if language != "" {
+		switch strings.ToLower(language) {
+		case "english":
+			dbPointer = dbPointer.Not("is_icelandic")
+		case "icelandic":
+			dbPointer = dbPointer.Where("is_icelandic")
+		}
+	}
+	return dbPointer
+}
+
+//maxMinNumberSQL adds the condition that the column is between the orderConfig's minimum and maximum, if they are numbers
+func maxMinNumberSQL(orderConfig structs.OrderConfig, column string, dbPointer *gorm.DB) *gorm.DB {
+	if nr, err := strconv.Atoi(orderConfig.Maximum); err == nil {
+		dbPointer = dbPointer.Where(column+" <= ?", nr)
+	}
+	if nr, err := strconv.Atoi(orderConfig.Minimum); err == nil {
+		dbPointer = dbPointer.Where(column+" >= ?", nr)
+	}
+	return dbPointer
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (repo *authorsPostgres) Resolve(name string) (structs.AuthorDBModel, error) {
+	var author structs.AuthorDBModel
+	err := repo.db.Table("authors").
+		Where("deleted_at is null").
+		Where("lower(name) = lower(?) or id in (select author_id from author_aliases where lower(alias) = lower(?))", name, name).
+		Clauses(clause.OrderBy{
+			Expression: clause.Expr{SQL: "lower(name) = lower(?) DESC, id", Vars: []interface{}{name}, WithoutParentheses: true},
+		}).
+		Limit(1).
+		Find(&author).Error
+	if err != nil {
+		return author, err
+	}
+	if author.Id == 0 {
+		return author, ErrNotFound
+	}
+	return author, nil
+}
+
+func (repo *authorsPostgres) Aliases(authorIds []int) ([]structs.AuthorAliasDBModel, error) {
+	var aliases []structs.AuthorAliasDBModel
+	err := repo.db.Table("author_aliases").Where("author_id in ?", authorIds).Order("lower(alias), id").Find(&aliases).Error
+	return aliases, err
+}
+
+func (repo *authorsPostgres) AddAliases(authorId int, aliases []string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := liveAuthor(tx, authorId); err != nil {
+			return err
+		}
+		for _, alias := range aliases {
+			var taken int64
+			err := tx.Table("authors").
+				Where("lower(name) = lower(?) and id != ? and deleted_at is null", alias, authorId).
+				Or("id in (select author_id from author_aliases where lower(alias) = lower(?) and author_id != ?)", alias, authorId).
+				Count(&taken).Error
+			if err != nil {
+				return err
+			}
+			if taken > 0 {
+				return ErrAliasTaken
+			}
+			//The alias may already be the author's
+			err = tx.Exec(`INSERT INTO author_aliases (author_id, alias) SELECT ?, ?
+				WHERE NOT EXISTS (SELECT 1 FROM author_aliases WHERE lower(alias) = lower(?))`, authorId, alias, alias).Error
+			if err != nil {
+				return err
+			}
+		}
+		return recomputeAuthors(tx, []int{authorId})
+	})
+}
+
+func (repo *authorsPostgres) RemoveAliases(authorId int, aliases []string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := liveAuthor(tx, authorId); err != nil {
+			return err
+		}
+		lowered := []string{}
+		for _, alias := range aliases {
+			lowered = append(lowered, strings.ToLower(alias))
+		}
+		if err := tx.Exec("DELETE FROM author_aliases WHERE author_id = ? AND lower(alias) in ?", authorId, lowered).Error; err != nil {
+			return err
+		}
+		return recomputeAuthors(tx, []int{authorId})
+	})
+}
+
+func (repo *authorsPostgres) Merge(survivorId int, authorIds []int) (structs.AuthorDBModel, error) {
+	var survivor structs.AuthorDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		all := append([]int{survivorId}, authorIds...)
+		var count int64
+		if err := tx.Table("authors").Where("id in ? and deleted_at is null", all).Count(&count).Error; err != nil {
+			return err
+		}
+		if int(count) != len(uniqueIds(all)) {
+			return ErrNotFound
+		}
+
+		statements := []string{
+			"UPDATE author_aliases SET author_id = @survivor WHERE author_id in @merged",
+			//The names of the merged authors live on as aliases of the survivor
+			`INSERT INTO author_aliases (author_id, alias) SELECT @survivor, name FROM authors
+				WHERE id in @merged AND NOT EXISTS (SELECT 1 FROM author_aliases WHERE lower(alias) = lower(authors.name))`,
+			"UPDATE quotes SET author_id = @survivor, updated_at = current_timestamp WHERE author_id in @merged",
+			"UPDATE submissions SET author_id = @survivor WHERE author_id in @merged",
+			"UPDATE aod SET author_id = @survivor WHERE author_id in @merged",
+			"UPDATE aodice SET author_id = @survivor WHERE author_id in @merged",
+			"UPDATE authors SET count = count + (SELECT coalesce(sum(count), 0) FROM authors WHERE id in @merged) WHERE id = @survivor",
+		}
+		args := map[string]interface{}{"survivor": survivorId, "merged": authorIds}
+		for _, statement := range statements {
+			if err := tx.Exec(statement, args).Error; err != nil {
+				return err
+			}
+		}
+
+		//The gaps in the survivor's profile are filled from the merged authors, in the given order
+		for _, authorId := range authorIds {
+			err := tx.Exec(`UPDATE authors s SET birth_date = coalesce(s.birth_date, m.birth_date), death_date = coalesce(s.death_date, m.death_date),
+				nationality = coalesce(s.nationality, m.nationality), bio = coalesce(s.bio, m.bio),
+				professions = coalesce((SELECT jsonb_agg(DISTINCT p) FROM jsonb_array_elements(s.professions || m.professions) p), '[]'),
+				links = coalesce((SELECT jsonb_agg(DISTINCT l) FROM jsonb_array_elements(s.links || m.links) l), '[]')
+				FROM authors m WHERE s.id = ? AND m.id = ?`, survivorId, authorId).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec("UPDATE authors SET deleted_at = current_timestamp WHERE id in ?", authorIds).Error; err != nil {
+			return err
+		}
+		if err := recomputeAuthors(tx, all); err != nil {
+			return err
+		}
+		return tx.Table("authors").Where("id = ?", survivorId).First(&survivor).Error
+	})
+	return survivor, err
+}
+
+//liveAuthor returns ErrNotFound if the author does not exist or is deleted
+func liveAuthor(tx *gorm.DB, authorId int) error {
+	var count int64
+	if err := tx.Table("authors").Where("id = ? and deleted_at is null", authorId).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//aliasAuthorId returns the id of the live author that has the alias (case insensitive), 0 if none
+func aliasAuthorId(tx *gorm.DB, alias string) (int, error) {
+	var author structs.AuthorDBModel
+	err := tx.Table("authors").
+		Where("deleted_at is null and id in (select author_id from author_aliases where lower(alias) = lower(?))", alias).
+		Limit(1).
+		Find(&author).Error
+	return author.Id, err
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type authorsPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *authorsPostgres) GetByIds(ids []int) ([]structs.AuthorDBModel, error) {
+	var authors []structs.AuthorDBModel
+	err := repo.db.Table("authors").
+		Where("id in (?)", ids).
+		Where("deleted_at is null").
+		Scan(&authors).
+		Error
+	return authors, err
+}
+
+func (repo *authorsPostgres) List(request structs.Request) ([]structs.AuthorDBModel, error) {
+	var authors []structs.AuthorDBModel
+	dbPointer, err := repo.listRows(request)
+	if err != nil {
+		return authors, err
+	}
+	column, orderDirection := authorsListOrder(request)
+	err = pagination(afterCursor(request), dbPointer.Order(columnSQL(column)+" "+orderDirection)).Order("id").
+		Find(&authors).
+		Error
+	return authors, err
+}
+
+func (repo *authorsPostgres) ListTotal(request structs.Request) (structs.TotalDBModel, error) {
+	dbPointer, err := repo.listRows(request)
+	if err != nil {
+		return structs.TotalDBModel{}, err
+	}
+	return countTotal(repo.db, dbPointer)
+}
+
+//listRows returns the DB pointer to the authors List pages through, i.e. with the request's language and profile,
+//between the orderConfig's minimum and maximum and after the cursor, unordered
+func (repo *authorsPostgres) listRows(request structs.Request) (*gorm.DB, error) {
+	dbPointer := repo.db.Table("authors").Where("deleted_at is null")
+
+	dbPointer = authorLanguageSQL(request.Language, dbPointer)
+	dbPointer = authorProfileSQL(request, dbPointer)
+
+	column, orderDirection := authorsListOrder(request)
+	switch column {
+	case "count":
+	case "initcap(name)":
+		//Minimum letter to start with (i.e. start from given minimum letter of the alphabet)
+		if request.OrderConfig.Minimum != "" {
+			dbPointer = dbPointer.Where(columnSQL(column)+" >= ?", strings.ToUpper(request.OrderConfig.Minimum))
+		}
+		//Maximum letter to start with (i.e. end at the given maximum letter of the alphabet)
+		if request.OrderConfig.Maximum != "" {
+			dbPointer = dbPointer.Where(columnSQL(column)+" <= ?", strings.ToUpper(request.OrderConfig.Maximum))
+		}
+	default:
+		dbPointer = maxMinNumberSQL(request.OrderConfig, column, dbPointer)
+	}
+	return cursorSQL(request.Cursor, "authors", column, orderDirection, "id", dbPointer)
+}
+
+//authorsListOrder returns the column the authors are listed by and in which direction, the most popular first unless
+//reversed. The number of quotes is the number in the request's language
+func authorsListOrder(request structs.Request) (string, string) {
+	orderDirection := "ASC"
+	if request.OrderConfig.Reverse {
+		orderDirection = "DESC"
+	}
+
+	switch strings.ToLower(request.OrderConfig.OrderBy) {
+	case "popularity":
+		if request.OrderConfig.Reverse {
+			return "count", "ASC"
+		}
+		return "count", "DESC"
+	case "nrofquotes":
+		switch strings.ToLower(request.Language) {
+		case "english":
+			return "nr_of_english_quotes", orderDirection
+		case "icelandic":
+			return "nr_of_icelandic_quotes", orderDirection
+		default:
+			return "nr_of_icelandic_quotes + nr_of_english_quotes", orderDirection
+		}
+	}
+	return "initcap(name)", orderDirection
+}
+
+func (repo *authorsPostgres) Random(language string) (structs.AuthorDBModel, error) {
+	var author structs.AuthorDBModel
+	dbPointer := repo.db.Table("authors").Where("deleted_at is null").Order("random()")
+
+	//author from a particular language
+	dbPointer = authorLanguageSQL(language, dbPointer)
+	err := dbPointer.First(&author).Error
+	return author, err
+}
+
+func (repo *authorsPostgres) Search(request structs.Request) ([]structs.AuthorDBModel, error) {
+	var results []structs.AuthorDBModel
+	//Order by authorid to have definitive order (when for examplke some names rank the same for similarity), same for why quote_id
+	dbPointer := repo.searchRows(request).
+		Clauses(clause.OrderBy{
+			Expression: clause.Expr{SQL: "greatest(similarity(name, ?), (select max(similarity(alias, ?)) from author_aliases where author_id = authors.id)) DESC, id DESC", Vars: []interface{}{request.SearchString, request.SearchString}, WithoutParentheses: true},
+		})
+	err := pagination(request, dbPointer).
+		Find(&results).Error
+	return results, err
+}
+
+func (repo *authorsPostgres) SearchTotal(request structs.Request) (structs.TotalDBModel, error) {
+	return countTotal(repo.db, repo.searchRows(request))
+}
+
+//searchRows returns the DB pointer to all the authors Search matches, unordered
+func (repo *authorsPostgres) searchRows(request structs.Request) *gorm.DB {
+	//% is same as SIMILARITY but with default threshold 0.3. The aliases are in the tsv and matched by similarity as well.
+	//The names are in the tsv in both configurations, so e.g. thordur finds Þórður
+	dbPointer := repo.db.Table("authors").
+		Where("( tsv @@ (plainto_tsquery('english', ?) || plainto_tsquery('icelandic', ?)) OR (?) % ANY(STRING_TO_ARRAY(name,' ')) OR id in (select author_id from author_aliases where alias % ?) )", request.SearchString, request.SearchString, request.SearchString, request.SearchString).
+		Where("deleted_at is null")
+
+	//Particular language search
+	return authorLanguageSQL(request.Language, dbPointer)
+}
+
+func (repo *authorsPostgres) IncrementCount(authorIds []int, by int) error {
+	return repo.db.Exec("UPDATE authors SET count = count + ? where id in (?) returning *", by, authorIds).Error
+}
+
+//authorProfileSQL adds to the sql query for the authors db the conditions on the authors' profession, nationality and year of birth
+func authorProfileSQL(request structs.Request, dbPointer *gorm.DB) *gorm.DB {
+	if request.Profession != "" {
+		profession, _ := json.Marshal([]string{strings.ToLower(strings.TrimSpace(request.Profession))})
+		dbPointer = dbPointer.Where("professions @> ?::jsonb", string(profession))
+	}
+	if request.Nationality != "" {
+		dbPointer = dbPointer.Where("nationality = ?", strings.ToUpper(strings.TrimSpace(request.Nationality)))
+	}
+	if request.BornBefore != 0 {
+		dbPointer = dbPointer.Where("date_year(birth_date) < ?", request.BornBefore)
+	}
+	if request.BornAfter != 0 {
+		dbPointer = dbPointer.Where("date_year(birth_date) > ?", request.BornAfter)
+	}
+	return dbPointer
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"regexp"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+var authorExistsRegex = regexp.MustCompile(`duplicate key value violates unique constraint "authors_name_key"`)
+
+func (repo *authorsPostgres) Create(name string) (structs.AuthorDBModel, error) {
+	var author structs.AuthorDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if author, err = takenName(tx, name, 0); err != nil {
+			return err
+		}
+		//Nor can an author be created with another author's alias
+		if aliasId, err := aliasAuthorId(tx, name); err != nil || aliasId != 0 {
+			if err == nil {
+				err = ErrAuthorExists
+			}
+			return err
+		}
+
+		author = structs.AuthorDBModel{Name: name}
+		if err := tx.Table("authors").Select("name").Create(&author).Error; err != nil {
+			if authorExistsRegex.MatchString(err.Error()) {
+				return ErrAuthorExists
+			}
+			return err
+		}
+		return recomputeAuthors(tx, []int{author.Id})
+	})
+	return author, err
+}
+
+func (repo *authorsPostgres) Update(id int, name string) (structs.AuthorDBModel, error) {
+	var author structs.AuthorDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if _, err := takenName(tx, name, id); err != nil {
+			return err
+		}
+		if aliasId, err := aliasAuthorId(tx, name); err != nil || (aliasId != 0 && aliasId != id) {
+			if err == nil {
+				err = ErrAuthorExists
+			}
+			return err
+		}
+
+		result := tx.Exec("UPDATE authors SET name = ?, updated_at = current_timestamp WHERE id = ? AND deleted_at is null", name, id)
+		if result.Error != nil {
+			if authorExistsRegex.MatchString(result.Error.Error()) {
+				return ErrAuthorExists
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if err := recomputeAuthors(tx, []int{id}); err != nil {
+			return err
+		}
+		return tx.Table("authors").Where("id = ?", id).First(&author).Error
+	})
+	return author, err
+}
+
+func (repo *authorsPostgres) UpdateProfile(id int, profile structs.AuthorProfile) (structs.AuthorDBModel, error) {
+	var author structs.AuthorDBModel
+	author.SetProfile(profile)
+	result := repo.db.Exec(`UPDATE authors SET birth_date = nullif(?, ''), death_date = nullif(?, ''), nationality = nullif(?, ''),
+		professions = ?::jsonb, bio = nullif(?, ''), links = ?::jsonb, updated_at = current_timestamp WHERE id = ? AND deleted_at is null`,
+		author.BirthDate, author.DeathDate, author.Nationality, author.Professions, author.Bio, author.Links, id)
+	if result.Error != nil {
+		return author, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return author, ErrNotFound
+	}
+	err := repo.db.Table("authors").Where("id = ?", id).First(&author).Error
+	return author, err
+}
+
+//takenName returns the live author, other than the one with the id, that has the name and ErrAuthorExists, or
+//ErrAuthorDeleted if only a soft deleted author has it. The names are unique among all the authors, so the deleted
+//author has to be restored, or renamed, before the name can be used again
+func takenName(tx *gorm.DB, name string, id int) (structs.AuthorDBModel, error) {
+	var taken structs.AuthorDBModel
+	if err := tx.Table("authors").Where("name = ? and id != ? and deleted_at is null", name, id).Limit(1).Find(&taken).Error; err != nil {
+		return taken, err
+	}
+	if taken.Id != 0 {
+		return taken, ErrAuthorExists
+	}
+	var deleted int64
+	if err := tx.Table("authors").Where("name = ? and id != ?", name, id).Count(&deleted).Error; err != nil {
+		return taken, err
+	}
+	if deleted > 0 {
+		return taken, ErrAuthorDeleted
+	}
+	return taken, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type completionsPostgres struct {
+	db *gorm.DB
+}
+
+//Complete runs three small queries that the gin indexes on to_tsvector('simple', ...) answer, see
+//0019_add_autocomplete_indexes
+func (repo *completionsPostgres) Complete(prefix string, language string, limit int) (structs.AutocompleteDBModel, error) {
+	completions := structs.AutocompleteDBModel{Authors: []structs.AuthorDBModel{}, Topics: []structs.TopicDBModel{}, Quotes: []structs.SearchViewDBModel{}}
+	query := prefixTsquery(prefix)
+	if query == "" {
+		return completions, nil
+	}
+
+	err := authorLanguageSQL(language, repo.db.Table("authors")).
+		Where("deleted_at is null and to_tsvector('simple', name) @@ to_tsquery('simple', ?)", query).
+		Order("count DESC, id").
+		Limit(limit).
+		Find(&completions.Authors).Error
+	if err != nil {
+		return completions, err
+	}
+
+	err = quoteLanguageSQL(language, repo.db.Table("topics")).
+		Where("deleted_at is null and to_tsvector('simple', name) @@ to_tsquery('simple', ?)", query).
+		Order("count DESC, id").
+		Limit(limit).
+		Find(&completions.Topics).Error
+	if err != nil {
+		return completions, err
+	}
+
+	err = quoteLanguageSQL(language, repo.db.Table("quotes").
+		Select("quotes.id as quote_id, quotes.quote, quotes.is_icelandic, quotes.count as quote_count, authors.id as author_id, authors.name, authors.count as author_count").
+		Joins("JOIN authors ON authors.id = quotes.author_id")).
+		Where("quotes.deleted_at is null and not quotes.is_private and authors.deleted_at is null").
+		Where("to_tsvector('simple', quotes.quote) @@ to_tsquery('simple', ?)", query).
+		Order("quotes.count DESC, quotes.id").
+		Limit(limit).
+		Find(&completions.Quotes).Error
+	return completions, err
+}
+
+//prefixTsquery turns the prefix into a tsquery where every word is a prefix, e.g. 'friedrich:* & nie:*'. The words
+//only have letters and digits so the prefix can not inject tsquery operators
+func prefixTsquery(prefix string) string {
+	terms := []string{}
+	for _, word := range words(prefix) {
+		terms = append(terms, word+":*")
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"regexp"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var collectionExistsRegex = regexp.MustCompile(`duplicate key value violates unique constraint "collections_user_id_name_key"`)
+
+//quoteRowSQL selects the quote and its author in the shape of a searchview row
+const quoteRowSQL = "a.id as author_id, a.name, q.id as quote_id, q.quote, q.is_icelandic, q.count as quote_count, a.count as author_count"
+
+type collectionsPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *collectionsPostgres) Favourites(userId int, request structs.Request) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	dbPointer := visibleQuotes(repo.db.Table("favourites f").Joins("inner join quotes q on q.id = f.quote_id"), userId).
+		Select(quoteRowSQL).
+		Where("f.user_id = ?", userId).
+		Order("f.created_at DESC, q.id DESC")
+	err := pagination(request, dbPointer).Find(&quotes).Error
+	return quotes, err
+}
+
+func (repo *collectionsPostgres) AddFavourites(userId int, quoteIds []int) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := allVisible(tx, userId, quoteIds); err != nil {
+			return err
+		}
+		for _, quoteId := range uniqueIds(quoteIds) {
+			err := tx.Exec("INSERT INTO favourites (user_id, quote_id) VALUES (?, ?) ON CONFLICT DO NOTHING", userId, quoteId).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (repo *collectionsPostgres) RemoveFavourites(userId int, quoteIds []int) error {
+	return repo.db.Exec("DELETE FROM favourites WHERE user_id = ? AND quote_id in ?", userId, quoteIds).Error
+}
+
+func (repo *collectionsPostgres) List(userId int) ([]structs.CollectionDBModel, error) {
+	var collections []structs.CollectionDBModel
+	err := repo.db.Table("collections c").
+		Select("c.*, (select count(*) from collection_quotes cq where cq.collection_id = c.id) as nr_of_quotes").
+		Where("c.user_id = ?", userId).
+		Order("c.name, c.id").
+		Find(&collections).Error
+	return collections, err
+}
+
+func (repo *collectionsPostgres) Get(id int, slug string) (structs.CollectionDBModel, error) {
+	var collection structs.CollectionDBModel
+	dbPointer := repo.db.Table("collections c").
+		Select("c.*, (select count(*) from collection_quotes cq where cq.collection_id = c.id) as nr_of_quotes")
+	if id > 0 {
+		dbPointer = dbPointer.Where("c.id = ?", id)
+	} else {
+		dbPointer = dbPointer.Where("c.slug = ?", slug)
+	}
+	if err := dbPointer.Limit(1).Find(&collection).Error; err != nil {
+		return collection, err
+	}
+	if collection.Id == 0 {
+		return collection, ErrNotFound
+	}
+	return collection, nil
+}
+
+func (repo *collectionsPostgres) Create(collection *structs.CollectionDBModel) error {
+	err := repo.db.Table("collections").Select("user_id", "name", "slug", "is_public").Create(collection).Error
+	if err != nil && collectionExistsRegex.MatchString(err.Error()) {
+		return ErrCollectionExists
+	}
+	return err
+}
+
+func (repo *collectionsPostgres) Update(collection structs.CollectionDBModel) (structs.CollectionDBModel, error) {
+	result := repo.db.Exec("UPDATE collections SET name = ?, is_public = ?, updated_at = current_timestamp WHERE id = ? AND user_id = ?",
+		collection.Name, collection.IsPublic, collection.Id, collection.UserId)
+	if result.Error != nil {
+		if collectionExistsRegex.MatchString(result.Error.Error()) {
+			return collection, ErrCollectionExists
+		}
+		return collection, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return collection, ErrNotFound
+	}
+	return repo.Get(collection.Id, "")
+}
+
+func (repo *collectionsPostgres) Delete(userId int, collectionId int) error {
+	result := repo.db.Exec("DELETE FROM collections WHERE id = ? AND user_id = ?", collectionId, userId)
+	if result.Error == nil && result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return result.Error
+}
+
+func (repo *collectionsPostgres) Quotes(collectionId int, viewerId int) ([]structs.CollectionQuoteDBModel, error) {
+	var quotes []structs.CollectionQuoteDBModel
+	err := visibleQuotes(repo.db.Table("collection_quotes cq").Joins("inner join quotes q on q.id = cq.quote_id"), viewerId).
+		Select(quoteRowSQL+", cq.position, coalesce(cq.note, '') as note").
+		Where("cq.collection_id = ?", collectionId).
+		Order("cq.position, q.id").
+		Find(&quotes).Error
+	return quotes, err
+}
+
+func (repo *collectionsPostgres) SetQuote(userId int, collectionId int, quoteId int, note string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := ownCollection(tx, userId, collectionId); err != nil {
+			return err
+		}
+		if err := allVisible(tx, userId, []int{quoteId}); err != nil {
+			return err
+		}
+		return tx.Exec(`INSERT INTO collection_quotes (collection_id, quote_id, position, note)
+			VALUES (?, ?, (SELECT coalesce(max(position), 0) + 1 FROM collection_quotes WHERE collection_id = ?), ?)
+			ON CONFLICT (collection_id, quote_id) DO UPDATE SET note = excluded.note`, collectionId, quoteId, collectionId, note).Error
+	})
+}
+
+func (repo *collectionsPostgres) RemoveQuotes(userId int, collectionId int, quoteIds []int) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := ownCollection(tx, userId, collectionId); err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM collection_quotes WHERE collection_id = ? AND quote_id in ?", collectionId, quoteIds).Error
+	})
+}
+
+func (repo *collectionsPostgres) Reorder(userId int, collectionId int, quoteIds []int) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := ownCollection(tx, userId, collectionId); err != nil {
+			return err
+		}
+		var current []int
+		err := tx.Table("collection_quotes").Where("collection_id = ?", collectionId).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Order("position, quote_id").
+			Pluck("quote_id", &current).Error
+		if err != nil {
+			return err
+		}
+		for idx, quoteId := range reordered(current, quoteIds) {
+			err := tx.Exec("UPDATE collection_quotes SET position = ? WHERE collection_id = ? AND quote_id = ?", idx+1, collectionId, quoteId).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+//visibleQuotes joins the authors of the quotes (aliased q) and keeps only the live quotes visible to the user
+func visibleQuotes(dbPointer *gorm.DB, userId int) *gorm.DB {
+	return dbPointer.Joins("inner join authors a on a.id = q.author_id").
+		Where("q.deleted_at is null and a.deleted_at is null and (not q.is_private or q.user_id = ?)", userId)
+}
+
+//allVisible returns ErrNotFound unless all the quotes are visible to the user
+func allVisible(tx *gorm.DB, userId int, quoteIds []int) error {
+	var count int64
+	err := visibleQuotes(tx.Table("quotes q"), userId).Where("q.id in ?", quoteIds).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if int(count) != len(uniqueIds(quoteIds)) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//ownCollection returns ErrNotFound unless the user has the collection. It locks the collection until the end of the
+//transaction, so that concurrent changes to its quotes, e.g. two adds reading the same max(position), run one by one
+func ownCollection(tx *gorm.DB, userId int, collectionId int) error {
+	var ids []int
+	err := tx.Table("collections").Where("id = ? and user_id = ?", collectionId, userId).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//reordered returns the current quote ids with the given ones moved to the front, in the given order
+func reordered(current []int, first []int) []int {
+	order := []int{}
+	for _, quoteId := range uniqueIds(first) {
+		if containsId(current, quoteId) {
+			order = append(order, quoteId)
+		}
+	}
+	for _, quoteId := range current {
+		if !containsId(order, quoteId) {
+			order = append(order, quoteId)
+		}
+	}
+	return order
+}
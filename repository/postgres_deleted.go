@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type deletedPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *deletedPostgres) Delete(table string, id int) error {
+	return repo.setDeletedAt(table, id, "current_timestamp", "deleted_at is null")
+}
+
+func (repo *deletedPostgres) Restore(table string, id int) error {
+	return repo.setDeletedAt(table, id, "null", "deleted_at is not null")
+}
+
+//setDeletedAt sets deleted_at of the row and, for authors and quotes, recomputes the counters of the authors and topics which only count the
+//live quotes of the live authors
+func (repo *deletedPostgres) setDeletedAt(table string, id int, value string, condition string) error {
+	if !isDeletableTable(table) {
+		return ErrNotFound
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		//The table and value are constants, never input
+		result := tx.Exec("UPDATE "+table+" SET deleted_at = "+value+" WHERE id = ? AND "+condition, id)
+		if result.Error != nil && quoteExistsRegex.MatchString(result.Error.Error()) {
+			return ErrQuoteExists
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if table == AuthorsTable {
+			if err := recomputeTopicsOfAuthor(tx, id); err != nil {
+				return err
+			}
+			return recomputeAuthors(tx, []int{id})
+		}
+		if table != QuotesTable {
+			return nil
+		}
+		var quote structs.QuoteDBModel
+		if err := tx.Table("quotes").Where("id = ?", id).First(&quote).Error; err != nil {
+			return err
+		}
+		if err := recomputeTopicsOfQuote(tx, id); err != nil {
+			return err
+		}
+		if value == "null" {
+			//The quotes inserted while it was deleted may be its near duplicates
+			if err := recordDuplicates(tx, id); err != nil {
+				return err
+			}
+		}
+		return recomputeAuthors(tx, []int{quote.AuthorId})
+	})
+}
+
+func (repo *deletedPostgres) List(table string, request structs.Request) ([]structs.DeletedDBModel, error) {
+	var deleted []structs.DeletedDBModel
+	var dbPointer *gorm.DB
+	switch table {
+	case AuthorsTable, TopicsTable:
+		dbPointer = repo.db.Table(table).Select("id, name, deleted_at").Where("deleted_at is not null").Order("deleted_at DESC, id DESC")
+	case QuotesTable:
+		dbPointer = repo.db.Table("quotes").
+			Select("quotes.id, quotes.quote, quotes.author_id, authors.name, quotes.deleted_at").
+			Joins("inner join authors on authors.id = quotes.author_id").
+			Where("quotes.deleted_at is not null").
+			Order("quotes.deleted_at DESC, quotes.id DESC")
+	default:
+		return nil, ErrNotFound
+	}
+	err := pagination(request, dbPointer).Find(&deleted).Error
+	return deleted, err
+}
+
+func isDeletableTable(table string) bool {
+	for _, deletable := range DeletableTables {
+		if table == deletable {
+			return true
+		}
+	}
+	return false
+}
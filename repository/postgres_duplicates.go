@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type duplicatesPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *duplicatesPostgres) Similar(quote string, userId int, minimum float64, limit int) ([]structs.SimilarQuoteDBModel, error) {
+	var quotes []structs.SimilarQuoteDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := setSimilarityThreshold(tx, minimum); err != nil {
+			return err
+		}
+		return visibleQuotes(tx.Table("quotes q"), userId).
+			Select(quoteRowSQL+", similarity(q.normalized, normalize_quote(?)) as similarity", quote).
+			Where("q.normalized % normalize_quote(?)", quote).
+			Order("similarity DESC, q.id").
+			Limit(limit).
+			Find(&quotes).Error
+	})
+	return quotes, err
+}
+
+func (repo *duplicatesPostgres) Clusters(minimum float64, request structs.Request) ([]structs.DuplicateClusterDBModel, error) {
+	clusters := []structs.DuplicateClusterDBModel{}
+	var members []duplicateMember
+	err := repo.db.Raw(`WITH RECURSIVE pairs AS (
+			SELECT d.quote_id, d.duplicate_id FROM quote_duplicates d
+				INNER JOIN quotes q1 ON q1.id = d.quote_id
+				INNER JOIN authors a1 ON a1.id = q1.author_id
+				INNER JOIN quotes q2 ON q2.id = d.duplicate_id
+				INNER JOIN authors a2 ON a2.id = q2.author_id
+			WHERE d.similarity >= ?
+				AND q1.deleted_at is null AND a1.deleted_at is null AND NOT q1.is_private
+				AND q2.deleted_at is null AND a2.deleted_at is null AND NOT q2.is_private
+		), links AS (
+			SELECT quote_id AS id, duplicate_id AS other FROM pairs
+			UNION SELECT duplicate_id, quote_id FROM pairs
+		), reached(id, cluster_id) AS (
+			SELECT id, id FROM links
+			UNION SELECT links.other, reached.cluster_id FROM reached INNER JOIN links ON links.id = reached.id
+		), clusters AS (
+			SELECT id AS quote_id, min(cluster_id) AS cluster_id FROM reached GROUP BY id
+		)
+		SELECT quote_id, cluster_id FROM clusters
+		WHERE cluster_id in (SELECT DISTINCT cluster_id FROM clusters ORDER BY cluster_id LIMIT ? OFFSET ?)
+		ORDER BY cluster_id, quote_id`, minimum, request.PageSize, request.Page*request.PageSize).
+		Scan(&members).Error
+	if err != nil || len(members) == 0 {
+		return clusters, err
+	}
+
+	quoteIds := []int{}
+	for _, member := range members {
+		quoteIds = append(quoteIds, member.QuoteId)
+	}
+	var candidates []duplicateCandidate
+	err = visibleQuotes(repo.db.Table("quotes q"), 0).
+		Select(quoteRowSQL+", q.normalized").
+		Where("q.id in ?", quoteIds).
+		Find(&candidates).Error
+	if err != nil {
+		return clusters, err
+	}
+	for _, group := range groupMembers(members, candidates) {
+		clusters = append(clusters, buildCluster(group))
+	}
+	return clusters, nil
+}
+
+func (repo *duplicatesPostgres) Merge(survivorId int, duplicateIds []int) (structs.QuoteDBModel, error) {
+	var survivor structs.QuoteDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		duplicateIds = uniqueIds(duplicateIds)
+		//visibleQuotes for no user leaves out all the private quotes
+		if err := allVisible(tx, 0, append([]int{survivorId}, duplicateIds...)); err != nil {
+			return err
+		}
+
+		var topicIds []int
+		if err := tx.Table("topicstoquotes").Where("quote_id in ? and deleted_at is null", duplicateIds).Distinct().Pluck("topic_id", &topicIds).Error; err != nil {
+			return err
+		}
+		for _, topicId := range topicIds {
+			if err := linkQuote(tx, topicId, survivorId); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Exec("UPDATE quotes SET count = count + (SELECT coalesce(sum(count), 0) FROM quotes WHERE id in ?) WHERE id = ?", duplicateIds, survivorId).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`INSERT INTO favourites (user_id, quote_id, created_at) SELECT user_id, ?, created_at FROM favourites
+			WHERE quote_id in ? ON CONFLICT DO NOTHING`, survivorId, duplicateIds).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`INSERT INTO collection_quotes (collection_id, quote_id, position, note)
+			SELECT DISTINCT ON (collection_id) collection_id, ?, position, note FROM collection_quotes
+			WHERE quote_id in ? ORDER BY collection_id, position ON CONFLICT DO NOTHING`, survivorId, duplicateIds).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM favourites WHERE quote_id in ?", duplicateIds).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM collection_quotes WHERE quote_id in ?", duplicateIds).Error; err != nil {
+			return err
+		}
+		for _, table := range []string{"qod", "qodice"} {
+			if err := tx.Exec("UPDATE "+table+" SET quote_id = ? WHERE quote_id in ?", survivorId, duplicateIds).Error; err != nil {
+				return err
+			}
+		}
+		//The survivor keeps its own citation, otherwise it gets the best one of the duplicates, verified first
+		err = tx.Exec(`UPDATE quotes SET source_id = cited.source_id, source_status = cited.source_status
+			FROM (SELECT source_id, source_status FROM quotes WHERE id in ? AND (source_id is not null OR source_status <> 'unverified')
+				ORDER BY source_status = 'verified' DESC, source_status = 'misattributed' DESC, id LIMIT 1) AS cited
+			WHERE quotes.id = ? AND quotes.source_id is null AND quotes.source_status = 'unverified'`, duplicateIds, survivorId).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE quotes SET source_id = null, source_status = 'unverified' WHERE id in ?", duplicateIds).Error; err != nil {
+			return err
+		}
+
+		var authorIds []int
+		if err := tx.Table("quotes").Where("id in ?", duplicateIds).Pluck("author_id", &authorIds).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE quotes SET deleted_at = current_timestamp WHERE id in ?", duplicateIds).Error; err != nil {
+			return err
+		}
+		if len(topicIds) > 0 {
+			if err := recomputeTopics(tx, topicIds); err != nil {
+				return err
+			}
+		}
+		if err := tx.Table("quotes").Where("id = ?", survivorId).First(&survivor).Error; err != nil {
+			return err
+		}
+		return recomputeAuthors(tx, uniqueIds(append(authorIds, survivor.AuthorId)))
+	})
+	return survivor, err
+}
+
+func (repo *duplicatesPostgres) RecordPairs(afterId int, limit int) (int, error) {
+	var quoteIds []int
+	err := repo.db.Table("quotes").Where("id > ?", afterId).Order("id").Limit(limit).Pluck("id", &quoteIds).Error
+	if err != nil || len(quoteIds) == 0 {
+		return 0, err
+	}
+	err = repo.db.Transaction(func(tx *gorm.DB) error {
+		return recordDuplicates(tx, quoteIds...)
+	})
+	return quoteIds[len(quoteIds)-1], err
+}
+
+//recordDuplicates replaces the pairs of the quotes in quote_duplicates with the quotes whose normalized texts have at
+//least MinDuplicateSimilarity to them, see 0022_create_quote_duplicates. It is called whenever a quote gets a new
+//normalized text or comes back, i.e. on insert and restore
+func recordDuplicates(tx *gorm.DB, quoteIds ...int) error {
+	if err := setSimilarityThreshold(tx, MinDuplicateSimilarity); err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM quote_duplicates WHERE quote_id in ? OR duplicate_id in ?", quoteIds, quoteIds).Error; err != nil {
+		return err
+	}
+	return tx.Exec(`INSERT INTO quote_duplicates (quote_id, duplicate_id, similarity)
+		SELECT least(q.id, quote.id), greatest(q.id, quote.id), similarity(q.normalized, quote.normalized)
+		FROM quotes quote
+			INNER JOIN quotes q ON q.normalized % quote.normalized AND q.id <> quote.id
+		WHERE quote.id in ? AND quote.normalized <> ''
+		ON CONFLICT DO NOTHING`, quoteIds).Error
+}
+
+//setSimilarityThreshold sets, for the rest of the transaction, the similarity the % operator of pg_trgm requires
+func setSimilarityThreshold(tx *gorm.DB, minimum float64) error {
+	return tx.Exec("SELECT set_config('pg_trgm.similarity_threshold', ?, true)", fmt.Sprintf("%g", minimum)).Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+func (repo *quotesPostgres) Export(request structs.Request, callbacks ExportCallbacks) error {
+	//A read only repeatable read transaction so that all the rows are from the same snapshot
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var snapshot time.Time
+		if err := tx.Raw("SELECT now()").Row().Scan(&snapshot); err != nil {
+			return err
+		}
+		if err := callbacks.Begin(snapshot); err != nil {
+			return err
+		}
+
+		if callbacks.Author != nil {
+			dbPointer := tx.Table("authors").Select("id, name").Where("deleted_at is null").Order("id")
+			err := exportRows(dbPointer, func(rows *sql.Rows) error {
+				var author structs.AuthorDBModel
+				if err := tx.ScanRows(rows, &author); err != nil {
+					return err
+				}
+				return callbacks.Author(author)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if callbacks.Topic != nil {
+			dbPointer := tx.Table("topics").Select("id, name, is_icelandic").Where("deleted_at is null").Order("id")
+			if request.TopicId > 0 {
+				dbPointer = dbPointer.Where("id = ?", request.TopicId)
+			} else if request.Topic != "" {
+				dbPointer = dbPointer.Where("lower(name) = lower(?)", request.Topic)
+			}
+			dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+			err := exportRows(dbPointer, func(rows *sql.Rows) error {
+				var topic structs.TopicDBModel
+				if err := tx.ScanRows(rows, &topic); err != nil {
+					return err
+				}
+				return callbacks.Topic(topic)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if callbacks.Quote == nil {
+			return nil
+		}
+		var dbPointer *gorm.DB
+		if ExportsTopics(request) {
+			dbPointer = tx.Table("topicsview").Select("author_id, name, quote_id, quote, is_icelandic, topic_id, topic_name").Order("quote_id, topic_id")
+			if request.TopicId > 0 {
+				dbPointer = dbPointer.Where("topic_id = ?", request.TopicId)
+			} else if request.Topic != "" {
+				dbPointer = dbPointer.Where("lower(topic_name) = lower(?)", request.Topic)
+			}
+		} else {
+			dbPointer = tx.Table("searchview").Select("author_id, name, quote_id, quote, is_icelandic").Order("quote_id")
+		}
+		dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+		return exportRows(dbPointer, func(rows *sql.Rows) error {
+			var row structs.TopicViewDBModel
+			if err := tx.ScanRows(rows, &row); err != nil {
+				return err
+			}
+			return callbacks.Quote(row)
+		})
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+// exportRows calls each with the rows of the DB pointer one at a time
+func exportRows(dbPointer *gorm.DB, each func(rows *sql.Rows) error) error {
+	rows, err := dbPointer.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := each(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ExportsTopics is whether the export is of the topicsview, i.e. one row per quote and topic
+func ExportsTopics(request structs.Request) bool {
+	return request.WithTopics || request.TopicId > 0 || request.Topic != ""
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//facetFiltersSQL adds the multi-value filters a facet is clicked through, i.e. the row's author is one of the authorIds
+//and its quote is in one of the topicIds
+func facetFiltersSQL(request structs.Request, dbPointer *gorm.DB) *gorm.DB {
+	if len(request.AuthorIds) > 0 {
+		dbPointer = dbPointer.Where("author_id in ?", request.AuthorIds)
+	}
+	if len(request.TopicIds) > 0 {
+		dbPointer = dbPointer.Where("quote_id in (select quote_id from topicstoquotes where topic_id in ? and deleted_at is null)", request.TopicIds)
+	}
+	return dbPointer
+}
+
+//facets counts the matched rows by language, along with the limit topics, through the topicsview, and authors with
+//the most of them. The matches are a subquery so that the counts see the same tsqueries and filters as the search
+func (repo *quotesPostgres) facets(matches *gorm.DB, limit int) (structs.FacetsDBModel, error) {
+	facets := structs.FacetsDBModel{Topics: []structs.FacetDBModel{}, Authors: []structs.FacetDBModel{}}
+	matches = matches.Select("quote_id, author_id, name, is_icelandic")
+
+	var languages []struct {
+		IsIcelandic bool
+		Count       int
+	}
+	err := repo.db.Table("(?) as matches", matches).
+		Select("is_icelandic, count(distinct quote_id) as count").
+		Group("is_icelandic").
+		Find(&languages).Error
+	if err != nil {
+		return facets, err
+	}
+	for _, language := range languages {
+		if language.IsIcelandic {
+			facets.Icelandic = language.Count
+		} else {
+			facets.English = language.Count
+		}
+	}
+
+	err = repo.db.Table("(?) as matches", matches).
+		Select("topicsview.topic_id as id, topicsview.topic_name as name, count(distinct matches.quote_id) as count").
+		Joins("inner join topicsview on topicsview.quote_id = matches.quote_id").
+		Group("topicsview.topic_id, topicsview.topic_name").
+		Order("count DESC, id").
+		Limit(limit).
+		Find(&facets.Topics).Error
+	if err != nil {
+		return facets, err
+	}
+
+	err = repo.db.Table("(?) as matches", matches).
+		Select("author_id as id, name, count(distinct quote_id) as count").
+		Group("author_id, name").
+		Order("count DESC, id").
+		Limit(limit).
+		Find(&facets.Authors).Error
+	return facets, err
+}
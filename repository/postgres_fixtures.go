@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//SeedPostgres empties the migrated database and seeds it with the fixtures, the same way NewMemoryRepositories seeds
+//the in-memory repositories, so that the tests of the routes can run against both. Meant for tests, it deletes every
+//row of every table but schema_migrations
+func SeedPostgres(db *gorm.DB, fixtures Fixtures) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var tables []string
+		err := tx.Raw("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'").
+			Scan(&tables).Error
+		if err != nil {
+			return err
+		}
+		//The table names come from the catalog, not from the caller
+		if err := tx.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE").Error; err != nil {
+			return err
+		}
+
+		authorIds := []int{}
+		for _, author := range fixtures.Authors {
+			err := tx.Exec(`INSERT INTO authors (id, name, count, birth_date, death_date, nationality, professions, links)
+				VALUES (?, ?, ?, nullif(?, ''), nullif(?, ''), nullif(?, ''), coalesce(nullif(?, ''), '[]')::jsonb, coalesce(nullif(?, ''), '[]')::jsonb)`,
+				author.Id, author.Name, author.Count, author.BirthDate, author.DeathDate, author.Nationality, author.Professions, author.Links).Error
+			if err != nil {
+				return err
+			}
+			authorIds = append(authorIds, author.Id)
+		}
+		for _, quote := range fixtures.Quotes {
+			var userId interface{}
+			if quote.UserId > 0 {
+				userId = quote.UserId
+			}
+			err := tx.Exec("INSERT INTO quotes (id, author_id, quote, count, is_icelandic, is_private, user_id) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				quote.Id, quote.AuthorId, quote.Quote, quote.Count, quote.IsIcelandic, quote.IsPrivate, userId).Error
+			if err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec("UPDATE quotes SET tsv = setweight(to_tsvector(search_config(is_icelandic), quote), 'B'), normalized = normalize_quote(quote)").Error; err != nil {
+			return err
+		}
+		topicIds := []int{}
+		for _, topic := range fixtures.Topics {
+			if err := tx.Exec("INSERT INTO topics (id, name, is_icelandic) VALUES (?, ?, ?)", topic.Id, topic.Name, topic.IsIcelandic).Error; err != nil {
+				return err
+			}
+			topicIds = append(topicIds, topic.Id)
+		}
+		for _, link := range fixtures.TopicsToQuotes {
+			if err := linkQuote(tx, link.TopicId, link.QuoteId); err != nil {
+				return err
+			}
+		}
+		for _, user := range fixtures.Users {
+			err := tx.Exec("INSERT INTO users (id, name, email, api_key, password_hash, tier) VALUES (?, ?, ?, ?, ?, ?)",
+				user.Id, user.Name, user.Email, user.ApiKey, user.PasswordHash, user.Tier).Error
+			if err != nil {
+				return err
+			}
+		}
+		//The rows have their ids, the next ones come after them
+		for _, table := range []string{"authors", "quotes", "topics", "users"} {
+			if err := tx.Exec("SELECT setval(pg_get_serial_sequence(?, 'id'), (SELECT coalesce(max(id), 0) + 1 FROM "+table+"), false)", table).Error; err != nil {
+				return err
+			}
+		}
+
+		quoteIds := []int{}
+		for _, quote := range fixtures.Quotes {
+			quoteIds = append(quoteIds, quote.Id)
+		}
+		if err := recordDuplicates(tx, quoteIds...); err != nil {
+			return err
+		}
+		if err := recomputeTopics(tx, topicIds); err != nil {
+			return err
+		}
+		return recomputeAuthors(tx, authorIds)
+	})
+	if err != nil {
+		return err
+	}
+
+	views := &viewsPostgres{db: db}
+	for _, view := range AllViews() {
+		if err := views.Refresh(strings.ToLower(view)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
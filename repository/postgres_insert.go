@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var quoteExistsRegex = regexp.MustCompile(`duplicate key value violates unique constraint "index_quotes_on_live_(public|private_user_id)_quote"`)
+
+func (repo *quotesPostgres) Insert(newQuote structs.QuoteInsertModel) (structs.QuoteDBModel, error) {
+	var quote structs.QuoteDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		quote, err = insertQuote(tx, newQuote)
+		return err
+	})
+	return quote, err
+}
+
+//insertQuote is the insert of Insert in the transaction, which the approval of a submission shares
+func insertQuote(tx *gorm.DB, newQuote structs.QuoteInsertModel) (structs.QuoteDBModel, error) {
+	existing, err := existingQuote(tx, newQuote.Quote, newQuote.UserId)
+	if err != nil {
+		return existing, err
+	}
+	if existing.Id != 0 {
+		return existing, ErrQuoteExists
+	}
+
+	authorId, err := insertAuthorId(tx, newQuote)
+	if err != nil {
+		return structs.QuoteDBModel{}, err
+	}
+
+	quote := structs.QuoteDBModel{AuthorId: authorId, Quote: newQuote.Quote, IsIcelandic: newQuote.IsIcelandic, IsPrivate: newQuote.IsPrivate, UserId: newQuote.UserId}
+	columns := []string{"author_id", "quote", "is_icelandic", "is_private"}
+	if newQuote.UserId > 0 {
+		columns = append(columns, "user_id")
+	}
+	if err := tx.Table("quotes").Select(columns).Create(&quote).Error; err != nil {
+		if quoteExistsRegex.MatchString(err.Error()) {
+			return quote, ErrQuoteExists
+		}
+		return quote, err
+	}
+	if err := tx.Exec("UPDATE quotes SET tsv = setweight(to_tsvector(search_config(is_icelandic), quote), 'B'), normalized = normalize_quote(quote) WHERE id = ?", quote.Id).Error; err != nil {
+		return quote, err
+	}
+	if err := recordDuplicates(tx, quote.Id); err != nil {
+		return quote, err
+	}
+
+	for _, topicName := range newQuote.Topics {
+		if err := linkTopic(tx, topicName, quote); err != nil {
+			return quote, err
+		}
+	}
+	if err := recomputeTopicsOfQuote(tx, quote.Id); err != nil {
+		return quote, err
+	}
+
+	return quote, recomputeAuthors(tx, []int{authorId})
+}
+
+//existingQuote returns the live quote, public or private to the user, with the same text or, failing that, the first
+//one with the same normalized text. Soft deleted quotes and other users' private quotes are not duplicates. The exact
+//text is looked up once among the public quotes and once among the user's private ones, each predicate matching one
+//of the partial unique indexes of 0024_unique_live_visible_quotes
+func existingQuote(tx *gorm.DB, text string, userId int) (structs.QuoteDBModel, error) {
+	var existing structs.QuoteDBModel
+	err := tx.Table("quotes").Where("quote = ? and deleted_at is null and not is_private", text).Limit(1).Find(&existing).Error
+	if err != nil || existing.Id != 0 {
+		return existing, err
+	}
+	if userId > 0 {
+		err := tx.Table("quotes").Where("user_id = ? and quote = ? and deleted_at is null and is_private", userId, text).Limit(1).Find(&existing).Error
+		if err != nil || existing.Id != 0 {
+			return existing, err
+		}
+	}
+	//Variants that only differ by punctuation, case or a trailing attribution are duplicates as well
+	err = tx.Table("quotes").Where("normalized = normalize_quote(?) and normalized <> ''", text).
+		Where("deleted_at is null and (not is_private or user_id = ?)", userId).Order("id").Limit(1).Find(&existing).Error
+	return existing, err
+}
+
+//insertAuthorId returns the id of the quote's author, which must exist if given by id, ErrNotFound otherwise
+func insertAuthorId(tx *gorm.DB, newQuote structs.QuoteInsertModel) (int, error) {
+	if newQuote.AuthorId <= 0 {
+		return upsertAuthor(tx, newQuote.Author)
+	}
+	var author structs.AuthorDBModel
+	if err := tx.Table("authors").Where("id = ? and deleted_at is null", newQuote.AuthorId).Limit(1).Find(&author).Error; err != nil {
+		return 0, err
+	}
+	if author.Id == 0 {
+		return 0, ErrNotFound
+	}
+	return author.Id, nil
+}
+
+//upsertAuthor returns the id of the live author with the given name or alias, inserting the author if needed.
+//ErrAuthorDeleted if only a soft deleted author has the name
+func upsertAuthor(tx *gorm.DB, name string) (int, error) {
+	if aliasId, err := aliasAuthorId(tx, name); err != nil || aliasId != 0 {
+		return aliasId, err
+	}
+	author := structs.AuthorDBModel{Name: name}
+	err := tx.Table("authors").Select("name").Clauses(clause.OnConflict{DoNothing: true}).Create(&author).Error
+	if err != nil || author.Id != 0 {
+		return author.Id, err
+	}
+	if err := tx.Table("authors").Where("name = ? and deleted_at is null", name).Limit(1).Find(&author).Error; err != nil {
+		return 0, err
+	}
+	if author.Id == 0 {
+		return 0, ErrAuthorDeleted
+	}
+	return author.Id, nil
+}
+
+//linkTopic links the quote to the live topic with the given name (case insensitive), creating the topic if needed.
+//ErrTopicDeleted if only a soft deleted topic has the name
+func linkTopic(tx *gorm.DB, topicName string, quote structs.QuoteDBModel) error {
+	topicName = strings.TrimSpace(topicName)
+	if topicName == "" {
+		return nil
+	}
+	var topic structs.TopicDBModel
+	if err := tx.Table("topics").Where("lower(name) = lower(?) and deleted_at is null", topicName).Limit(1).Find(&topic).Error; err != nil {
+		return err
+	}
+	if topic.Id == 0 {
+		var deleted int64
+		if err := tx.Table("topics").Where("lower(name) = lower(?)", topicName).Count(&deleted).Error; err != nil {
+			return err
+		}
+		if deleted > 0 {
+			return ErrTopicDeleted
+		}
+
+		topic = structs.TopicDBModel{Name: topicName, IsIcelandic: quote.IsIcelandic}
+		if err := tx.Table("topics").Select("name", "is_icelandic").Create(&topic).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("UPDATE topics SET tsv = setweight(to_tsvector(search_config(is_icelandic), name), 'A') WHERE id = ?", topic.Id).Error; err != nil {
+			return err
+		}
+	}
+	return linkQuote(tx, topic.Id, quote.Id)
+}
+
+//linkQuote links the quote to the topic unless it already is, bringing back the link if it had been soft deleted. A
+//quote is linked to a topic at most once, see 0007_create_view_refreshes
+func linkQuote(tx *gorm.DB, topicId int, quoteId int) error {
+	return tx.Exec(`INSERT INTO topicstoquotes (topic_id, quote_id) VALUES (?, ?)
+		ON CONFLICT (topic_id, quote_id) DO UPDATE SET deleted_at = null`, topicId, quoteId).Error
+}
+
+//recomputeAuthors sets nr_of_english_quotes, nr_of_icelandic_quotes, has_icelandic_quotes and tsv of the given authors from their live, public quotes,
+//none if the author is deleted, and their name and aliases, which are in both the english and the icelandic configuration
+func recomputeAuthors(tx *gorm.DB, authorIds []int) error {
+	return tx.Exec(`UPDATE authors SET
+			nr_of_english_quotes = counts.english,
+			nr_of_icelandic_quotes = counts.icelandic,
+			has_icelandic_quotes = counts.icelandic > 0,
+			tsv = setweight(name_tsvector(authors.name), 'A') ||
+				setweight(name_tsvector((SELECT string_agg(alias, ' ') FROM author_aliases WHERE author_id = authors.id)), 'B')
+		FROM (
+			SELECT a.id,
+				count(q.id) FILTER (WHERE NOT q.is_icelandic) AS english,
+				count(q.id) FILTER (WHERE q.is_icelandic) AS icelandic
+			FROM authors a LEFT JOIN quotes q ON q.author_id = a.id AND a.deleted_at is null AND q.deleted_at is null AND NOT q.is_private
+			WHERE a.id in ?
+			GROUP BY a.id
+		) AS counts
+		WHERE authors.id = counts.id`, authorIds).Error
+}
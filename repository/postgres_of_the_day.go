@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type ofTheDayPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *ofTheDayPostgres) GetQuoteOfTheDay(language string) (structs.QodViewDBModel, error) {
+	var quote structs.QodViewDBModel
+	err := repo.qodLanguageSQL(language).Where("date = current_date").Scan(&quote).Error
+	return quote, err
+}
+
+func (repo *ofTheDayPostgres) QuoteOfTheDayHistory(language string, minimum string) ([]structs.QodViewDBModel, error) {
+	var quotes []structs.QodViewDBModel
+	dbPointer := repo.qodLanguageSQL(language)
+
+	//Not maximum because then possibility of endless cycle with the if statement below!
+	if minimum != "" {
+		dbPointer = dbPointer.Where("date >= ?", minimum)
+	}
+	err := dbPointer.Where("date <= current_date").Order("date DESC").Find(&quotes).Error
+	return quotes, err
+}
+
+//SetQuoteOfTheDay inserts a new row into qod/qodice table
+func (repo *ofTheDayPostgres) SetQuoteOfTheDay(language string, date string, quoteId int) error {
+	switch strings.ToLower(language) {
+	case "icelandic":
+		return repo.db.Exec("insert into qodice (quote_id, date) values((select id from quotes where id = ? and is_icelandic and deleted_at is null and not is_private), ?) on conflict (date) do update set quote_id = ?", quoteId, date, quoteId).Error
+	default:
+		return repo.db.Exec("insert into qod (quote_id, date) values((select id from quotes where id = ? and not is_icelandic and deleted_at is null and not is_private), ?) on conflict (date) do update set quote_id = ?", quoteId, date, quoteId).Error
+	}
+}
+
+//SetRandomQuoteOfTheDay sets a random quote as the qod for today (if language=icelandic is supplied then it adds the random qod to the icelandic qod table)
+func (repo *ofTheDayPostgres) SetRandomQuoteOfTheDay(language string) error {
+	var quoteItem structs.QuoteDBModel
+	dbPointer := repo.db.Table("quotes").Where("deleted_at is null and not is_private")
+	dbPointer = quoteLanguageSQL(language, dbPointer)
+	if strings.ToLower(language) != "icelandic" {
+		dbPointer = dbPointer.Where("Random() < 0.005")
+	}
+
+	err := dbPointer.Order("random()").Limit(1).Scan(&quoteItem).Error
+	if err != nil {
+		return err
+	}
+
+	return repo.SetQuoteOfTheDay(language, time.Now().Format("2006-01-02"), quoteItem.Id)
+}
+
+func (repo *ofTheDayPostgres) GetAuthorOfTheDay(language string) (structs.AodDBModel, error) {
+	var author structs.AodDBModel
+	err := repo.aodLanguageSQL(language).Where("date = current_date").Scan(&author).Error
+	return author, err
+}
+
+func (repo *ofTheDayPostgres) AuthorOfTheDayHistory(language string, minimum string) ([]structs.AodDBModel, error) {
+	var authors []structs.AodDBModel
+	dbPointer := repo.aodLanguageSQL(language)
+
+	//Not maximum because then possibility of endless cycle with the if statement below!
+	if minimum != "" {
+		dbPointer = dbPointer.Where("date >= ?", minimum)
+	}
+	err := dbPointer.Where("date <= current_date").Order("date DESC").Find(&authors).Error
+	return authors, err
+}
+
+//SetAuthorOfTheDay inserts a new row into the aod/aodice table
+func (repo *ofTheDayPostgres) SetAuthorOfTheDay(language string, date string, authorId int) error {
+	switch strings.ToLower(language) {
+	case "icelandic":
+		return repo.db.Exec("insert into aodice (author_id, date) values((select id from authors where id = ? and has_icelandic_quotes and deleted_at is null), ?) on conflict (date) do update set author_id = ?", authorId, date, authorId).Error
+	default:
+		return repo.db.Exec("insert into aod (author_id, date) values((select id from authors where id = ? and not has_icelandic_quotes and deleted_at is null), ?) on conflict (date) do update set author_id = ?", authorId, date, authorId).Error
+	}
+}
+
+//SetRandomAuthorOfTheDay sets a random author as the aod for today (if language=icelandic is supplied then it adds the random aod to the icelandic aod table)
+func (repo *ofTheDayPostgres) SetRandomAuthorOfTheDay(language string) error {
+	var authorItem structs.AuthorDBModel
+
+	if language == "" {
+		language = "english"
+	}
+	dbPointer := repo.db.Table("authors").Where("deleted_at is null")
+	dbPointer = authorLanguageSQL(language, dbPointer)
+
+	err := dbPointer.Order("random()").Limit(1).Scan(&authorItem).Error
+	if err != nil {
+		return err
+	}
+
+	return repo.SetAuthorOfTheDay(language, time.Now().Format("2006-01-02"), authorItem.Id)
+}
+
+//aodLanguageSQL returns a pointer to the AOD view for the given language
+func (repo *ofTheDayPostgres) aodLanguageSQL(language string) *gorm.DB {
+	switch strings.ToLower(language) {
+	case "icelandic":
+		return repo.db.Table("aodiceview")
+	default:
+		return repo.db.Table("aodview")
+	}
+}
+
+//qodLanguageSQL returns a pointer to the QOD view for the given language
+func (repo *ofTheDayPostgres) qodLanguageSQL(language string) *gorm.DB {
+	switch strings.ToLower(language) {
+	case "icelandic":
+		return repo.db.Table("qodiceview")
+	default:
+		return repo.db.Table("qodview")
+	}
+}
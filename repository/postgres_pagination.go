@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//maxExactTotal is the number of rows up to which the total of a list / search is counted, above it counting every row
+//would take too long and the total is estimated
+const maxExactTotal = 10000
+
+//countTotal returns the number of rows of the query, which must not be ordered or paginated. Counting stops after
+//maxExactTotal rows, the total of a larger query is the planner's estimate of its rows (at least maxExactTotal + 1)
+func countTotal(db *gorm.DB, rows *gorm.DB) (structs.TotalDBModel, error) {
+	rows = rows.Select("1")
+	var count int64
+	err := db.Table("(?) as counted", rows.Session(&gorm.Session{}).Limit(maxExactTotal+1)).Count(&count).Error
+	if err != nil || count <= maxExactTotal {
+		return structs.TotalDBModel{Total: int(count)}, err
+	}
+
+	estimate, err := estimateRows(db, rows)
+	if err != nil {
+		return structs.TotalDBModel{}, err
+	}
+	if estimate <= maxExactTotal {
+		estimate = maxExactTotal + 1
+	}
+	return structs.TotalDBModel{Total: estimate, Estimated: true}, nil
+}
+
+//estimateRows returns the number of rows the planner expects the query to return, from EXPLAIN. The query is run
+//through database/sql so that its bind variables stay parameters
+func estimateRows(db *gorm.DB, rows *gorm.DB) (int, error) {
+	statement := rows.Session(&gorm.Session{DryRun: true}).Find(&[]map[string]interface{}{}).Statement
+	sqlDB, err := db.DB()
+	if err != nil {
+		return 0, err
+	}
+	var explained string
+	if err := sqlDB.QueryRow("EXPLAIN (FORMAT JSON) "+statement.SQL.String(), statement.Vars...).Scan(&explained); err != nil {
+		return 0, err
+	}
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal([]byte(explained), &plans); err != nil || len(plans) == 0 {
+		return 0, err
+	}
+	return int(plans[0].Plan.Rows), nil
+}
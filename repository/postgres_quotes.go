@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type quotesPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *quotesPostgres) GetQuotes(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	dbPointer := repo.db.Table("searchview").Order("quote_id ASC")
+	if request.AuthorId > 0 {
+		dbPointer = dbPointer.
+			Where("author_id = ?", request.AuthorId)
+		dbPointer = pagination(request, dbPointer)
+	} else {
+		dbPointer = dbPointer.Where("quote_id in ?", request.Ids)
+	}
+	err := dbPointer.Find(&quotes).Error
+	return quotes, err
+}
+
+func (repo *quotesPostgres) GetByAuthor(authorId int, language string, limit int) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	dbPointer := repo.db.Table("searchview").Where("author_id = ?", authorId)
+
+	//An icelandic quote from the particular author
+	dbPointer = quoteLanguageSQL(language, dbPointer)
+	err := dbPointer.Limit(limit).Find(&quotes).Error
+	return quotes, err
+}
+
+func (repo *quotesPostgres) List(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	dbPointer, err := repo.listRows(request)
+	if err != nil {
+		return quotes, err
+	}
+	column, orderDirection := quotesListOrder(request.OrderConfig)
+	err = pagination(afterCursor(request), dbPointer.Order(column+" "+orderDirection)).Order("quote_id").
+		Find(&quotes).
+		Error
+	return quotes, err
+}
+
+func (repo *quotesPostgres) ListTotal(request structs.Request) (structs.TotalDBModel, error) {
+	dbPointer, err := repo.listRows(request)
+	if err != nil {
+		return structs.TotalDBModel{}, err
+	}
+	return countTotal(repo.db, dbPointer)
+}
+
+//listRows returns the DB pointer to the quotes List pages through, i.e. in the request's language, between the
+//orderConfig's minimum and maximum and after the cursor, unordered
+func (repo *quotesPostgres) listRows(request structs.Request) (*gorm.DB, error) {
+	dbPointer := repo.db.Table("searchview")
+	dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+	dbPointer = quoteSourceSQL(request.Verified, dbPointer)
+	column, orderDirection := quotesListOrder(request.OrderConfig)
+	if column != "quote_count" {
+		dbPointer = maxMinNumberSQL(request.OrderConfig, column, dbPointer)
+	}
+	return cursorSQL(request.Cursor, "quotes", column, orderDirection, "quote_id", dbPointer)
+}
+
+//quotesListOrder returns the column the quotes are listed by and in which direction, the most popular first unless
+//reversed
+func quotesListOrder(orderConfig structs.OrderConfig) (string, string) {
+	orderDirection := "ASC"
+	if orderConfig.Reverse {
+		orderDirection = "DESC"
+	}
+
+	switch strings.ToLower(orderConfig.OrderBy) {
+	case "popularity":
+		if orderConfig.Reverse {
+			return "quote_count", "ASC"
+		}
+		return "quote_count", "DESC"
+	case "length":
+		return "length(quote)", orderDirection
+	}
+	return "quote_id", orderDirection
+}
+
+func (repo *quotesPostgres) Random(request structs.Request) (structs.TopicViewDBModel, error) {
+	const NR_OF_QUOTES = 639028
+	const NR_OF_ENGLISH_QUOTES = 634841
+	var dbPointer *gorm.DB
+	var topicResult structs.TopicViewDBModel
+
+	var shouldDoQuick = true
+
+	query, err := parseSearchQuery(request.SearchString)
+	if err != nil {
+		return topicResult, err
+	}
+
+	configs := searchConfigs(request.Language, query)
+	table := "searchview"
+	//Random quote from a particular topic
+	if request.TopicId > 0 {
+		table = "topicsview"
+		shouldDoQuick = false
+	}
+	if len(query.groups) > 0 {
+		tables, vars := searchQueryTablesSQL(query, configs)
+		dbPointer = repo.db.Table(table+", "+tables, vars...).Where("( quote_tsv @@ plainq OR quote_tsv @@ phraseq)")
+	} else {
+		dbPointer = repo.db.Table(table)
+	}
+	if request.TopicId > 0 {
+		dbPointer = dbPointer.Where("topic_id = ?", request.TopicId)
+	}
+	if !query.isEmpty() {
+		dbPointer = searchQueryFiltersSQL(query, configs, "quote_tsv", dbPointer)
+		shouldDoQuick = false
+	}
+
+	//Random quote from a particular author
+	if request.AuthorId > 0 {
+		dbPointer = dbPointer.Where("author_id = ?", request.AuthorId)
+		shouldDoQuick = false
+	}
+
+	//Random quote from a particular language
+	dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+	dbPointer = quoteSourceSQL(request.Verified, dbPointer)
+
+	if strings.ToLower(request.Language) == "icelandic" || request.Verified {
+		shouldDoQuick = false
+	}
+
+	//Order by used to get random quote if there are "few" rows returned
+	if !shouldDoQuick {
+		dbPointer = dbPointer.Order("random()") //Randomized, O( n*log(n) )
+	} else {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		if strings.ToLower(request.Language) == "english" {
+			dbPointer = dbPointer.Offset(r.Intn(NR_OF_ENGLISH_QUOTES))
+		} else {
+			dbPointer = dbPointer.Offset(r.Intn(NR_OF_QUOTES))
+		}
+	}
+
+	err = dbPointer.Limit(1).Find(&topicResult).Error
+	return topicResult, err
+}
+
+func (repo *quotesPostgres) Search(request structs.Request) (structs.SearchResultsDBModel, error) {
+	matches, query, err := repo.searchMatches(request)
+	if err != nil || query.isEmpty() {
+		return structs.SearchResultsDBModel{Results: []structs.TopicViewDBModel{}, Strategy: StrategyFullText}, err
+	}
+	//Every query below starts from the same matches, a new session keeps the order out of the total and the facets
+	matches = matches.Session(&gorm.Session{})
+	fallbackOrder, vars := fallbackOrderSQL(query, searchConfigs(request.Language, query))
+	//Order by authorid to have definitive order (when for examplke some quotes rank the same for plain, phrase, general and similarity)
+	ordered := matches.Clauses(clause.OrderBy{
+		Expression: clause.Expr{SQL: fallbackOrder + ", phraserank DESC,similarity(name, ?) DESC, plainrank DESC, generalrank DESC, author_id DESC", Vars: append(vars, query.text()), WithoutParentheses: true},
+	})
+	return repo.searchResults(request, matches, ordered)
+}
+
+func (repo *quotesPostgres) SearchQuotes(request structs.Request) (structs.SearchResultsDBModel, error) {
+	matches, query, err := repo.searchQuotesMatches(request)
+	if err != nil || query.isEmpty() {
+		return structs.SearchResultsDBModel{Results: []structs.TopicViewDBModel{}, Strategy: StrategyFullText}, err
+	}
+	//Every query below starts from the same matches, a new session keeps the order out of the total and the facets
+	matches = matches.Session(&gorm.Session{})
+	fallbackOrder, vars := fallbackOrderSQL(query, searchConfigs(request.Language, query))
+	//Order by quote_id to have definitive order (when for examplke some quotes rank the same for plain, phrase and general)
+	ordered := matches.
+		Clauses(clause.OrderBy{
+			Expression: clause.Expr{SQL: fallbackOrder + ", plainrank DESC, phraserank DESC, generalrank DESC, quote_id DESC", Vars: vars, WithoutParentheses: true},
+		})
+	return repo.searchResults(request, matches, ordered)
+}
+
+//searchResults returns the page of the ordered matches along with, if the request asks for them, the total and the
+//facets of the matches. The matches are only built once for all of them
+func (repo *quotesPostgres) searchResults(request structs.Request, matches *gorm.DB, ordered *gorm.DB) (structs.SearchResultsDBModel, error) {
+	results := structs.SearchResultsDBModel{Results: []structs.TopicViewDBModel{}, Strategy: StrategyFullText}
+	if err := pagination(request, ordered).Find(&results.Results).Error; err != nil {
+		return results, err
+	}
+	results.Strategy = resultsStrategy(results.Results)
+	var err error
+	if request.WithPagination {
+		if results.Total, err = countTotal(repo.db, matches.Session(&gorm.Session{})); err != nil {
+			return results, err
+		}
+	}
+	if request.Facets != nil {
+		results.Facets, err = repo.facets(matches.Session(&gorm.Session{}), request.Facets.Limit)
+	}
+	return results, err
+}
+
+//searchMatches returns the DB pointer to all the rows the general search matches, unordered and not paginated
+func (repo *quotesPostgres) searchMatches(request structs.Request) (*gorm.DB, searchQuery, error) {
+	dbPointer, query, err := repo.getBasePointer(request)
+	if err != nil || query.isEmpty() {
+		return dbPointer, query, err
+	}
+	dbPointer = searchQueryFiltersSQL(query, searchConfigs(request.Language, query), "tsv", dbPointer)
+
+	//Particular language search
+	dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+	dbPointer = quoteSourceSQL(request.Verified, dbPointer)
+	dbPointer = facetFiltersSQL(request, dbPointer)
+
+	if len(query.groups) > 0 {
+		fullText := "( tsv @@ plainq OR tsv @@ phraseq OR ? % ANY(STRING_TO_ARRAY(name,' ')) OR tsv @@ generalq)"
+		dbPointer = matchSQL(query, searchConfigs(request.Language, query), fullText, []interface{}{query.text()}, dbPointer)
+	}
+	return dbPointer, query, nil
+}
+
+//searchQuotesMatches returns the DB pointer to all the rows the quotes search matches, unordered and not paginated
+func (repo *quotesPostgres) searchQuotesMatches(request structs.Request) (*gorm.DB, searchQuery, error) {
+	dbPointer, query, err := repo.getBasePointer(request)
+	if err != nil || query.isEmpty() {
+		return dbPointer, query, err
+	}
+	dbPointer = searchQueryFiltersSQL(query, searchConfigs(request.Language, query), "quote_tsv", dbPointer)
+
+	if request.AuthorId > 0 {
+		dbPointer = dbPointer.Where("author_id = ?", request.AuthorId)
+	}
+
+	//Particular language search
+	dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+	dbPointer = quoteSourceSQL(request.Verified, dbPointer)
+	dbPointer = facetFiltersSQL(request, dbPointer)
+
+	if len(query.groups) > 0 {
+		fullText := "( quote_tsv @@ plainq OR quote_tsv @@ phraseq OR quote_tsv @@ generalq)"
+		dbPointer = matchSQL(query, searchConfigs(request.Language, query), fullText, nil, dbPointer)
+	}
+	return dbPointer, query, nil
+}
+
+func (repo *quotesPostgres) IncrementCount(quoteIds []int, by int) error {
+	return repo.db.Exec("UPDATE quotes SET count = count + ? where id in (?) returning *", by, quoteIds).Error
+}
+
+//getBasePointer returns a base DB pointer for a table for a thorough full text search along with the parsed search
+//string, or a *QueryError if the search string is not a valid query
+func (repo *quotesPostgres) getBasePointer(request structs.Request) (*gorm.DB, searchQuery, error) {
+	query, err := parseSearchQuery(request.SearchString)
+	if err != nil {
+		return nil, query, err
+	}
+	table := "searchview"
+	//TODO: Validate that this topicId exists
+	if request.TopicId > 0 {
+		table = "topicsview"
+	}
+	configs := searchConfigs(request.Language, query)
+	tables, vars := searchQueryTablesSQL(query, configs)
+	dbPointer := repo.db.Table(table+", "+tables, vars...)
+	strategy, columnVars := strategySQL(query, configs)
+	columns := "*, ts_rank(quote_tsv, plainq) as plainrank, ts_rank(quote_tsv, phraseq) as phraserank, ts_rank(quote_tsv, generalq) as generalrank, " + strategy
+	if request.Highlight != nil {
+		//generalq has every term of the query, i.e. every word that ranked the row, and the quote is parsed with the
+		//configuration of its language so that the folded words are highlighted
+		dbPointer = dbPointer.Select(columns+", ts_headline(search_config(is_icelandic), quote, generalq, ?) as highlight", append(columnVars, headlineOptions(*request.Highlight))...)
+	} else {
+		dbPointer = dbPointer.Select(columns, columnVars...)
+	}
+
+	if request.TopicId > 0 {
+		dbPointer = dbPointer.Where("topic_id = ?", request.TopicId)
+	}
+	return dbPointer, query, nil
+}
+
+func (repo *quotesPostgres) GetPrivateQuotes(userId int, request structs.Request) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	dbPointer := repo.db.Table("quotes q").
+		Select(quoteRowSQL).
+		Joins("inner join authors a on a.id = q.author_id").
+		Where("q.is_private and q.user_id = ? and q.deleted_at is null and a.deleted_at is null", userId).
+		Order("q.id ASC")
+	if request.AuthorId > 0 {
+		dbPointer = dbPointer.Where("q.author_id = ?", request.AuthorId)
+		dbPointer = pagination(request, dbPointer)
+	} else {
+		dbPointer = dbPointer.Where("q.id in ?", request.Ids)
+	}
+	err := dbPointer.Find(&quotes).Error
+	return quotes, err
+}
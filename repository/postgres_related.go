@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//Related scores every quote that shares a lexeme of the quote_tsv, through the gin index and a tsquery of the
+//quote's lexemes, or a topic with the quote, or its author if sameAuthor is set
+func (repo *quotesPostgres) Related(request structs.Request) ([]structs.SearchViewDBModel, error) {
+	var quotes []structs.SearchViewDBModel
+	var quote structs.SearchViewDBModel
+	err := repo.db.Table("searchview").Where("quote_id = ?", request.Id).Take(&quote).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return quotes, ErrNotFound
+	}
+	if err != nil {
+		return quotes, err
+	}
+
+	target := repo.db.Table("searchview").
+		Select("quote_id, author_id, tsvector_to_array(quote_tsv) as lexemes, array(select topic_id from topicstoquotes where topicstoquotes.quote_id = searchview.quote_id and deleted_at is null) as topics").
+		Where("quote_id = ?", request.Id)
+	dbPointer := repo.db.Table("searchview s, (?) as target", target).
+		Select(`s.*,
+			?::float * cardinality(array(select unnest(tsvector_to_array(s.quote_tsv)) intersect select unnest(target.lexemes))) /
+				greatest(cardinality(array(select unnest(tsvector_to_array(s.quote_tsv)) union select unnest(target.lexemes))), 1) +
+			?::float * cardinality(array(select topic_id from topicstoquotes where topicstoquotes.quote_id = s.quote_id and deleted_at is null intersect select unnest(target.topics))) /
+				greatest(cardinality(target.topics), 1) +
+			case when ? and s.author_id = target.author_id then ?::float else 0 end as score`,
+			relatedLexemeWeight, relatedTopicWeight, request.SameAuthor, relatedAuthorWeight).
+		Where("s.quote_id <> target.quote_id").
+		//The lexemes are quoted, so that they are taken as is, and or-ed into a tsquery
+		Where(`( s.quote_tsv @@ (select string_agg('''' || replace(replace(l, '\', '\\'), '''', '''''') || '''', ' | ') from unnest(target.lexemes) l)::tsquery
+			OR s.quote_id in (select quote_id from topicstoquotes where topic_id = any(target.topics) and deleted_at is null)
+			OR (? and s.author_id = target.author_id) )`, request.SameAuthor)
+	if request.ExcludeSameAuthor {
+		dbPointer = dbPointer.Where("s.author_id <> target.author_id")
+	}
+
+	dbPointer = quoteLanguageSQL(request.Language, dbPointer)
+	err = pagination(request, dbPointer.Order("score DESC, quote_id")).Find(&quotes).Error
+	return quotes, err
+}
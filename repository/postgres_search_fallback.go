@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//stopWordsSQL returns the condition that the query is only stop words, i.e. that one of the configurations reduces
+//its text to an empty tsquery, e.g. the english one for "to be or not to be". It is part of the search query itself,
+//so that the stop words are the ones of the dictionaries without asking Postgres for them first. The icelandic
+//configuration has no stop words. A query with an OR or an excluded term is never only stop words, the fallback only
+//has the phrase of its terms
+func stopWordsSQL(query searchQuery, configs []string) (string, []interface{}) {
+	if query.hasOperators() || len(query.groups) == 0 {
+		return "false", nil
+	}
+	empty := []string{}
+	vars := []interface{}{}
+	for _, config := range configs {
+		empty = append(empty, "numnode("+tsquerySQL(queryTerm{}, config)+") = 0")
+		vars = append(vars, query.text())
+	}
+	return "(" + strings.Join(empty, " OR ") + ")", vars
+}
+
+//matchSQL adds the condition of the search, the full text search fullText unless the query is only stop words, and
+//then the fallback: the normalized quote is similar by trigrams to the phrase of the query (pg_trgm's <% operator),
+//which every quote with the whole phrase also is. It is answered by the trigram index on quotes.normalized, see
+//0014_add_quote_normalization
+func matchSQL(query searchQuery, configs []string, fullText string, fullTextVars []interface{}, dbPointer *gorm.DB) *gorm.DB {
+	stopWords, stopWordsVars := stopWordsSQL(query, configs)
+	vars := append(append(append([]interface{}{}, stopWordsVars...), fullTextVars...), stopWordsVars...)
+	vars = append(vars, query.phrase())
+	return dbPointer.Where("((NOT "+stopWords+" AND "+fullText+") OR ("+stopWords+" AND quote_id in (select id from quotes where ? <% normalized)))", vars...)
+}
+
+//strategySQL returns the column strategy, what each row was matched with: StrategyFullText unless the query is only
+//stop words, and then StrategyPhrase for the quotes with the phrase of the query as whole words and StrategyTrigram
+//for the others
+func strategySQL(query searchQuery, configs []string) (string, []interface{}) {
+	stopWords, vars := stopWordsSQL(query, configs)
+	return "CASE WHEN NOT " + stopWords + " THEN '" + StrategyFullText + "' WHEN " + phraseSQL + " THEN '" + StrategyPhrase +
+		"' ELSE '" + StrategyTrigram + "' END as strategy", append(vars, wholePhrase(query))
+}
+
+//fallbackOrderSQL returns the first expressions of the order of a search, which order the rows of a query of stop
+//words by the phrase, then by how similar the whole quote is to the phrase, i.e. the quotes that are little more than
+//the phrase first, and are constant for other queries
+func fallbackOrderSQL(query searchQuery, configs []string) (string, []interface{}) {
+	stopWords, stopWordsVars := stopWordsSQL(query, configs)
+	vars := append(append([]interface{}{}, stopWordsVars...), wholePhrase(query))
+	vars = append(append(vars, stopWordsVars...), query.phrase())
+	vars = append(vars, stopWordsVars...)
+	return "CASE WHEN " + stopWords + " THEN " + phraseSQL + " END DESC, CASE WHEN " + stopWords + " THEN similarity(quote, ?) END DESC, " +
+		"CASE WHEN " + stopWords + " THEN quote_id END DESC", vars
+}
+
+//phraseSQL is the condition that the quote has the phrase of the query as whole words, see wholePhrase. The words
+//have neither % nor _ so they need no escaping
+const phraseSQL = "' ' || normalize_quote(quote) || ' ' like ?"
+
+func wholePhrase(query searchQuery) string {
+	return "% " + query.phrase() + " %"
+}
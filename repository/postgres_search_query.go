@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+//The text search configurations of the two languages. The icelandic one does not stem and folds þ, ð, æ and the
+//accents, see migration 0020, and a search in either language is parsed with both
+const (
+	englishSearchConfig   = "english"
+	icelandicSearchConfig = "icelandic"
+)
+
+//searchConfigs returns the text search configurations of the languages the request and the lang: filters of the
+//query ask for, both if they ask for none (or contradict each other, which matches nothing anyway)
+func searchConfigs(language string, query searchQuery) []string {
+	english, icelandic := true, true
+	languages := []string{strings.ToLower(language)}
+	for _, filter := range query.filters {
+		if filter.field == queryFieldLanguage {
+			languages = append(languages, filter.language())
+		}
+	}
+	for _, asked := range languages {
+		switch asked {
+		case "english":
+			icelandic = false
+		case "icelandic":
+			english = false
+		}
+	}
+	switch {
+	case english && !icelandic:
+		return []string{englishSearchConfig}
+	case icelandic && !english:
+		return []string{icelandicSearchConfig}
+	}
+	return []string{englishSearchConfig, icelandicSearchConfig}
+}
+
+//searchQueryTablesSQL returns the from item with the columns plainq, phraseq and generalq of the query, a one row
+//subquery since a from item can be a function call but not an expression of several. Every word and phrase is
+//passed to plainto_tsquery / phraseto_tsquery as a parameter and the tsqueries combined with && and ||, so the search
+//string can never break the tsquery syntax. plainq requires a term of each group, phraseq a term of each group in
+//order, i.e. one of the phrases of the query, and generalq any of the terms. The tsqueries of each configuration are
+//OR-ed, since each row's tsv only has the lexemes of its own configuration
+func searchQueryTablesSQL(query searchQuery, configs []string) (string, []interface{}) {
+	vars := []interface{}{}
+	plain := []string{}
+	for _, config := range configs {
+		groups := []string{}
+		for _, group := range query.groups {
+			terms := []string{}
+			for _, term := range group {
+				terms = append(terms, tsquerySQL(term, config))
+				vars = append(vars, term.text)
+			}
+			groups = append(groups, "("+strings.Join(terms, " || ")+")")
+		}
+		plain = append(plain, emptyTsquery(strings.Join(groups, " && ")))
+	}
+
+	phrase := []string{}
+	for _, config := range configs {
+		for _, text := range query.phrases() {
+			phrase = append(phrase, tsquerySQL(queryTerm{phrase: true}, config))
+			vars = append(vars, text)
+		}
+	}
+
+	terms := []string{}
+	for _, config := range configs {
+		for _, term := range query.terms() {
+			terms = append(terms, tsquerySQL(term, config))
+			vars = append(vars, term.text)
+		}
+	}
+
+	return "(SELECT (" + strings.Join(plain, " || ") + ") as plainq, (" + strings.Join(phrase, " || ") + ") as phraseq, " +
+		emptyTsquery(strings.Join(terms, " || ")) + " as generalq) as search_query", vars
+}
+
+//searchQueryFiltersSQL adds the excluded terms and the author:, topic: and lang: filters of the query to the sql query
+//for the views, the excluded terms are matched against the tsv column in each of the configurations
+func searchQueryFiltersSQL(query searchQuery, configs []string, tsvColumn string, dbPointer *gorm.DB) *gorm.DB {
+	if len(query.excluded) > 0 {
+		terms := []string{}
+		vars := []interface{}{}
+		for _, config := range configs {
+			for _, term := range query.excluded {
+				terms = append(terms, tsquerySQL(term, config))
+				vars = append(vars, term.text)
+			}
+		}
+		dbPointer = dbPointer.Where("NOT ("+tsvColumn+" @@ ("+strings.Join(terms, " || ")+"))", vars...)
+	}
+
+	for _, filter := range query.filters {
+		in := "in"
+		if filter.negated {
+			in = "not in"
+		}
+		switch filter.field {
+		case queryFieldAuthor:
+			dbPointer = dbPointer.Where("author_id "+in+` (select id from authors where lower(name) = lower(?) and deleted_at is null
+				union select author_id from author_aliases where lower(alias) = lower(?))`, filter.value, filter.value)
+		case queryFieldTopic:
+			dbPointer = dbPointer.Where("quote_id "+in+` (select ttq.quote_id from topicstoquotes ttq inner join topics t on t.id = ttq.topic_id
+				where lower(t.name) = lower(?) and t.deleted_at is null and ttq.deleted_at is null)`, filter.value)
+		case queryFieldLanguage:
+			dbPointer = quoteLanguageSQL(filter.language(), dbPointer)
+		}
+	}
+	return dbPointer
+}
+
+//tsquerySQL returns the tsquery of the term in the configuration, which is one of the constants above and never input
+func tsquerySQL(term queryTerm, config string) string {
+	if term.phrase {
+		return "phraseto_tsquery('" + config + "', ?)"
+	}
+	return "plainto_tsquery('" + config + "', ?)"
+}
+
+//emptyTsquery returns an empty tsquery, which matches nothing, in place of an empty expression
+func emptyTsquery(expression string) string {
+	if expression == "" {
+		return "plainto_tsquery('')"
+	}
+	return "(" + expression + ")"
+}
+
+//headlineOptions returns the ts_headline options of the highlight config. The markers are quoted so that they can
+//contain spaces and commas, and MinWords is half of MaxWords since ts_headline needs it to be lower
+func headlineOptions(config structs.HighlightConfig) string {
+	return fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=%d, MinWords=%d, MaxFragments=%d`,
+		config.Start, config.Stop, config.MaxWords, config.MaxWords/2, config.MaxFragments)
+}
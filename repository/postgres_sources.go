@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type sourcesPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *sourcesPostgres) Create(source *structs.SourceDBModel) error {
+	if err := repo.db.Table("sources").Select("title", "type", "year", "url").Create(source).Error; err != nil {
+		return err
+	}
+	//created_at is set by the database
+	return repo.db.Table("sources").Where("id = ?", source.Id).Find(source).Error
+}
+
+func (repo *sourcesPostgres) Update(source structs.SourceDBModel) (structs.SourceDBModel, error) {
+	result := repo.db.Exec("UPDATE sources SET title = ?, type = ?, year = nullif(?, 0), url = nullif(?, ''), updated_at = current_timestamp WHERE id = ?",
+		source.Title, source.Type, source.Year, source.Url, source.Id)
+	if result.Error != nil {
+		return source, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return source, ErrNotFound
+	}
+	var updated structs.SourceDBModel
+	err := repo.db.Table("sources").Where("id = ?", source.Id).Find(&updated).Error
+	return updated, err
+}
+
+func (repo *sourcesPostgres) List(request structs.Request) ([]structs.SourceDBModel, error) {
+	var sources []structs.SourceDBModel
+	dbPointer := repo.db.Table("sources").Order("lower(title), id")
+	if request.SearchString != "" {
+		dbPointer = dbPointer.Where("title ILIKE ?", "%"+request.SearchString+"%")
+	}
+	if request.Type != "" {
+		dbPointer = dbPointer.Where("type = ?", request.Type)
+	}
+	err := pagination(request, dbPointer).Find(&sources).Error
+	return sources, err
+}
+
+func (repo *sourcesPostgres) Cite(quoteId int, sourceId int, status string) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var source interface{}
+		if sourceId > 0 {
+			var count int64
+			if err := tx.Table("sources").Where("id = ?", sourceId).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return ErrNotFound
+			}
+			source = sourceId
+		}
+		result := tx.Exec("UPDATE quotes SET source_id = ?, source_status = ? WHERE id = ? AND deleted_at is null", source, status, quoteId)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (repo *sourcesPostgres) ForQuotes(quoteIds []int) ([]structs.CitationDBModel, error) {
+	var citations []structs.CitationDBModel
+	if len(quoteIds) == 0 {
+		return citations, nil
+	}
+	err := repo.db.Table("quotes q").
+		Select("q.id as quote_id, s.id as source_id, s.title, s.type, s.year, s.url, q.source_status as status").
+		Joins("left join sources s on s.id = q.source_id").
+		Where("q.id in ? and (q.source_id is not null or q.source_status <> ?)", quoteIds, structs.SourceUnverified).
+		Find(&citations).Error
+	return citations, err
+}
+
+//quoteSourceSQL adds to the sql query for the views a condition of whether only the verified quotes are to be fetched
+func quoteSourceSQL(verified bool, dbPointer *gorm.DB) *gorm.DB {
+	if verified {
+		dbPointer = dbPointer.Where("quote_id in (select id from quotes where source_status = ?)", structs.SourceVerified)
+	}
+	return dbPointer
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type submissionsPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *submissionsPostgres) Create(submission *structs.SubmissionDBModel) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		existing, err := existingQuote(tx, submission.Quote, submission.UserId)
+		if err != nil {
+			return err
+		}
+		if existing.Id != 0 {
+			return ErrQuoteExists
+		}
+
+		columns := []string{"user_id", "quote", "is_icelandic", "topics", "status"}
+		if submission.AuthorId > 0 {
+			if _, err := insertAuthorId(tx, submission.ConvertToInsertModel()); err != nil {
+				return err
+			}
+			columns = append(columns, "author_id")
+		} else {
+			columns = append(columns, "author")
+		}
+		submission.Status = structs.SubmissionPending
+		if err := tx.Table("submissions").Select(columns).Create(submission).Error; err != nil {
+			return err
+		}
+		//created_at is set by the database
+		return tx.Table("submissions").Where("id = ?", submission.Id).Find(submission).Error
+	})
+}
+
+func (repo *submissionsPostgres) Get(id int) (structs.SubmissionDBModel, error) {
+	var submission structs.SubmissionDBModel
+	if err := repo.db.Table("submissions").Where("id = ?", id).Limit(1).Find(&submission).Error; err != nil {
+		return submission, err
+	}
+	if submission.Id == 0 {
+		return submission, ErrNotFound
+	}
+	return submission, nil
+}
+
+func (repo *submissionsPostgres) List(userId int, status string, request structs.Request) ([]structs.SubmissionDBModel, error) {
+	var submissions []structs.SubmissionDBModel
+	dbPointer := repo.db.Table("submissions").Order("created_at, id")
+	if userId > 0 {
+		dbPointer = dbPointer.Where("user_id = ?", userId)
+	}
+	if status != "" {
+		dbPointer = dbPointer.Where("status = ?", status)
+	}
+	err := pagination(request, dbPointer).Find(&submissions).Error
+	return submissions, err
+}
+
+func (repo *submissionsPostgres) Review(submission structs.SubmissionDBModel) (structs.SubmissionDBModel, error) {
+	if err := reviewSubmission(repo.db, submission); err != nil {
+		return submission, err
+	}
+	return repo.Get(submission.Id)
+}
+
+func (repo *submissionsPostgres) Approve(submission structs.SubmissionDBModel, newQuote structs.QuoteInsertModel) (structs.SubmissionDBModel, structs.QuoteDBModel, error) {
+	var quote structs.QuoteDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		//The lock keeps a review running at the same time waiting until this one is done, and it then finds the
+		//submission reviewed, so a published quote is never left with a rejected submission
+		var pending structs.SubmissionDBModel
+		err := tx.Table("submissions").Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", submission.Id).Limit(1).Find(&pending).Error
+		if err != nil {
+			return err
+		}
+		if pending.Id == 0 {
+			return ErrNotFound
+		}
+		if pending.Status != structs.SubmissionPending {
+			return ErrSubmissionReviewed
+		}
+
+		if quote, err = insertQuote(tx, newQuote); err != nil {
+			return err
+		}
+		submission.Status, submission.QuoteId = structs.SubmissionApproved, quote.Id
+		return reviewSubmission(tx, submission)
+	})
+	if err != nil {
+		return submission, quote, err
+	}
+	submission, err = repo.Get(submission.Id)
+	return submission, quote, err
+}
+
+//reviewSubmission saves the submission if it is still pending, ErrSubmissionReviewed otherwise
+func reviewSubmission(dbPointer *gorm.DB, submission structs.SubmissionDBModel) error {
+	var authorId interface{}
+	if submission.AuthorId > 0 {
+		authorId = submission.AuthorId
+	}
+	var quoteId interface{}
+	if submission.QuoteId > 0 {
+		quoteId = submission.QuoteId
+	}
+	result := dbPointer.Exec(`UPDATE submissions SET quote = ?, author = ?, author_id = ?, is_icelandic = ?, topics = ?, status = ?,
+		reason = nullif(?, ''), quote_id = ?, reviewed_by = ?, reviewed_at = current_timestamp WHERE id = ? AND status = ?`,
+		submission.Quote, submission.Author, authorId, submission.IsIcelandic, submission.Topics, submission.Status,
+		submission.Reason, quoteId, submission.ReviewedBy, submission.Id, structs.SubmissionPending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := dbPointer.Table("submissions").Where("id = ?", submission.Id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return ErrNotFound
+		}
+		return ErrSubmissionReviewed
+	}
+	return nil
+}
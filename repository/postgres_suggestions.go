@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type suggestionsPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *suggestionsPostgres) Suggest(searchString string, view string, limit int) ([]structs.SuggestionDBModel, error) {
+	if !isLexemeView(view) {
+		return nil, ErrNotFound
+	}
+	return suggest(searchString, limit, &lexemesPostgres{db: repo.db, view: view})
+}
+
+//lexemesPostgres looks up the words in the lexeme view, the view name is one of LexemeViews
+type lexemesPostgres struct {
+	db   *gorm.DB
+	view string
+}
+
+func (lexemes *lexemesPostgres) known(words []string) ([]string, error) {
+	var known []string
+	err := lexemes.db.Table(lexemes.view).Where("word in ?", words).Pluck("word", &known).Error
+	return known, err
+}
+
+//similar finds the words through the trigram index on the view, the same way as the query in sql/useful.sql. The more
+//common words come first among the equally similar ones
+func (lexemes *lexemesPostgres) similar(word string, limit int) ([]similarWord, error) {
+	var similar []similarWord
+	err := lexemes.db.Table(lexemes.view).
+		Select("word, similarity(word, ?) as similarity", word).
+		Where("word % ?", word).
+		Clauses(clause.OrderBy{
+			Expression: clause.Expr{SQL: "word <-> ?, ndoc DESC, word", Vars: []interface{}{word}, WithoutParentheses: true},
+		}).
+		Limit(limit).
+		Find(&similar).Error
+	return similar, err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type topicsPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *topicsPostgres) List(language string) ([]structs.TopicDBModel, error) {
+	var results []structs.TopicDBModel
+	dbPointer := repo.db.Table("topics").Where("deleted_at is null")
+
+	dbPointer = quoteLanguageSQL(language, dbPointer)
+	err := dbPointer.Find(&results).Error
+	return results, err
+}
+
+func (repo *topicsPostgres) GetTopic(request structs.Request) ([]structs.TopicViewDBModel, error) {
+	var results []structs.TopicViewDBModel
+	//Order by quoteid to have definitive order (when for examplke some quotes rank the same for plain, phrase and general)
+	dbPoint := repo.topicRows(request).Clauses(clause.OrderBy{
+		Expression: clause.Expr{SQL: "quote_id DESC", Vars: []interface{}{}, WithoutParentheses: true},
+	})
+
+	err := pagination(request, dbPoint).Find(&results).Error
+	return results, err
+}
+
+func (repo *topicsPostgres) GetTopicTotal(request structs.Request) (structs.TotalDBModel, error) {
+	return countTotal(repo.db, repo.topicRows(request))
+}
+
+//topicRows returns the DB pointer to the quotes of the topic with the given id or name, unordered
+func (repo *topicsPostgres) topicRows(request structs.Request) *gorm.DB {
+	dbPoint := repo.db.Table("topicsview")
+	if request.Topic != "" {
+		dbPoint = dbPoint.Where("lower(topic_name) = lower(?)", request.Topic)
+	} else {
+		dbPoint = dbPoint.Where("topic_id = ?", request.Id)
+	}
+	return quoteSourceSQL(request.Verified, dbPoint)
+}
+
+func (repo *topicsPostgres) IncrementCount(topicId int, topicName string, by int) error {
+	return repo.db.Exec("UPDATE topics SET count = count + ? where id = ? or lower(name) = lower(?) returning *", by, topicId, topicName).Error
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"regexp"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+var topicExistsRegex = regexp.MustCompile(`duplicate key value violates unique constraint "topics_name_key"`)
+
+func (repo *topicsPostgres) Create(name string, isIcelandic bool) (structs.TopicDBModel, error) {
+	var topic structs.TopicDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table("topics").Where("lower(name) = lower(?)", name).Limit(1).Find(&topic).Error; err != nil {
+			return err
+		}
+		if topic.Id != 0 {
+			return ErrTopicExists
+		}
+
+		topic = structs.TopicDBModel{Name: name, IsIcelandic: isIcelandic}
+		if err := tx.Table("topics").Select("name", "is_icelandic").Create(&topic).Error; err != nil {
+			if topicExistsRegex.MatchString(err.Error()) {
+				return ErrTopicExists
+			}
+			return err
+		}
+		return recomputeTopics(tx, []int{topic.Id})
+	})
+	return topic, err
+}
+
+func (repo *topicsPostgres) Rename(id int, name string) (structs.TopicDBModel, error) {
+	var topic structs.TopicDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var taken structs.TopicDBModel
+		if err := tx.Table("topics").Where("lower(name) = lower(?) and id != ?", name, id).Limit(1).Find(&taken).Error; err != nil {
+			return err
+		}
+		if taken.Id != 0 {
+			return ErrTopicExists
+		}
+
+		result := tx.Exec("UPDATE topics SET name = ?, updated_at = current_timestamp WHERE id = ? AND deleted_at is null", name, id)
+		if result.Error != nil {
+			if topicExistsRegex.MatchString(result.Error.Error()) {
+				return ErrTopicExists
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		if err := recomputeTopics(tx, []int{id}); err != nil {
+			return err
+		}
+		return tx.Table("topics").Where("id = ?", id).First(&topic).Error
+	})
+	return topic, err
+}
+
+func (repo *topicsPostgres) AddQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error) {
+	var topic structs.TopicDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := liveTopic(tx, topicId, &topic); err != nil {
+			return err
+		}
+		var quotes []structs.QuoteDBModel
+		if err := tx.Table("quotes").Where("id in ? and deleted_at is null", quoteIds).Find(&quotes).Error; err != nil {
+			return err
+		}
+		if len(quotes) != len(uniqueIds(quoteIds)) {
+			return ErrNotFound
+		}
+
+		for _, quote := range quotes {
+			if err := linkQuote(tx, topicId, quote.Id); err != nil {
+				return err
+			}
+		}
+		if err := recomputeTopics(tx, []int{topicId}); err != nil {
+			return err
+		}
+		return tx.Table("topics").Where("id = ?", topicId).First(&topic).Error
+	})
+	return topic, err
+}
+
+func (repo *topicsPostgres) RemoveQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error) {
+	var topic structs.TopicDBModel
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := liveTopic(tx, topicId, &topic); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM topicstoquotes WHERE topic_id = ? AND quote_id in ?", topicId, quoteIds).Error; err != nil {
+			return err
+		}
+		if err := recomputeTopics(tx, []int{topicId}); err != nil {
+			return err
+		}
+		return tx.Table("topics").Where("id = ?", topicId).First(&topic).Error
+	})
+	return topic, err
+}
+
+//liveTopic reads the topic, that has not been deleted, into topic. Returns ErrNotFound if there is no such topic
+func liveTopic(tx *gorm.DB, id int, topic *structs.TopicDBModel) error {
+	if err := tx.Table("topics").Where("id = ? and deleted_at is null", id).Limit(1).Find(topic).Error; err != nil {
+		return err
+	}
+	if topic.Id == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+//recomputeTopics sets nr_of_quotes, is_icelandic and tsv of the given topics from their live, public quotes of live authors. A topic
+//is Icelandic if all its quotes are, a topic without quotes keeps the language it has. The name is in the text search
+//configuration of the topic's language
+func recomputeTopics(tx *gorm.DB, topicIds []int) error {
+	return tx.Exec(`UPDATE topics SET
+			nr_of_quotes = counts.total,
+			is_icelandic = counts.is_icelandic,
+			tsv = setweight(to_tsvector(search_config(counts.is_icelandic), topics.name), 'A')
+		FROM (
+			SELECT t.id,
+				count(q.id) AS total,
+				CASE WHEN count(q.id) > 0 THEN count(q.id) = count(q.id) FILTER (WHERE q.is_icelandic) ELSE t.is_icelandic END AS is_icelandic
+			FROM topics t
+				LEFT JOIN topicstoquotes ttq ON ttq.topic_id = t.id AND ttq.deleted_at is null
+				LEFT JOIN quotes q ON q.id = ttq.quote_id AND q.deleted_at is null AND NOT q.is_private
+					AND q.author_id IN (SELECT id FROM authors WHERE deleted_at is null)
+			WHERE t.id in ?
+			GROUP BY t.id
+		) AS counts
+		WHERE topics.id = counts.id`, topicIds).Error
+}
+
+//recomputeTopicsOfQuote recomputes the counters of all the topics the quote is in
+func recomputeTopicsOfQuote(tx *gorm.DB, quoteId int) error {
+	var topicIds []int
+	if err := tx.Table("topicstoquotes").Where("quote_id = ?", quoteId).Pluck("topic_id", &topicIds).Error; err != nil {
+		return err
+	}
+	if len(topicIds) == 0 {
+		return nil
+	}
+	return recomputeTopics(tx, topicIds)
+}
+
+//recomputeTopicsOfAuthor recomputes the counters of all the topics the author's quotes are in
+func recomputeTopicsOfAuthor(tx *gorm.DB, authorId int) error {
+	var topicIds []int
+	err := tx.Table("topicstoquotes ttq").Joins("inner join quotes q on q.id = ttq.quote_id").
+		Where("q.author_id = ?", authorId).Distinct().Pluck("ttq.topic_id", &topicIds).Error
+	if err != nil {
+		return err
+	}
+	if len(topicIds) == 0 {
+		return nil
+	}
+	return recomputeTopics(tx, topicIds)
+}
+
+//uniqueIds returns the ids without duplicates
+func uniqueIds(ids []int) []int {
+	unique := []int{}
+	for _, id := range ids {
+		if !containsId(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
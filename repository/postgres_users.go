@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+)
+
+type usersPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *usersPostgres) GetByApiKey(apiKey string) (structs.UserDBModel, error) {
+	var user structs.UserDBModel
+	err := repo.db.Table("users").Where("api_key = ?", apiKey).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, ErrNotFound
+	}
+	return user, err
+}
+
+func (repo *usersPostgres) GetByEmail(email string) (structs.UserDBModel, error) {
+	var user structs.UserDBModel
+	err := repo.db.Table("users").Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, ErrNotFound
+	}
+	return user, err
+}
+
+func (repo *usersPostgres) Create(user *structs.UserDBModel) error {
+	err := repo.db.Table("users").Select("name", "api_key", "tier", "email", "password_hash").Create(user).Error
+	if err != nil {
+		m1 := regexp.MustCompile(`duplicate key value violates unique constraint "users_email_key"`)
+		if m1.Match([]byte(err.Error())) {
+			return ErrEmailTaken
+		}
+	}
+	return err
+}
+
+type requestHistoryPostgres struct {
+	db *gorm.DB
+}
+
+func (repo *requestHistoryPostgres) CountLastHour(userId int) (int, error) {
+	type countStruct struct {
+		Count int `json:"count"`
+	}
+	var count countStruct
+	err := repo.db.Table("requesthistory").Select("count(*)").
+		Where("created_at >= (NOW() - INTERVAL '1 hour')").
+		Where("user_id = ?", userId).
+		First(&count).Error
+	return count.Count, err
+}
+
+func (repo *requestHistoryPostgres) Create(event *structs.RequestEvent) error {
+	return repo.db.Table("requesthistory").Create(event).Error
+}
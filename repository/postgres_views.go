@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type viewsPostgres struct {
+	db *gorm.DB
+}
+
+//Refresh refreshes the view concurrently, i.e. reads are not blocked, and saves the outcome into view_refreshes
+func (repo *viewsPostgres) Refresh(view string) error {
+	if !IsMaterializedView(view) {
+		return ErrNotFound
+	}
+
+	start := time.Now()
+	//The view name can not be a bind parameter but it is one of MaterializedViews or LexemeViews
+	refreshErr := repo.db.Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).Error
+
+	refresh := structs.ViewRefreshDBModel{ViewName: view, DurationMs: int(time.Since(start).Milliseconds())}
+	columns := []string{"duration_ms", "error"}
+	if refreshErr != nil {
+		refresh.Error = refreshErr.Error()
+	} else {
+		refresh.RefreshedAt = &start
+		columns = append(columns, "refreshed_at")
+	}
+
+	err := repo.db.Table("view_refreshes").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "view_name"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(&refresh).Error
+	if refreshErr != nil {
+		return refreshErr
+	}
+	return err
+}
+
+func (repo *viewsPostgres) LastRefreshed() ([]structs.ViewRefreshDBModel, error) {
+	var rows []structs.ViewRefreshDBModel
+	if err := repo.db.Table("view_refreshes").Where("view_name in ?", MaterializedViews).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	refreshes := []structs.ViewRefreshDBModel{}
+	for _, view := range MaterializedViews {
+		refresh := structs.ViewRefreshDBModel{ViewName: view}
+		for _, row := range rows {
+			if row.ViewName == view {
+				refresh = row
+			}
+		}
+		refreshes = append(refreshes, refresh)
+	}
+	return refreshes, nil
+}
+
+func isLexemeView(view string) bool {
+	for _, lexemeView := range LexemeViews {
+		if view == lexemeView {
+			return true
+		}
+	}
+	return false
+}
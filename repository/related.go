@@ -0,0 +1,10 @@
+package repository
+
+//The weights of the score Related ranks the quotes by. The lexemes are the share of the two quotes' distinct lexemes
+//that they have in common, the topics the share of the quote's topics the other quote is in as well and the author
+//counts, if it is asked for, when both quotes are by the same author
+const (
+	relatedLexemeWeight = 1.0
+	relatedTopicWeight  = 0.5
+	relatedAuthorWeight = 0.25
+)
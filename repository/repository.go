@@ -0,0 +1,375 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//ErrNotFound is returned when the requested row does not exist
+var ErrNotFound = errors.New("record not found")
+
+//ErrEmailTaken is returned when creating a user with an email that is already in use
+var ErrEmailTaken = errors.New("email is taken")
+
+//ErrAuthorExists is returned when creating an author, or renaming one, with a name that is already taken
+var ErrAuthorExists = errors.New("author already exists")
+
+//ErrAliasTaken is returned when adding an alias to an author that is the name or alias of another author
+var ErrAliasTaken = errors.New("alias is taken")
+
+//ErrTopicExists is returned when creating a topic, or renaming one, with a name (case insensitive) that is already taken
+var ErrTopicExists = errors.New("topic already exists")
+
+//ErrAuthorDeleted is returned when an author, or the author of a new quote, is given a name that only a soft deleted
+//author has, the deleted author has to be restored before the name can be used
+var ErrAuthorDeleted = errors.New("author is deleted")
+
+//ErrTopicDeleted is returned when a topic of a new quote is given by a name that only a soft deleted topic has, the
+//topic has to be restored before it gets new quotes
+var ErrTopicDeleted = errors.New("topic is deleted")
+
+//ErrQuoteExists is returned when inserting a quote that is already in the database
+var ErrQuoteExists = errors.New("quote already exists")
+
+//QuoteRepository fetches quotes from the searchview / topicsview
+type QuoteRepository interface {
+	//GetQuotes returns the quotes with the given ids or, if authorId is set, a page of the author's quotes
+	GetQuotes(request structs.Request) ([]structs.SearchViewDBModel, error)
+	//GetPrivateQuotes returns the private quotes the user created, with the given ids or, if authorId is set, a page of the
+	//user's private quotes by the author
+	GetPrivateQuotes(userId int, request structs.Request) ([]structs.SearchViewDBModel, error)
+	//GetByAuthor returns at most limit quotes from the author in the given language
+	GetByAuthor(authorId int, language string, limit int) ([]structs.SearchViewDBModel, error)
+	//List returns a page of quotes according to the request's orderConfig
+	List(request structs.Request) ([]structs.SearchViewDBModel, error)
+	//ListTotal returns the number of quotes on all the pages of List
+	ListTotal(request structs.Request) (structs.TotalDBModel, error)
+	//Random returns a random quote matching the request's parameters, the zero value if none matches
+	Random(request structs.Request) (structs.TopicViewDBModel, error)
+	//Search is the general search in both the names of the authors and the quotes. Returns a page of the results, the
+	//strategy of the first of them, i.e. StrategyFullText unless the search string is only stop words, and, if the
+	//request asks for them, the number of rows on all the pages (withPagination) and the facets (facets), i.e. the
+	//counts of all the matched rows by language and of the limit topics and authors with the most of them. The match
+	//is only built once for all of them
+	Search(request structs.Request) (structs.SearchResultsDBModel, error)
+	//SearchQuotes is Search only in the quotes themselves
+	SearchQuotes(request structs.Request) (structs.SearchResultsDBModel, error)
+	//Related returns a page of the quotes most similar to the quote with the given id (request.Id), by the lexemes of
+	//their quote_tsv, their topics and, if sameAuthor is set, their author. ErrNotFound if no public quote has the id
+	Related(request structs.Request) ([]structs.SearchViewDBModel, error)
+	//IncrementCount increments the popularity count of the given quotes
+	IncrementCount(quoteIds []int, by int) error
+	//Insert inserts the quote, creating its author and topics by name if they do not exist, links it to the topics and
+	//recomputes the author's counters and tsv. Returns the existing quote and ErrQuoteExists if the quote, or a quote with
+	//the same normalized text (see normalize_quote), is already a live quote that is public or private to quote.UserId,
+	//ErrNotFound if the author is given by an id that does not exist and ErrAuthorDeleted / ErrTopicDeleted if the
+	//author or a topic is given by the name of a soft deleted one
+	Insert(quote structs.QuoteInsertModel) (structs.QuoteDBModel, error)
+	//Export reads, from a single consistent snapshot, every author, then every topic in the request's language and topic
+	//and then every searchview row (or topicsview row if withTopics or a topic is given) in the request's language and
+	//topic, ordered by id. Begin is called with the time of the snapshot before the other callbacks are called with the
+	//records one at a time, the records are never all held in memory. The records of a nil callback are not read
+	Export(request structs.Request, callbacks ExportCallbacks) error
+}
+
+//ExportCallbacks are called by QuoteRepository.Export with the time of the snapshot and the records of each type
+type ExportCallbacks struct {
+	Begin  func(snapshot time.Time) error
+	Author func(author structs.AuthorDBModel) error
+	Topic  func(topic structs.TopicDBModel) error
+	Quote  func(row structs.TopicViewDBModel) error
+}
+
+//AuthorRepository fetches authors from the authors table
+type AuthorRepository interface {
+	GetByIds(ids []int) ([]structs.AuthorDBModel, error)
+	//List returns a page of authors according to the request's orderConfig, with the request's profession, nationality
+	//and bornBefore / bornAfter (years) if set
+	List(request structs.Request) ([]structs.AuthorDBModel, error)
+	//ListTotal returns the number of authors on all the pages of List
+	ListTotal(request structs.Request) (structs.TotalDBModel, error)
+	//Random returns a random author that has quotes in the given language
+	Random(language string) (structs.AuthorDBModel, error)
+	Search(request structs.Request) ([]structs.AuthorDBModel, error)
+	//SearchTotal returns the number of authors on all the pages of Search
+	SearchTotal(request structs.Request) (structs.TotalDBModel, error)
+	//IncrementCount increments the popularity count of the given authors
+	IncrementCount(authorIds []int, by int) error
+	//Create inserts an author without any quotes, returns the existing author and ErrAuthorExists if the name is taken
+	//and ErrAuthorDeleted if only a soft deleted author has it
+	Create(name string) (structs.AuthorDBModel, error)
+	//Update renames the author and recomputes its counters and tsv. Returns ErrNotFound if the author does not exist and
+	//ErrAuthorExists if another author has the name, ErrAuthorDeleted if a soft deleted one has it
+	Update(id int, name string) (structs.AuthorDBModel, error)
+	//UpdateProfile replaces the author's profile, returns ErrNotFound if the author does not exist
+	UpdateProfile(id int, profile structs.AuthorProfile) (structs.AuthorDBModel, error)
+	//Resolve returns the live author with the given name or alias (case insensitive), a name before an alias. Returns
+	//ErrNotFound if there is none
+	Resolve(name string) (structs.AuthorDBModel, error)
+	//Aliases returns the aliases of the given authors, ordered by alias
+	Aliases(authorIds []int) ([]structs.AuthorAliasDBModel, error)
+	//AddAliases adds the aliases to the author and recomputes its tsv, an alias that is already the author's is left
+	//alone. Returns ErrNotFound if the author does not exist and ErrAliasTaken if an alias is another author's name or alias
+	AddAliases(authorId int, aliases []string) error
+	//RemoveAliases returns ErrNotFound if the author does not exist
+	RemoveAliases(authorId int, aliases []string) error
+	//Merge moves the quotes, aliases, popularity count and of-the-day dates of the authors to the survivor, which also
+	//gets their names as aliases and fills the gaps in its profile from theirs, and soft deletes the authors. Returns
+	//ErrNotFound if any of them is not a live author
+	Merge(survivorId int, authorIds []int) (structs.AuthorDBModel, error)
+}
+
+//TopicRepository fetches topics and the quotes in them
+type TopicRepository interface {
+	List(language string) ([]structs.TopicDBModel, error)
+	//GetTopic returns a page of quotes from the topic with the given id or name (request.Id / request.Topic)
+	GetTopic(request structs.Request) ([]structs.TopicViewDBModel, error)
+	//GetTopicTotal returns the number of quotes on all the pages of GetTopic
+	GetTopicTotal(request structs.Request) (structs.TotalDBModel, error)
+	//IncrementCount increments the popularity count of the topic with the given id or name
+	IncrementCount(topicId int, topicName string, by int) error
+	//Create inserts a topic without any quotes, returns the existing topic and ErrTopicExists if the name is taken
+	Create(name string, isIcelandic bool) (structs.TopicDBModel, error)
+	//Rename returns ErrNotFound if the topic does not exist and ErrTopicExists if another topic has the name
+	Rename(id int, name string) (structs.TopicDBModel, error)
+	//AddQuotes links the quotes to the topic and recomputes its nr_of_quotes and is_icelandic. Returns ErrNotFound if
+	//the topic or any of the quotes does not exist
+	AddQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error)
+	//RemoveQuotes unlinks the quotes from the topic and recomputes its nr_of_quotes and is_icelandic. Returns ErrNotFound
+	//if the topic does not exist
+	RemoveQuotes(topicId int, quoteIds []int) (structs.TopicDBModel, error)
+}
+
+//OfTheDayRepository handles the quotes / authors of the day, language is either english or icelandic
+type OfTheDayRepository interface {
+	//GetQuoteOfTheDay returns today's QOD, the zero value if it has not been set
+	GetQuoteOfTheDay(language string) (structs.QodViewDBModel, error)
+	//QuoteOfTheDayHistory returns the QODs from minimum (if set) until today, newest first
+	QuoteOfTheDayHistory(language string, minimum string) ([]structs.QodViewDBModel, error)
+	SetQuoteOfTheDay(language string, date string, quoteId int) error
+	//SetRandomQuoteOfTheDay sets a random quote in the given language as today's QOD
+	SetRandomQuoteOfTheDay(language string) error
+
+	//GetAuthorOfTheDay returns today's AOD, the zero value if it has not been set
+	GetAuthorOfTheDay(language string) (structs.AodDBModel, error)
+	//AuthorOfTheDayHistory returns the AODs from minimum until today, newest first
+	AuthorOfTheDayHistory(language string, minimum string) ([]structs.AodDBModel, error)
+	SetAuthorOfTheDay(language string, date string, authorId int) error
+	//SetRandomAuthorOfTheDay sets a random author with quotes in the given language as today's AOD
+	SetRandomAuthorOfTheDay(language string) error
+}
+
+//UserRepository handles the users of the api
+type UserRepository interface {
+	//GetByApiKey returns ErrNotFound if no user has the given apiKey
+	GetByApiKey(apiKey string) (structs.UserDBModel, error)
+	//GetByEmail returns ErrNotFound if no user has the given email
+	GetByEmail(email string) (structs.UserDBModel, error)
+	//Create inserts the user and sets its id, returns ErrEmailTaken if the email is in use
+	Create(user *structs.UserDBModel) error
+}
+
+//RequestHistoryRepository saves the requests made by the users
+type RequestHistoryRepository interface {
+	//CountLastHour returns how many requests the user has made the past hour
+	CountLastHour(userId int) (int, error)
+	Create(event *structs.RequestEvent) error
+}
+
+//The materialized views that search and the random routes read from
+const (
+	SearchView = "searchview"
+	TopicsView = "topicsview"
+)
+
+//MaterializedViews are the views that need to be refreshed to see new writes
+var MaterializedViews = []string{SearchView, TopicsView}
+
+//The materialized views with the words the spelling suggestions are made from, i.e. the words of the live public
+//quotes and the names and aliases of the live authors
+const (
+	LexemeView        = "unique_lexeme"
+	QuotesLexemeView  = "unique_lexeme_quotes"
+	AuthorsLexemeView = "unique_lexeme_authors"
+)
+
+//LexemeViews are expensive to build and new words matter little to the suggestions, so they are only refreshed on the
+//schedule and after imports, not after every write
+var LexemeViews = []string{LexemeView, QuotesLexemeView, AuthorsLexemeView}
+
+//AllViews returns every view that can be refreshed, i.e. the MaterializedViews and the LexemeViews
+func AllViews() []string {
+	return append(append([]string{}, MaterializedViews...), LexemeViews...)
+}
+
+//IsMaterializedView tells whether view is one of the views that can be refreshed, i.e. one of AllViews
+func IsMaterializedView(view string) bool {
+	for _, materializedView := range MaterializedViews {
+		if view == materializedView {
+			return true
+		}
+	}
+	return isLexemeView(view)
+}
+
+//ViewRepository refreshes the materialized views and keeps track of when they were refreshed
+type ViewRepository interface {
+	//Refresh refreshes the view, without blocking reads, and records the result. Returns ErrNotFound for views that are
+	//not in MaterializedViews or LexemeViews
+	Refresh(view string) error
+	//LastRefreshed returns the last refresh of each of the MaterializedViews
+	LastRefreshed() ([]structs.ViewRefreshDBModel, error)
+}
+
+//The tables whose rows can be soft deleted, i.e. their deleted_at set
+const (
+	AuthorsTable = "authors"
+	QuotesTable  = "quotes"
+	TopicsTable  = "topics"
+)
+
+//DeletableTables are the tables that DeletedRepository handles
+var DeletableTables = []string{AuthorsTable, QuotesTable, TopicsTable}
+
+//DeletedRepository soft deletes and restores authors, quotes and topics. Deleted rows are left out of all the other
+//repositories, and of the views once they are refreshed. Deleting an author also hides the author's quotes.
+type DeletedRepository interface {
+	//Delete sets deleted_at of the row, returns ErrNotFound if the row does not exist or is already deleted
+	Delete(table string, id int) error
+	//Restore clears deleted_at of the row, returns ErrNotFound if the row does not exist or is not deleted and
+	//ErrQuoteExists if a quote with the same text was inserted in its place, see 0024_unique_live_visible_quotes
+	Restore(table string, id int) error
+	//List returns a page of the deleted rows of the table, most recently deleted first
+	List(table string, request structs.Request) ([]structs.DeletedDBModel, error)
+}
+
+//ErrCollectionExists is returned when creating, or renaming, a collection with a name the user already has a collection with
+var ErrCollectionExists = errors.New("collection already exists")
+
+//CollectionRepository handles the users' favourite quotes and their named collections of quotes. A user can only save
+//the quotes visible to them, i.e. the public ones and their own private ones
+type CollectionRepository interface {
+	//Favourites returns a page of the user's favourite quotes, the most recently added first
+	Favourites(userId int, request structs.Request) ([]structs.SearchViewDBModel, error)
+	//AddFavourites returns ErrNotFound if any of the quotes is not visible to the user
+	AddFavourites(userId int, quoteIds []int) error
+	RemoveFavourites(userId int, quoteIds []int) error
+
+	//List returns the user's collections, without their quotes, ordered by name
+	List(userId int) ([]structs.CollectionDBModel, error)
+	//Get returns the collection with the given id, or the given slug if id is 0. Returns ErrNotFound if there is none
+	Get(id int, slug string) (structs.CollectionDBModel, error)
+	//Create inserts the collection and sets its id, returns ErrCollectionExists if the user has a collection with the name
+	Create(collection *structs.CollectionDBModel) error
+	//Update sets the name and is_public of the user's collection. Returns ErrNotFound if the user has no such collection
+	//and ErrCollectionExists if the user has another collection with the name
+	Update(collection structs.CollectionDBModel) (structs.CollectionDBModel, error)
+	//Delete returns ErrNotFound if the user has no such collection
+	Delete(userId int, collectionId int) error
+	//Quotes returns the quotes in the collection that are visible to the viewer, ordered by position
+	Quotes(collectionId int, viewerId int) ([]structs.CollectionQuoteDBModel, error)
+	//SetQuote adds the quote to the end of the user's collection, or updates its note if it is already there. Returns
+	//ErrNotFound if the user has no such collection or the quote is not visible to the user
+	SetQuote(userId int, collectionId int, quoteId int, note string) error
+	//RemoveQuotes returns ErrNotFound if the user has no such collection
+	RemoveQuotes(userId int, collectionId int, quoteIds []int) error
+	//Reorder moves the given quotes to the front of the user's collection in the given order, the other quotes keep
+	//their order after them. Returns ErrNotFound if the user has no such collection
+	Reorder(userId int, collectionId int, quoteIds []int) error
+}
+
+//ErrSubmissionReviewed is returned when approving or rejecting a submission that is no longer pending
+var ErrSubmissionReviewed = errors.New("submission has already been reviewed")
+
+//SubmissionRepository holds the quotes submitted by the users until a GOD-tier user approves or rejects them. An
+//approved submission is published through QuoteRepository.Insert, like the quotes of the import
+type SubmissionRepository interface {
+	//Create inserts the pending submission and sets its id. Returns ErrQuoteExists if the quote is already a live quote
+	//that is public or private to the submitter and ErrNotFound if the author is given by an id that does not exist
+	Create(submission *structs.SubmissionDBModel) error
+	//Get returns ErrNotFound if the submission does not exist
+	Get(id int) (structs.SubmissionDBModel, error)
+	//List returns a page of the submissions in the given status (all if empty), of the given user if userId is set,
+	//oldest first
+	List(userId int, status string, request structs.Request) ([]structs.SubmissionDBModel, error)
+	//Review saves the submission, with the GOD-tier user's edits, as approved or rejected. Returns
+	//ErrSubmissionReviewed if it is no longer pending
+	Review(submission structs.SubmissionDBModel) (structs.SubmissionDBModel, error)
+	//Approve publishes the quote, through the insert of QuoteRepository.Insert, and saves the submission as approved
+	//with the quote's id in one transaction that holds the lock of the submission. Returns ErrSubmissionReviewed if it
+	//is no longer pending and the errors of Insert, along with the existing quote for ErrQuoteExists
+	Approve(submission structs.SubmissionDBModel, newQuote structs.QuoteInsertModel) (structs.SubmissionDBModel, structs.QuoteDBModel, error)
+}
+
+//DuplicateRepository finds the near duplicate quotes, i.e. the quotes whose normalized text (see normalize_quote) is
+//similar by trigrams, and merges them
+type DuplicateRepository interface {
+	//Similar returns at most limit of the live quotes visible to the user whose normalized text has at least the given
+	//similarity to the normalized quote, the most similar first
+	Similar(quote string, userId int, minimum float64, limit int) ([]structs.SimilarQuoteDBModel, error)
+	//Clusters returns a page of the groups of live public quotes that are linked by pairs with at least the given
+	//similarity, ordered by their lowest quote id. The most popular quote of each cluster comes first. The pairs are
+	//the ones recorded on insert, so the minimum must be at least MinDuplicateSimilarity
+	Clusters(minimum float64, request structs.Request) ([]structs.DuplicateClusterDBModel, error)
+	//Merge moves the topic links, popularity count, favourites, collection entries, days as the quote of the day and, if
+	//the survivor has none, the best citation of the duplicates to the survivor, soft deletes the duplicates and returns
+	//the survivor. Returns ErrNotFound if any of them is not a live public quote
+	Merge(survivorId int, duplicateIds []int) (structs.QuoteDBModel, error)
+	//RecordPairs records the pairs of the first limit quotes with ids above afterId, in a transaction of their own, and
+	//returns the highest of their ids, 0 when there are none left. It is the backfill of the quotes from before
+	//0022_create_quote_duplicates, the later ones record their pairs on insert
+	RecordPairs(afterId int, limit int) (int, error)
+}
+
+//SourceRepository handles the works, e.g. books and speeches, that quotes are cited from and the status of each
+//quote's attribution. The other repositories only return the verified quotes if request.Verified is set
+type SourceRepository interface {
+	//Create inserts the source and sets its id
+	Create(source *structs.SourceDBModel) error
+	//Update returns ErrNotFound if the source does not exist
+	Update(source structs.SourceDBModel) (structs.SourceDBModel, error)
+	//List returns a page of the sources, ordered by title, whose title contains the request's searchString (if set)
+	//and of the request's type (if set)
+	List(request structs.Request) ([]structs.SourceDBModel, error)
+	//Cite links the live quote to the source (none if sourceId is 0) with the given status of the attribution.
+	//Returns ErrNotFound if the quote or the source does not exist
+	Cite(quoteId int, sourceId int, status string) error
+	//ForQuotes returns the citations of the given quotes that have a source or an attribution that is not unverified
+	ForQuotes(quoteIds []int) ([]structs.CitationDBModel, error)
+}
+
+//SuggestionRepository suggests corrections of search strings from the words in the LexemeViews
+type SuggestionRepository interface {
+	//Suggest returns at most limit corrections of the search string, the most similar first, in which the words that
+	//are not in the view are replaced by similar words that are. Returns a *QueryError if the search string is not a
+	//valid query and no suggestions if every word is in the view or none has a similar word
+	Suggest(searchString string, view string, limit int) ([]structs.SuggestionDBModel, error)
+}
+
+//CompletionRepository completes what a user is typing into the names of the authors and topics and the quotes, it
+//reads the tables and not the views so that it is always up to date
+type CompletionRepository interface {
+	//Complete returns at most limit of each of the live authors, the live topics and the live public quotes, in the
+	//given language if set, that have a word starting with each word of the prefix, the most popular first
+	Complete(prefix string, language string, limit int) (structs.AutocompleteDBModel, error)
+}
+
+//Repositories bundles together all the repositories the routes need
+type Repositories struct {
+	Quotes         QuoteRepository
+	Authors        AuthorRepository
+	Topics         TopicRepository
+	OfTheDay       OfTheDayRepository
+	Users          UserRepository
+	RequestHistory RequestHistoryRepository
+	Views          ViewRepository
+	Deleted        DeletedRepository
+	Collections    CollectionRepository
+	Submissions    SubmissionRepository
+	Duplicates     DuplicateRepository
+	Sources        SourceRepository
+	Suggestions    SuggestionRepository
+	Completions    CompletionRepository
+}
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//The fields a search query can be filtered by, e.g. author:"Oscar Wilde", topic:love or lang:icelandic
+const (
+	queryFieldAuthor   = "author"
+	queryFieldTopic    = "topic"
+	queryFieldLanguage = "lang"
+)
+
+//The strategies the quote searches match the search string with, the full text search unless the query is only stop
+//words, see stopWordsSQL, and then a trigram match of the quote text, with the quotes that have the whole phrase
+//first. Each result has the strategy it was matched with
+const (
+	StrategyFullText = "fulltext"
+	StrategyPhrase   = "phrase"
+	StrategyTrigram  = "trigram"
+)
+
+//resultsStrategy returns the strategy of the first of the results, the one the search is reported to be matched with
+func resultsStrategy(results []structs.TopicViewDBModel) string {
+	if len(results) == 0 || results[0].Strategy == "" {
+		return StrategyFullText
+	}
+	return results[0].Strategy
+}
+
+//QueryError is returned for a search string that is not a valid query. Position is the 1-based position of the
+//offending character in the search string
+type QueryError struct {
+	Position int
+	Message  string
+}
+
+func (err *QueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", err.Message, err.Position)
+}
+
+//queryTerm is a word, or a quoted phrase whose words must follow each other
+type queryTerm struct {
+	text   string
+	phrase bool
+}
+
+//queryFilter is a field filter, e.g. author:"Oscar Wilde", a negated filter excludes the matches
+type queryFilter struct {
+	field   string
+	value   string
+	negated bool
+}
+
+//searchQuery is a parsed search string. A row matches if it matches a term of each group, i.e. groups are AND-ed and
+//the terms in a group OR-ed, and none of the excluded terms. A folded query has its terms folded for an Icelandic row
+type searchQuery struct {
+	groups   [][]queryTerm
+	excluded []queryTerm
+	filters  []queryFilter
+	folded   bool
+}
+
+//queryToken is a term, filter or OR in the search string along with where it starts
+type queryToken struct {
+	position int
+	term     queryTerm
+	filter   *queryFilter
+	negated  bool
+	or       bool
+}
+
+//parseSearchQuery parses the search string. Words are required, "quoted phrases" must appear as is, -term excludes the
+//term, OR between terms matches either of them and author:, topic: and lang: filter the results. Returns a *QueryError
+//if the search string is not a valid query
+func parseSearchQuery(searchString string) (searchQuery, error) {
+	tokens, err := tokenizeSearchQuery([]rune(searchString))
+	if err != nil {
+		return searchQuery{}, err
+	}
+
+	query := searchQuery{}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token.or:
+			if i == 0 || !tokens[i-1].isPositiveTerm() || i+1 == len(tokens) || !tokens[i+1].isPositiveTerm() {
+				return query, &QueryError{Position: token.position, Message: "OR must be between two terms"}
+			}
+			//The term after OR joins the group of the term before it
+			i++
+			if hasLexemes(tokens[i].term.text) {
+				last := len(query.groups) - 1
+				query.groups[last] = append(query.groups[last], tokens[i].term)
+			}
+		case token.filter != nil:
+			query.filters = append(query.filters, *token.filter)
+		case token.negated:
+			if hasLexemes(token.term.text) {
+				query.excluded = append(query.excluded, token.term)
+			}
+		default:
+			//A term without any words, e.g. '&', is left out the same way plainto_tsquery leaves it out, but still
+			//starts a group so that an OR after it has a group to join
+			group := []queryTerm{}
+			if hasLexemes(token.term.text) {
+				group = append(group, token.term)
+			}
+			query.groups = append(query.groups, group)
+		}
+	}
+
+	groups := [][]queryTerm{}
+	for _, group := range query.groups {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	query.groups = groups
+	return query, nil
+}
+
+func tokenizeSearchQuery(runes []rune) ([]queryToken, error) {
+	tokens := []queryToken{}
+	for pos := 0; pos < len(runes); {
+		if unicode.IsSpace(runes[pos]) {
+			pos++
+			continue
+		}
+		token := queryToken{position: pos + 1}
+		if runes[pos] == '-' {
+			token.negated = true
+			pos++
+			if pos == len(runes) || unicode.IsSpace(runes[pos]) {
+				return nil, &QueryError{Position: token.position, Message: "Expected a term after -"}
+			}
+		}
+
+		if runes[pos] == '"' {
+			phrase, end, err := readPhrase(runes, pos)
+			if err != nil {
+				return nil, err
+			}
+			token.term, pos = queryTerm{text: phrase, phrase: true}, end
+			tokens = append(tokens, token)
+			continue
+		}
+
+		word, end := readWord(runes, pos)
+		field := strings.ToLower(strings.SplitN(word, ":", 2)[0])
+		switch {
+		case word == "OR" && !token.negated:
+			token.or = true
+		case strings.Contains(word, ":") && isQueryField(field):
+			filter, filterEnd, err := readFilter(runes, pos, field, token.negated)
+			if err != nil {
+				return nil, err
+			}
+			token.filter, end = &filter, filterEnd
+		default:
+			token.term = queryTerm{text: word}
+		}
+		pos = end
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+//readPhrase reads the quoted phrase starting at start, returns the phrase and the position after the closing quote
+func readPhrase(runes []rune, start int) (string, int, error) {
+	for end := start + 1; end < len(runes); end++ {
+		if runes[end] == '"' {
+			phrase := strings.TrimSpace(string(runes[start+1 : end]))
+			if phrase == "" {
+				return "", 0, &QueryError{Position: start + 1, Message: "The phrase is empty"}
+			}
+			return phrase, end + 1, nil
+		}
+	}
+	return "", 0, &QueryError{Position: start + 1, Message: "The phrase is missing its closing quote"}
+}
+
+//readWord reads the word starting at start, a word ends at a space or a quote unless the quote is the value of a field
+func readWord(runes []rune, start int) (string, int) {
+	end := start
+	for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+		end++
+	}
+	return string(runes[start:end]), end
+}
+
+//readFilter reads the field filter starting at start, the value is either a word or a quoted phrase
+func readFilter(runes []rune, start int, field string, negated bool) (queryFilter, int, error) {
+	valueStart := start + len([]rune(field)) + 1
+	filter := queryFilter{field: field, negated: negated}
+	end := valueStart
+	if valueStart < len(runes) && runes[valueStart] == '"' {
+		value, phraseEnd, err := readPhrase(runes, valueStart)
+		if err != nil {
+			return filter, 0, err
+		}
+		filter.value, end = value, phraseEnd
+	} else {
+		filter.value, end = readWord(runes, valueStart)
+	}
+
+	if filter.value == "" {
+		return filter, 0, &QueryError{Position: valueStart + 1, Message: fmt.Sprintf("Expected a value after %s:", field)}
+	}
+	if field == queryFieldLanguage {
+		filter.value = strings.ToLower(filter.value)
+		if filter.value != "english" && filter.value != "icelandic" {
+			return filter, 0, &QueryError{Position: valueStart + 1, Message: "The lang: filter should be english or icelandic"}
+		}
+	}
+	return filter, end, nil
+}
+
+func isQueryField(field string) bool {
+	return field == queryFieldAuthor || field == queryFieldTopic || field == queryFieldLanguage
+}
+
+//hasLexemes is false for a term without any letters or digits, which the text search leaves out
+func hasLexemes(text string) bool {
+	return len(words(text)) > 0
+}
+
+func (token queryToken) isPositiveTerm() bool {
+	return !token.or && !token.negated && token.filter == nil
+}
+
+//terms returns the required / optional terms of the query, i.e. everything but the excluded terms
+func (query searchQuery) terms() []queryTerm {
+	terms := []queryTerm{}
+	for _, group := range query.groups {
+		terms = append(terms, group...)
+	}
+	return terms
+}
+
+//text returns the text of the terms of the query, e.g. for the similarity of the authors' names
+func (query searchQuery) text() string {
+	texts := []string{}
+	for _, term := range query.terms() {
+		texts = append(texts, term.text)
+	}
+	return strings.Join(texts, " ")
+}
+
+//maxPhrases caps the phrases of a query, whose number is the product of the sizes of its groups
+const maxPhrases = 16
+
+//phrases returns the texts the query reads as in order, one for every choice of a term of each group, e.g. "a b c"
+//and "d c" for `"a b" OR d c`, at most maxPhrases of them
+func (query searchQuery) phrases() []string {
+	phrases := []string{""}
+	for _, group := range query.groups {
+		next := []string{}
+		for _, phrase := range phrases {
+			for _, term := range group {
+				if len(next) < maxPhrases {
+					next = append(next, strings.TrimSpace(phrase+" "+term.text))
+				}
+			}
+		}
+		phrases = next
+	}
+	return phrases
+}
+
+//hasOperators is true for a query with an OR or an excluded term, which only the full text search matches the way
+//it is written
+func (query searchQuery) hasOperators() bool {
+	if len(query.excluded) > 0 {
+		return true
+	}
+	for _, group := range query.groups {
+		if len(group) > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+//phrase returns the words of the terms as they are in a normalized quote, i.e. in lowercase and separated by single
+//spaces, see normalizeQuote
+func (query searchQuery) phrase() string {
+	return strings.Join(words(query.text()), " ")
+}
+
+//isEmpty is true for a query without any terms or filters, which matches nothing
+func (query searchQuery) isEmpty() bool {
+	return len(query.groups) == 0 && len(query.excluded) == 0 && len(query.filters) == 0
+}
+
+//language returns the language the lang: filter asks for, a negated filter asks for the other language
+func (filter queryFilter) language() string {
+	if filter.negated == (filter.value == "english") {
+		return "icelandic"
+	}
+	return "english"
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//How many similar words are tried for each misspelled word, and how short a word can be before it is left alone since
+//it has too few trigrams to find similar words by
+const (
+	candidatesPerWord      = 3
+	minSuggestedWordLength = 3
+)
+
+//similarWord is a word in a lexeme view with its trigram similarity to a misspelled word
+type similarWord struct {
+	Word       string
+	Similarity float64
+}
+
+//wordLookup looks up words in one of the LexemeViews
+type wordLookup interface {
+	//known returns the given words that are in the view
+	known(words []string) ([]string, error)
+	//similar returns at most limit of the words in the view that are similar to the word, the most similar first
+	similar(word string, limit int) ([]similarWord, error)
+}
+
+//suggest corrects the words of the search string's terms that are not in the view. The first suggestion replaces each
+//of them with its most similar word, the others replace one of them with its next most similar word instead
+func suggest(searchString string, limit int, lookup wordLookup) ([]structs.SuggestionDBModel, error) {
+	query, err := parseSearchQuery(searchString)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := []structs.SuggestionDBModel{}
+	termWords := query.termWords()
+	if len(termWords) == 0 {
+		return suggestions, nil
+	}
+	known, err := lookup.known(termWords)
+	if err != nil {
+		return nil, err
+	}
+
+	misspelled := []string{}
+	candidates := map[string][]similarWord{}
+	for _, word := range termWords {
+		if contains(known, word) || len([]rune(word)) < minSuggestedWordLength {
+			continue
+		}
+		similar, err := lookup.similar(word, candidatesPerWord)
+		if err != nil {
+			return nil, err
+		}
+		if len(similar) > 0 {
+			misspelled = append(misspelled, word)
+			candidates[word] = similar
+		}
+	}
+	if len(misspelled) == 0 {
+		return suggestions, nil
+	}
+
+	best := map[string]similarWord{}
+	for _, word := range misspelled {
+		best[word] = candidates[word][0]
+	}
+	corrections := []map[string]similarWord{best}
+	for _, word := range misspelled {
+		for _, candidate := range candidates[word][1:] {
+			correction := map[string]similarWord{word: candidate}
+			for other, otherCandidate := range best {
+				if other != word {
+					correction[other] = otherCandidate
+				}
+			}
+			corrections = append(corrections, correction)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, correction := range corrections {
+		replacements := map[string]string{}
+		total := 0.0
+		for word, candidate := range correction {
+			replacements[word] = candidate.Word
+			total += candidate.Similarity
+		}
+		corrected := replaceWords(searchString, replacements)
+		if seen[corrected] {
+			continue
+		}
+		seen[corrected] = true
+		suggestions = append(suggestions, structs.SuggestionDBModel{SearchString: corrected, Similarity: total / float64(len(correction))})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool { return suggestions[i].Similarity > suggestions[j].Similarity })
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+//termWords returns the words of the terms and the excluded terms, without duplicates, the filters are left alone
+func (query searchQuery) termWords() []string {
+	result := []string{}
+	for _, term := range append(query.terms(), query.excluded...) {
+		for _, word := range words(term.text) {
+			if !contains(result, word) {
+				result = append(result, word)
+			}
+		}
+	}
+	return result
+}
+
+//replaceWords replaces the words (case insensitive) of the search string with their replacements and keeps everything
+//else, e.g. the quotes of a phrase and the - of an exclusion, as is. A capitalized word stays capitalized
+func replaceWords(searchString string, replacements map[string]string) string {
+	var builder strings.Builder
+	runes := []rune(searchString)
+	for start := 0; start < len(runes); {
+		if !isWordRune(runes[start]) {
+			builder.WriteRune(runes[start])
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		word := string(runes[start:end])
+		replacement, ok := replacements[strings.ToLower(word)]
+		switch {
+		case !ok:
+			builder.WriteString(word)
+		case unicode.IsUpper(runes[start]):
+			replacementRunes := []rune(replacement)
+			builder.WriteString(string(unicode.ToUpper(replacementRunes[0])) + string(replacementRunes[1:]))
+		default:
+			builder.WriteString(replacement)
+		}
+		start = end
+	}
+	return builder.String()
+}
+
+//isWordRune mirrors words, i.e. letters and digits are a part of a word and everything else is a separator
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
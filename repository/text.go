@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+//trailingAttribution matches an attribution at the end of a quote, e.g. ' - Steve Jobs' or '."—Mark Twain'
+var trailingAttribution = regexp.MustCompile(`(\s+|[.!?"”’'])[-–—~]+\s*\p{Lu}[^.!?,;:]{0,60}$`)
+
+//quoteSeparators are the whitespace, ASCII punctuation, curly quotes and dashes normalize_quote replaces with a space
+var quoteSeparators = regexp.MustCompile("[\\s!-/:-@\\[-`{-~‘’“”„«»–—…´]+")
+
+//normalizeQuote mirrors the normalize_quote function in Postgres, i.e. the quote without a trailing attribution, in
+//lowercase and with the punctuation replaced by single spaces
+func normalizeQuote(quote string) string {
+	quote = trailingAttribution.ReplaceAllString(quote, "${1}")
+	return strings.TrimSpace(quoteSeparators.ReplaceAllString(strings.ToLower(quote), " "))
+}
+
+//words splits the text into lowercase words, everything that is not a letter or a digit is a separator
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+//icelandicFolding mirrors the unaccent rules of the icelandic text search configuration for the Icelandic letters
+var icelandicFolding = strings.NewReplacer("þ", "th", "ð", "d", "æ", "ae", "á", "a", "é", "e", "í", "i", "ó", "o",
+	"ú", "u", "ý", "y", "ö", "o")
+
+//foldIcelandic returns the text in lowercase with þ, ð and æ spelled out in ASCII and the accents dropped, e.g.
+//'Þórður' becomes 'thordur'
+func foldIcelandic(text string) string {
+	return icelandicFolding.Replace(strings.ToLower(text))
+}
+
+//trigrams returns the set of trigrams of the text the same way pg_trgm does, i.e. each word is padded with two spaces
+//in front and one behind
+func trigrams(text string) map[string]bool {
+	result := map[string]bool{}
+	for _, word := range words(text) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = true
+		}
+	}
+	return result
+}
+
+//similarity is the pg_trgm similarity of the two texts, i.e. shared trigrams over all trigrams
+func similarity(a string, b string) float64 {
+	trigramsA := trigrams(a)
+	trigramsB := trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range trigramsA {
+		if trigramsB[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+//similarityThreshold is the default threshold of the % operator in pg_trgm
+const similarityThreshold = 0.3
+
+//matchesAll is true if every lexeme in query is in text (plainto_tsquery)
+func matchesAll(query []string, text []string) bool {
+	if len(query) == 0 {
+		return false
+	}
+	for _, lexeme := range query {
+		if !contains(text, lexeme) {
+			return false
+		}
+	}
+	return true
+}
+
+//matchesPhrase is true if the lexemes in query follow each other in text (phrase search)
+func matchesPhrase(query []string, text []string) bool {
+	if len(query) == 0 {
+		return false
+	}
+	for start := 0; start+len(query) <= len(text); start++ {
+		found := true
+		for i, lexeme := range query {
+			if text[start+i] != lexeme {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, item string) bool {
+	for _, listItem := range list {
+		if listItem == item {
+			return true
+		}
+	}
+	return false
+}
+
+//initcap mirrors Postgres' initcap, first letter of each word in uppercase and the rest in lowercase
+func initcap(text string) string {
+	runes := []rune(strings.ToLower(text))
+	startOfWord := true
+	for i, r := range runes {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			if startOfWord {
+				runes[i] = unicode.ToUpper(r)
+			}
+			startOfWord = false
+		} else {
+			startOfWord = true
+		}
+	}
+	return string(runes)
+}
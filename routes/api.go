@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"log"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+)
+
+// Api holds the repositories that the routes fetch their data from
+type Api struct {
+	*repository.Repositories
+	// Refresher keeps the materialized views up to date after writes
+	Refresher *handlers.ViewRefresher
+}
+
+// NewApi returns the routes of the api backed by the given repositories
+func NewApi(repos *repository.Repositories) *Api {
+	return &Api{Repositories: repos, Refresher: handlers.NewViewRefresher(repos.Views)}
+}
+
+// offline runs the "offline" database update, i.e. a popularity count, in the background. The counts do not mark the
+// materialized views as stale, every read counts, they are picked up by the scheduled refresh. Only the writes of the
+// content mark the views stale
+func (api *Api) offline(update func() error) {
+	go func() {
+		if err := update(); err != nil {
+			log.Printf("Got error when updating the database offline: %s", err)
+		}
+	}()
+}
+
+// refreshNow refreshes the views right away so that an edit is seen at once, returns false if the views will only be
+// refreshed later in the background
+func (api *Api) refreshNow(views ...string) bool {
+	if err := api.Refresher.Refresh(views...); err != nil {
+		api.Refresher.MarkStale()
+		return false
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// swagger:route POST /authors/aliases/add AUTHORS AddAuthorAliases
+// Add other names or spellings to an author, they are searchable and can be used in place of the author's name (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: aliasTakenResponse
+//  500: internalServerErrorResponse
+
+// AddAuthorAliases handles POST requests to add the aliases to the author with the given id
+func (api *Api) AddAuthorAliases(rw http.ResponseWriter, r *http.Request) {
+	api.changeAuthorAliases(rw, r, api.Authors.AddAliases, "AddAuthorAliases")
+}
+
+// swagger:route POST /authors/aliases/remove AUTHORS RemoveAuthorAliases
+// Remove aliases from an author (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// RemoveAuthorAliases handles POST requests to remove the aliases from the author with the given id
+func (api *Api) RemoveAuthorAliases(rw http.ResponseWriter, r *http.Request) {
+	api.changeAuthorAliases(rw, r, api.Authors.RemoveAliases, "RemoveAuthorAliases")
+}
+
+// swagger:route POST /authors/merge AUTHORS MergeAuthors
+// Merge duplicate author records into the canonical author, which gets their quotes, aliases, popularity and profile details, and keeps their names as aliases. The merged authors are soft deleted (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// MergeAuthors handles POST requests to merge the authors with the given ids into the author with the given id
+func (api *Api) MergeAuthors(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+	if requestBody.Id <= 0 || len(requestBody.Ids) == 0 || containsInt(requestBody.Ids, requestBody.Id) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the canonical author and the ids of the authors to merge into it", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	survivor, err := api.Authors.Merge(requestBody.Id, requestBody.Ids)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Some of the authors do not exist or are deleted", StatusCode: http.StatusNotFound})
+		return
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when merging the authors %v into the author %d: %s", requestBody.Ids, requestBody.Id, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	//The quotes changed authors in the views
+	api.refreshNow(repository.MaterializedViews...)
+	api.writeAuthorWithAliases(rw, survivor, "MergeAuthors")
+}
+
+// changeAuthorAliases authorizes the GOD-tier user, validates the aliases and adds / removes them through change
+func (api *Api) changeAuthorAliases(rw http.ResponseWriter, r *http.Request, change func(authorId int, aliases []string) error, route string) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	aliases := []string{}
+	for _, alias := range requestBody.Aliases {
+		alias = strings.TrimSpace(alias)
+		if alias != "" && !containsString(aliases, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
+	if requestBody.Id <= 0 || len(aliases) == 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the author and its aliases", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	err := change(requestBody.Id, aliases)
+	switch {
+	case errors.Is(err, repository.ErrAliasTaken):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Some of the aliases are the name or an alias of another author", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No author exists with the id %d", requestBody.Id), StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when changing the aliases %v of the author %d in %s: %s", aliases, requestBody.Id, route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		authors, err := api.Authors.GetByIds([]int{requestBody.Id})
+		if err != nil || len(authors) == 0 {
+			rw.WriteHeader(http.StatusInternalServerError)
+			log.Printf("Got error when fetching the author %d in %s: %v", requestBody.Id, route, err)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+			return
+		}
+		api.writeAuthorWithAliases(rw, authors[0], route)
+	}
+}
+
+// writeAuthorWithAliases writes the author along with its aliases
+func (api *Api) writeAuthorWithAliases(rw http.ResponseWriter, author structs.AuthorDBModel, route string) {
+	authorsAPI, err := api.withAliases([]structs.AuthorDBModel{author})
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when getting the aliases of the author %d in %s: %s", author.Id, route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(authorsAPI[0])
+}
+
+// withAliases converts the authors to their api model along with their aliases
+func (api *Api) withAliases(authors []structs.AuthorDBModel) ([]structs.AuthorAPIModel, error) {
+	authorsAPI := structs.ConvertToAuthorsAPIModel(authors)
+	if len(authors) == 0 {
+		return authorsAPI, nil
+	}
+	authorIds := []int{}
+	for _, author := range authors {
+		authorIds = append(authorIds, author.Id)
+	}
+	aliases, err := api.Authors.Aliases(authorIds)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range authorsAPI {
+		for _, alias := range aliases {
+			if alias.AuthorId == authorsAPI[idx].Id {
+				authorsAPI[idx].Aliases = append(authorsAPI[idx].Aliases, alias.Alias)
+			}
+		}
+	}
+	return authorsAPI, nil
+}
+
+// resolveAuthor sets the authorId of the request from the author's name or alias, if given instead of the id. Writes
+// a 404 if no author has the name or alias
+func (api *Api) resolveAuthor(rw http.ResponseWriter, requestBody *structs.Request) error {
+	name := strings.TrimSpace(requestBody.Author)
+	if requestBody.AuthorId > 0 || name == "" {
+		return nil
+	}
+	author, err := api.Authors.Resolve(name)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No author has the name or alias %s", name), StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when resolving the author %s: %s", name, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		requestBody.AuthorId = author.Id
+	}
+	return err
+}
+
+// resolveAuthors adds to the ids of the request the ids of the authors with the given names or aliases, the unknown
+// names are left out like unknown ids
+func (api *Api) resolveAuthors(requestBody *structs.Request) error {
+	for _, name := range requestBody.Authors {
+		author, err := api.Authors.Resolve(strings.TrimSpace(name))
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !containsInt(requestBody.Ids, author.Id) {
+			requestBody.Ids = append(requestBody.Ids, author.Id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestAuthorAliasesWithFixtures tests the aliases of the authors and merging authors against the fixtures
+func TestAuthorAliasesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		authorRequest := func(fn httpRequest, body jsonBody) (structs.AuthorAPIModel, int) {
+			var author structs.AuthorAPIModel
+			statusCode := requestAndDecode(t, fn, body, &author)
+			return author, statusCode
+		}
+		getAuthors := func(body jsonBody) []structs.AuthorAPIModel {
+			var authors []structs.AuthorAPIModel
+			body["apiKey"] = apiKey
+			requestAndDecode(t, testApi.GetAuthorsById, body, &authors)
+			return authors
+		}
+		getQuotes := func(body jsonBody) ([]structs.SearchViewAPIModel, int) {
+			var quotes []structs.SearchViewAPIModel
+			body["apiKey"] = apiKey
+			statusCode := requestAndDecode(t, testApi.GetQuotes, body, &quotes)
+			return quotes, statusCode
+		}
+
+		t.Run("should add aliases to an author, unless another author has them", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.AddAuthorAliases, jsonBody{"apiKey": apiKey, "id": 1, "aliases": []string{"Cassius Clay"}}, http.StatusUnauthorized},
+			})
+			author, statusCode := authorRequest(testApi.AddAuthorAliases, jsonBody{"apiKey": godApiKey, "id": 1, "aliases": []string{"Cassius Clay", " The Greatest ", "cassius clay"}})
+			if statusCode != http.StatusOK || len(author.Aliases) != 2 || author.Aliases[0] != "Cassius Clay" || author.Aliases[1] != "The Greatest" {
+				t.Fatalf("got status code %d and %+v, want Muhammad Ali with his two aliases", statusCode, author)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse another author's alias", testApi.AddAuthorAliases, jsonBody{"apiKey": godApiKey, "id": 5, "aliases": []string{"the greatest"}}, http.StatusConflict},
+				{"should refuse another author's name", testApi.AddAuthorAliases, jsonBody{"apiKey": godApiKey, "id": 5, "aliases": []string{"Oscar Wilde"}}, http.StatusConflict},
+				{"should refuse an unknown author", testApi.AddAuthorAliases, jsonBody{"apiKey": godApiKey, "id": 9999, "aliases": []string{"Nobody"}}, http.StatusNotFound},
+				{"should refuse creating an author with an alias", testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Cassius Clay"}, http.StatusConflict},
+			})
+		})
+
+		t.Run("should search the aliases and resolve them in place of the author", func(t *testing.T) {
+			var authors []structs.AuthorAPIModel
+			requestAndDecode(t, testApi.SearchAuthorsByString, jsonBody{"apiKey": apiKey, "searchString": "Cassius Clay"}, &authors)
+			if len(authors) == 0 || authors[0].Name != "Muhammad Ali" {
+				t.Fatalf("got %+v, want Muhammad Ali first", authors)
+			}
+
+			if authors := getAuthors(jsonBody{"authors": []string{"cassius clay", "Nobody"}}); len(authors) != 1 || authors[0].Id != 1 || len(authors[0].Aliases) != 2 {
+				t.Fatalf("got %+v, want Muhammad Ali with his aliases", authors)
+			}
+			quotes, statusCode := getQuotes(jsonBody{"author": "CASSIUS CLAY"})
+			if statusCode != http.StatusOK || len(quotes) == 0 {
+				t.Fatalf("got status code %d and %+v, want Muhammad Ali's quotes", statusCode, quotes)
+			}
+			for _, quote := range quotes {
+				if quote.AuthorId != 1 {
+					t.Fatalf("got %+v, want only Muhammad Ali's quotes", quote)
+				}
+			}
+			if _, statusCode = getQuotes(jsonBody{"author": "Nobody"}); statusCode != http.StatusNotFound {
+				t.Fatalf("got status code %d for an unknown author but expected %d", statusCode, http.StatusNotFound)
+			}
+		})
+
+		t.Run("should merge a duplicate author into the canonical one", func(t *testing.T) {
+			var quote structs.QuoteAPIModel
+			requestAndDecode(t, testApi.CreateQuote, jsonBody{"apiKey": godApiKey, "quote": "I am the greatest, I said that even before I knew I was.", "author": "M. Ali"}, &quote)
+			duplicate, statusCode := authorRequest(testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Cassius Marcellus Clay"})
+			if statusCode != http.StatusOK {
+				t.Fatalf("got status code %d when creating the duplicate author", statusCode)
+			}
+			authorRequest(testApi.UpdateAuthorProfile, jsonBody{"apiKey": godApiKey, "id": duplicate.Id, "birthDate": "1942", "nationality": "CA", "professions": []string{"Poet"}})
+			mAli := getAuthors(jsonBody{"authors": []string{"M. Ali"}})
+			if len(mAli) != 1 {
+				t.Fatalf("got %+v, want the author of the new quote", mAli)
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should refuse merging an author into itself", testApi.MergeAuthors, jsonBody{"apiKey": godApiKey, "id": 1, "ids": []int{1, duplicate.Id}}, http.StatusBadRequest},
+				{"should refuse an unknown author", testApi.MergeAuthors, jsonBody{"apiKey": godApiKey, "id": 1, "ids": []int{9999}}, http.StatusNotFound},
+			})
+			author, statusCode := authorRequest(testApi.MergeAuthors, jsonBody{"apiKey": godApiKey, "id": 1, "ids": []int{duplicate.Id, mAli[0].Id}})
+			if statusCode != http.StatusOK || len(author.Aliases) != 4 || author.Nationality != "US" || len(author.Professions) != 3 {
+				t.Fatalf("got status code %d and %+v, want Muhammad Ali with the names of the duplicates as aliases and their professions", statusCode, author)
+			}
+
+			if authors := getAuthors(jsonBody{"ids": []int{duplicate.Id, mAli[0].Id}}); len(authors) != 0 {
+				t.Fatalf("got %+v, want the merged authors deleted", authors)
+			}
+			quotes, _ := getQuotes(jsonBody{"author": "M. Ali", "pageSize": 100})
+			found := false
+			for _, row := range quotes {
+				found = found || row.QuoteId == quote.Id
+			}
+			if !found {
+				t.Fatalf("got %+v, want the quote of the merged author among Muhammad Ali's quotes", quotes)
+			}
+
+			//A new quote by the merged name goes to the canonical author
+			quote = structs.QuoteAPIModel{}
+			requestAndDecode(t, testApi.CreateQuote, jsonBody{"apiKey": godApiKey, "quote": "Impossible is just a big word thrown around by small men.", "author": "Cassius Marcellus Clay"}, &quote)
+			if quote.AuthorId != 1 {
+				t.Fatalf("got %+v, want the quote by Muhammad Ali", quote)
+			}
+		})
+
+		t.Run("should remove aliases", func(t *testing.T) {
+			author, statusCode := authorRequest(testApi.RemoveAuthorAliases, jsonBody{"apiKey": godApiKey, "id": 1, "aliases": []string{"the greatest"}})
+			if statusCode != http.StatusOK || len(author.Aliases) != 3 || containsString(author.Aliases, "The Greatest") {
+				t.Fatalf("got status code %d and %+v, want Muhammad Ali without the alias", statusCode, author)
+			}
+			if _, statusCode = getQuotes(jsonBody{"author": "The Greatest"}); statusCode != http.StatusNotFound {
+				t.Fatalf("got status code %d for a removed alias but expected %d", statusCode, http.StatusNotFound)
+			}
+		})
+	})
+}
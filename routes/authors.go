@@ -2,41 +2,54 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// maxBioLength is the maximum number of characters in the bio of an author
+const maxBioLength = 2000
 
-	"gorm.io/gorm"
+var (
+	profileDateRegex = regexp.MustCompile(`^-?[0-9]{1,4}(-[0-9]{2}(-[0-9]{2})?)?$`)
+	profileYearRegex = regexp.MustCompile(`^-?[0-9]+`)
+	nationalityRegex = regexp.MustCompile(`^[A-Z]{2}$`)
 )
 
 // swagger:route POST /authors AUTHORS GetAuthors
-// Get the authors by their ids
+// Get the authors, along with their aliases, by their ids and / or names or aliases
 //
 // responses:
 //	200: authorsResponse
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-// Get Authors handles POST requests to get the authors, and their quotes, that have the given ids
-func GetAuthorsById(rw http.ResponseWriter, r *http.Request) {
+// Get Authors handles POST requests to get the authors, and their quotes, that have the given ids or names / aliases
+func (api *Api) GetAuthorsById(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
+	err := api.resolveAuthors(&requestBody)
 	var authors []structs.AuthorDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	err := handlers.Db.Table("authors").
-		Where("id in (?)", requestBody.Ids).
-		Scan(&authors).
-		Error
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
+	if err == nil {
+		authors, err = api.Authors.GetByIds(requestBody.Ids)
+	}
+	var authorsAPI []structs.AuthorAPIModel
+	if err == nil {
+		authorsAPI, err = api.withAliases(authors)
+	}
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -46,9 +59,8 @@ func GetAuthorsById(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	//Update popularity in background!
-	go handlers.DirectFetchAuthorsCountIncrement(requestBody.Ids)
+	api.offline(func() error { return handlers.DirectFetchAuthorsCountIncrement(api.Repositories, requestBody.Ids) })
 
-	authorsAPI := structs.ConvertToAuthorsAPIModel(authors)
 	json.NewEncoder(rw).Encode(&authorsAPI)
 }
 
@@ -62,69 +74,30 @@ func GetAuthorsById(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // GetAuthorsList handles POST requests to get the authors that fit the parameters
-func GetAuthorsList(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetAuthorsList(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
-	var authors []structs.AuthorDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := handlers.Db.Table("authors")
-
-	dbPointer = authorLanguageSQL(requestBody.Language, dbPointer)
-
-	orderDirection := "ASC"
-	if requestBody.OrderConfig.Reverse {
-		orderDirection = "DESC"
-	}
-
-	switch strings.ToLower(requestBody.OrderConfig.OrderBy) {
-	case "popularity": //TODO: add popularity ordering
-		orderDirection = "DESC"
-		if requestBody.OrderConfig.Reverse {
-			orderDirection = "ASC"
-		}
-		dbPointer = dbPointer.Order("count " + orderDirection)
-	case "nrofquotes":
-		switch strings.ToLower(requestBody.Language) {
-		case "english":
-			dbPointer = setMaxMinNumber(requestBody.OrderConfig, "nr_of_english_quotes", orderDirection, dbPointer)
-		case "icelandic":
-			dbPointer = setMaxMinNumber(requestBody.OrderConfig, "nr_of_icelandic_quotes", orderDirection, dbPointer)
-		default:
-			dbPointer = setMaxMinNumber(requestBody.OrderConfig, "nr_of_icelandic_quotes + nr_of_english_quotes", orderDirection, dbPointer)
-		}
-
-	default:
-		//Minimum letter to start with (i.e. start from given minimum letter of the alphabet)
-		if requestBody.OrderConfig.Minimum != "" {
-			dbPointer = dbPointer.Where("initcap(name) >= ?", strings.ToUpper(requestBody.OrderConfig.Minimum))
-		}
-		//Maximum letter to start with (i.e. end at the given maximum letter of the alphabet)
-		if requestBody.OrderConfig.Maximum != "" {
-			dbPointer = dbPointer.Where("initcap(name) <= ?", strings.ToUpper(requestBody.OrderConfig.Maximum))
-		}
-		dbPointer = dbPointer.Order("initcap(name) " + orderDirection)
-	}
-
-	//** ---------- Paramatere configuratino for DB query ends---------- **//
-	err := pagination(requestBody, dbPointer).Order("id").
-		Find(&authors).
-		Error
+	authors, err := api.Authors.List(listRequest(requestBody))
 
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when querying DB in GetAuthors: %s", err)
-		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		writeListError(rw, err, "GetAuthorsList")
 		return
 	}
+	nrOfAuthors, hasMore := listedPage(requestBody, len(authors))
+	authors = authors[:nrOfAuthors]
 
 	//Update popularity in background!
-	go handlers.AuthorsAppearInSearchCountIncrement(authors)
+	api.offline(func() error { return handlers.AuthorsAppearInSearchCountIncrement(api.Repositories, authors) })
 
 	authorsAPI := structs.ConvertToAuthorsAPIModel(authors)
-	json.NewEncoder(rw).Encode(&authorsAPI)
+	nextCursor := ""
+	if len(authors) > 0 {
+		nextCursor = repository.AuthorsListCursor(requestBody, authors[len(authors)-1])
+	}
+	writePage(rw, requestBody, &authorsAPI, len(authorsAPI), api.Authors.ListTotal, nextCursor, hasMore, "GetAuthorsList")
 }
 
 // swagger:route POST /authors/random AUTHORS GetRandomAuthor
@@ -135,24 +108,13 @@ func GetAuthorsList(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // GetRandomAuthor handles POST requests for getting a random author
-func GetRandomAuthor(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetRandomAuthor(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
-	var result []structs.SearchViewDBModel
-	var author structs.AuthorDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-
-	//Get Random author
-	dbPointer := handlers.Db.Table("authors").Order("random()")
-
-	//author from a particular language
-	dbPointer = authorLanguageSQL(requestBody.Language, dbPointer)
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-
-	err := dbPointer.First(&author).Error
+	author, err := api.Authors.Random(requestBody.Language)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -161,12 +123,8 @@ func GetRandomAuthor(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	dbPointer = handlers.Db.Table("searchview").Where("author_id = ?", author.Id)
-
 	//An icelandic quote from the particular/random author
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
-
-	err = dbPointer.Limit(requestBody.MaxQuotes).Find(&result).Error
+	result, err := api.Quotes.GetByAuthor(author.Id, requestBody.Language, requestBody.MaxQuotes)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -186,27 +144,17 @@ func GetRandomAuthor(rw http.ResponseWriter, r *http.Request) {
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//GetAuthorOfTheDay gets the author of the day
-func GetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
+// GetAuthorOfTheDay gets the author of the day
+func (api *Api) GetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 	if requestBody.Language == "" {
 		requestBody.Language = "English"
 	}
 
-	var author structs.AodDBModel
-	var err error
-
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-
-	//Which table to look for quotes (ice table has icelandic quotes)
-	dbPointer := aodLanguageSQL(requestBody.Language).
-		Where("date = current_date")
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-
-	err = dbPointer.Scan(&author).Error
+	author, err := api.OfTheDay.GetAuthorOfTheDay(requestBody.Language)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -216,7 +164,7 @@ func GetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	if (structs.AodDBModel{}) == author {
-		err = setNewRandomAOD(requestBody.Language)
+		err = api.OfTheDay.SetRandomAuthorOfTheDay(requestBody.Language)
 		if err != nil {
 			rw.WriteHeader(http.StatusInternalServerError)
 			log.Printf("Got error when setting new random AOD in GetAuthorOfTheDay: %s", err)
@@ -224,7 +172,7 @@ func GetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		GetAuthorOfTheDay(rw, r) //Dangerous? possibility of endless cycle? Only iff the setNewRandomAOD fails in some way. Or the date is not saved correctly into the DB?
+		api.GetAuthorOfTheDay(rw, r) //Dangerous? possibility of endless cycle? Only iff the setNewRandomAOD fails in some way. Or the date is not saved correctly into the DB?
 		return
 	}
 
@@ -238,20 +186,15 @@ func GetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//GetAODHistory gets Aod history starting from some point
-func GetAODHistory(rw http.ResponseWriter, r *http.Request) {
+// GetAODHistory gets Aod history starting from some point
+func (api *Api) GetAODHistory(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 	if requestBody.Language == "" {
 		requestBody.Language = "English"
 	}
-	var authors []structs.AodDBModel
-	var err error
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := aodLanguageSQL(requestBody.Language)
-
 	if requestBody.Minimum == "" {
 		requestBody.Minimum = "1900-12-21"
 	}
@@ -272,13 +215,7 @@ func GetAODHistory(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//Not maximum because then possibility of endless cycle with the if statement below!
-	if requestBody.Minimum != "" {
-		dbPointer = dbPointer.Where("date >= ?", requestBody.Minimum)
-	}
-	dbPointer = dbPointer.Where("date <= current_date").Order("date DESC")
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err = dbPointer.Find(&authors).Error
+	authors, err := api.OfTheDay.AuthorOfTheDayHistory(requestBody.Language, requestBody.Minimum)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -290,7 +227,7 @@ func GetAODHistory(rw http.ResponseWriter, r *http.Request) {
 	reg := regexp.MustCompile(time.Now().Format("2006-01-02"))
 
 	if len(authors) == 0 || !reg.Match([]byte(authors[0].Date)) {
-		err = setNewRandomAOD(requestBody.Language)
+		err = api.OfTheDay.SetRandomAuthorOfTheDay(requestBody.Language)
 		if err != nil {
 			rw.WriteHeader(http.StatusInternalServerError)
 			log.Printf("Got error when setting newRandomAOD in getAODHistory: %s", err)
@@ -298,7 +235,7 @@ func GetAODHistory(rw http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		GetAODHistory(rw, r)
+		api.GetAODHistory(rw, r)
 		return
 	}
 
@@ -314,13 +251,13 @@ func GetAODHistory(rw http.ResponseWriter, r *http.Request) {
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//SetAuthorOfTheDay sets the author of the day.
-func SetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
-	if err := handlers.AuthorizeGODApiKey(rw, r); err != nil {
+// SetAuthorOfTheDay sets the author of the day.
+func (api *Api) SetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
 		return
 	}
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
@@ -332,7 +269,7 @@ func SetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, aod := range requestBody.Aods {
-		err := setAOD(requestBody.Language, aod.Date, aod.Id)
+		err := api.OfTheDay.SetAuthorOfTheDay(requestBody.Language, aod.Date, aod.Id)
 		if err != nil {
 			log.Printf("Got err when setting the AOD for %+v: %s", aod, err)
 			rw.WriteHeader(http.StatusBadRequest)
@@ -344,87 +281,186 @@ func SetAuthorOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Successfully inserted quote of the day!", StatusCode: http.StatusOK})
 }
 
-//setAOD inserts a new row into the aod/aodice table
-func setAOD(language string, date string, authorId int) error {
-	switch strings.ToLower(language) {
-	case "icelandic":
-		return handlers.Db.Exec("insert into aodice (author_id, date) values((select id from authors where id = ? and has_icelandic_quotes), ?) on conflict (date) do update set author_id = ?", authorId, date, authorId).Error
-	default:
-		return handlers.Db.Exec("insert into aod (author_id, date) values((select id from authors where id = ? and not has_icelandic_quotes), ?) on conflict (date) do update set author_id = ?", authorId, date, authorId).Error
+// swagger:route POST /authors/new AUTHORS CreateAuthor
+// Create a new author, without any quotes (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  409: authorExistsResponse
+//  500: internalServerErrorResponse
+
+// CreateAuthor handles POST requests to create an author
+func (api *Api) CreateAuthor(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getAuthorRequestBody(rw, r, &requestBody); err != nil {
+		return
 	}
+
+	author, err := api.Authors.Create(requestBody.Name)
+	api.writeAuthor(rw, author, err, "CreateAuthor")
 }
 
-//SetNewRandomQOD sets a random quote as the qod for today (if language=icelandic is supplied then it adds the random qod to the icelandic qod table)
-func setNewRandomAOD(language string) error {
-	var authorItem structs.AuthorDBModel
+// swagger:route POST /authors/update AUTHORS UpdateAuthor
+// Rename an author, its counters and search vector are recomputed (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: authorExistsResponse
+//  500: internalServerErrorResponse
 
-	if language == "" {
-		language = "english"
+// UpdateAuthor handles POST requests to update the author with the given id
+func (api *Api) UpdateAuthor(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getAuthorRequestBody(rw, r, &requestBody); err != nil {
+		return
 	}
-	dbPointer := handlers.Db.Table("authors")
-	dbPointer = authorLanguageSQL(language, dbPointer)
 
-	err := dbPointer.Order("random()").Limit(1).Scan(&authorItem).Error
-	if err != nil {
-		return err
+	if requestBody.Id <= 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the author", StatusCode: http.StatusBadRequest})
+		return
 	}
 
-	return setAOD(language, time.Now().Format("2006-01-02"), authorItem.Id)
+	author, err := api.Authors.Update(requestBody.Id, requestBody.Name)
+	if err == nil {
+		//The author's name is in the views
+		api.Refresher.MarkStale()
+	}
+	api.writeAuthor(rw, author, err, "UpdateAuthor")
 }
 
-//aodLanguageSQL adds to the sql query for the authors db a condition of whether the authors to be fetched have quotes in a particular language
-func aodLanguageSQL(language string) *gorm.DB {
-	switch strings.ToLower(language) {
-	case "icelandic":
-		return handlers.Db.Table("aodiceview")
-	default:
-		return handlers.Db.Table("aodview")
+// swagger:route POST /authors/profile AUTHORS UpdateAuthorProfile
+// Set the profile of an author, i.e. the birth and death dates, nationality, professions, bio and reference links. The whole profile is replaced (is password protected)
+// responses:
+//	200: authorResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// UpdateAuthorProfile handles POST requests to replace the profile of the author with the given id
+func (api *Api) UpdateAuthorProfile(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
 	}
-}
 
-//qodLanguageSQL adds to the sql query for the quotes db a condition of whether the quotes to be fetched are quotes in a particular language
-func qodLanguageSQL(language string) *gorm.DB {
-	switch strings.ToLower(language) {
-	case "icelandic":
-		return handlers.Db.Table("qodiceview")
-	default:
-		return handlers.Db.Table("qodview")
+	profile, err := authorProfileFromRequest(requestBody)
+	if err == nil && requestBody.Id <= 0 {
+		err = errors.New("please supply the id of the author")
 	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	author, err := api.Authors.UpdateProfile(requestBody.Id, profile)
+	api.writeAuthor(rw, author, err, "UpdateAuthorProfile")
 }
 
-//authorLanguageSQL adds to the sql query for the authors db a condition of whether the authors to be fetched have quotes in a particular language
-func authorLanguageSQL(language string, dbPointer *gorm.DB) *gorm.DB {
-	if language != "" {
-		switch strings.ToLower(language) {
-		case "english":
-			dbPointer = dbPointer.Not("has_icelandic_quotes")
-		case "icelandic":
-			dbPointer = dbPointer.Where("has_icelandic_quotes")
+// authorProfileFromRequest validates the author's profile, the professions are lowercased and the nationality uppercased
+func authorProfileFromRequest(requestBody structs.Request) (structs.AuthorProfile, error) {
+	profile := structs.AuthorProfile{
+		BirthDate:   strings.TrimSpace(requestBody.BirthDate),
+		DeathDate:   strings.TrimSpace(requestBody.DeathDate),
+		Nationality: strings.ToUpper(strings.TrimSpace(requestBody.Nationality)),
+		Bio:         strings.TrimSpace(requestBody.Bio),
+	}
+	for _, date := range []string{profile.BirthDate, profile.DeathDate} {
+		if date != "" && !validProfileDate(date) {
+			return profile, fmt.Errorf("the date %s should be ISO 8601 as precise as is known, e.g. 1844, 1844-10 or 1844-10-15 (negative years are BC)", date)
 		}
 	}
-	return dbPointer
+	if profile.BirthDate != "" && profile.DeathDate != "" && profileYear(profile.DeathDate) < profileYear(profile.BirthDate) {
+		return profile, errors.New("the author can not die before being born")
+	}
+	if profile.Nationality != "" && !nationalityRegex.MatchString(profile.Nationality) {
+		return profile, errors.New("the nationality should be an ISO 3166-1 alpha-2 country code, e.g. IS")
+	}
+	if utf8.RuneCountInString(profile.Bio) > maxBioLength {
+		return profile, fmt.Errorf("the bio can be at most %d characters long", maxBioLength)
+	}
+	for _, profession := range requestBody.Professions {
+		profession = strings.ToLower(strings.TrimSpace(profession))
+		if profession != "" && !containsString(profile.Professions, profession) {
+			profile.Professions = append(profile.Professions, profession)
+		}
+	}
+	for _, link := range requestBody.Links {
+		link.Title = strings.TrimSpace(link.Title)
+		link.Url = strings.TrimSpace(link.Url)
+		if !validUrl(link.Url) {
+			return profile, fmt.Errorf("the link %q should be an absolute http or https url", link.Url)
+		}
+		profile.Links = append(profile.Links, link)
+	}
+	return profile, nil
 }
 
-//quoteLanguageSQL adds to the sql query for the quotes db a condition of whether the quotes to be fetched are in a particular language
-func quoteLanguageSQL(language string, dbPointer *gorm.DB) *gorm.DB {
-	if language != "" {
-		switch strings.ToLower(language) {
-		case "english":
-			dbPointer = dbPointer.Not("is_icelandic")
-		case "icelandic":
-			dbPointer = dbPointer.Where("is_icelandic")
-		}
+// validProfileDate returns whether the date is ISO 8601 as precise as is known, with a real month and day if given
+func validProfileDate(date string) bool {
+	if !profileDateRegex.MatchString(date) {
+		return false
 	}
-	return dbPointer
+	parts := strings.Split(strings.TrimPrefix(date, "-"), "-")
+	if len(parts) == 1 {
+		return true
+	}
+	//The year does not matter for the month and day, other than for February 29th
+	layout, value := "2006-01", "2000-"+parts[1]
+	if len(parts) == 3 {
+		layout, value = "2006-01-02", "2000-"+parts[1]+"-"+parts[2]
+	}
+	_, err := time.Parse(layout, value)
+	return err == nil
 }
 
-//setMaxMinNumber sets the condition for which authors to return
-func setMaxMinNumber(orderConfig structs.OrderConfig, column string, orderDirection string, dbPointer *gorm.DB) *gorm.DB {
-	if nr, err := strconv.Atoi(orderConfig.Maximum); err == nil {
-		dbPointer = dbPointer.Where(column+" <= ?", nr)
+// profileYear returns the year of a valid profile date
+func profileYear(date string) int {
+	year, _ := strconv.Atoi(profileYearRegex.FindString(date))
+	return year
+}
+
+// getAuthorRequestBody authorizes the GOD-tier user and validates the name of the author
+func (api *Api) getAuthorRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) error {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return err
+	}
+	if err := handlers.GetRequestBody(rw, r, requestBody, api.Repositories); err != nil {
+		return err
+	}
+
+	requestBody.Name = strings.TrimSpace(requestBody.Name)
+	if requestBody.Name == "" {
+		err := errors.New("please supply the name of the author")
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+		return err
 	}
-	if nr, err := strconv.Atoi(orderConfig.Minimum); err == nil {
-		dbPointer = dbPointer.Where(column+" >= ?", nr)
+	return nil
+}
+
+// writeAuthor writes the created / updated author or the error
+func (api *Api) writeAuthor(rw http.ResponseWriter, author structs.AuthorDBModel, err error, route string) {
+	switch {
+	case errors.Is(err, repository.ErrAuthorExists):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "An author with this name already exists", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrAuthorDeleted):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "A deleted author has this name, restore it with /api/restore instead", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "No author exists with the given id", StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when querying DB in %s: %s", route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		json.NewEncoder(rw).Encode(author.ConvertToAPIModel())
 	}
-	return dbPointer.Order(column + " " + orderDirection)
 }
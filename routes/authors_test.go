@@ -3,6 +3,7 @@ package routes
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -19,7 +20,7 @@ func createUser(t *testing.T) structs.UserResponse {
 	name := "Þórður Ágústsson"
 	email := random.String() + "@gmail.com"
 	var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, name, password, passwordConfirmation, email))
-	userResponse, response := basicRequestReturnSingle(jsonStr, CreateUser)
+	userResponse, response := basicRequestReturnSingle(jsonStr, api.CreateUser)
 	if response.Result().StatusCode != 200 {
 		t.Fatalf("Failed creating user, got statust code %d but expected %d", response.Result().StatusCode, 200)
 	}
@@ -43,6 +44,7 @@ func getGODModeUser(t *testing.T) structs.UserResponse {
 }
 
 func TestAuthors(t *testing.T) {
+	requireDatabase(t)
 	user := createUser(t)
 	godUser := getGODModeUser(t)
 
@@ -52,7 +54,7 @@ func TestAuthors(t *testing.T) {
 			authorId := Set{1}
 			var jsonStr = []byte(fmt.Sprintf(`{"ids": [%s], "apiKey":"%s"}`, authorId.toString(), user.ApiKey))
 
-			respObj, _ := requestAndReturnArray(jsonStr, GetAuthorsById)
+			respObj, _ := requestAndReturnArray(jsonStr, api.GetAuthorsById)
 			firstAuthor := respObj[0]
 			if firstAuthor.Id != authorId[0] {
 				t.Fatalf("got %d, want %d", firstAuthor.AuthorId, authorId[0])
@@ -63,7 +65,7 @@ func TestAuthors(t *testing.T) {
 			authorId := []string{"1", "2", "3"}
 			var jsonStr = []byte(fmt.Sprintf(`{"ids": [%s], "apiKey":"%s"}`, authorId, user.ApiKey))
 
-			_, response := requestAndReturnArray(jsonStr, GetAuthorsById)
+			_, response := requestAndReturnArray(jsonStr, api.GetAuthorsById)
 			if response.StatusCode != 400 {
 				t.Fatalf("got statusCode %d, want 400", response.StatusCode)
 			}
@@ -78,7 +80,7 @@ func TestAuthors(t *testing.T) {
 			pageSize := 50
 			var jsonStr = []byte(fmt.Sprintf(`{"pageSize": %d,"apiKey":"%s"}`, pageSize, user.ApiKey))
 
-			respObj, _ := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, _ := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if len(respObj) != 50 {
 				t.Fatalf("got list of length %d, but expected list of length %d", len(respObj), pageSize)
@@ -96,7 +98,7 @@ func TestAuthors(t *testing.T) {
 			language := "english"
 			var jsonStr = []byte(fmt.Sprintf(`{"language": "%s","apiKey":"%s"}`, language, user.ApiKey))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -119,7 +121,7 @@ func TestAuthors(t *testing.T) {
 			language := "english"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s", "orderConfig":{"orderBy":"alphabetical", "reverse":true}}`, user.ApiKey, language))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -142,7 +144,7 @@ func TestAuthors(t *testing.T) {
 			minimum := "f"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s", "orderConfig":{"orderBy":"alphabetical","minimum":"%s"}}`, user.ApiKey, language, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -169,7 +171,7 @@ func TestAuthors(t *testing.T) {
 			maximum := 1
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "orderConfig":{"orderBy":"nrOfQuotes","maximum":"%d"}}`, user.ApiKey, maximum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -189,7 +191,7 @@ func TestAuthors(t *testing.T) {
 			maximum := 11
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"nrOfQuotes","maximum":"%d", "minimum":"%d"}}`, user.ApiKey, maximum, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -209,7 +211,7 @@ func TestAuthors(t *testing.T) {
 			maximum := 10
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"nrOfQuotes","maximum":"%d","reverse":true}}`, user.ApiKey, maximum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -227,7 +229,7 @@ func TestAuthors(t *testing.T) {
 			language := "icelandic"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s", "orderConfig":{"orderBy":"nrOfQuotes","reverse":true}}`, user.ApiKey, language))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -241,11 +243,11 @@ func TestAuthors(t *testing.T) {
 		})
 
 		t.Run("Should return first 50 authors (ordered by most popular, i.e. DESC count)", func(t *testing.T) {
-			handlers.DirectFetchAuthorsCountIncrement([]int{1})
+			handlers.DirectFetchAuthorsCountIncrement(api.Repositories, []int{1})
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"%s"}}`, user.ApiKey, "popularity"))
 
-			respObj, errResponse := requestAndReturnArrayAuthors(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArrayAuthors(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -263,7 +265,7 @@ func TestAuthors(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"%s","reverse":true}}`, user.ApiKey, "popularity"))
 
-			respObj, errResponse := requestAndReturnArrayAuthors(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArrayAuthors(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -281,7 +283,7 @@ func TestAuthors(t *testing.T) {
 			pageSize := 100
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize":%d}}`, user.ApiKey, pageSize))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -298,7 +300,7 @@ func TestAuthors(t *testing.T) {
 			minimum := "F"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize":%d, "orderConfig":{"minimum":"%s"}}}`, user.ApiKey, pageSize, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			objToFetch := respObj[50]
 
@@ -314,7 +316,7 @@ func TestAuthors(t *testing.T) {
 			page := 1
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "pageSize":%d, "page":%d, "orderConfig":{"minimum":"%s"}}}`, user.ApiKey, pageSize, page, minimum))
 
-			respObj, errResponse = requestAndReturnArray(jsonStr, GetAuthorsList)
+			respObj, errResponse = requestAndReturnArray(jsonStr, api.GetAuthorsList)
 
 			if objToFetch.AuthorId != respObj[0].AuthorId {
 				t.Fatalf("got %+v, but expected %+v", respObj[0], objToFetch)
@@ -328,7 +330,7 @@ func TestAuthors(t *testing.T) {
 		t.Run("Should return a random author with only a single quote (i.e. default)", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s"}`, user.ApiKey))
-			firstRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			firstRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 
 			if len(firstRespObj) != 1 {
 				t.Fatalf("Expected only a single quote from the random author but got %d", len(firstRespObj))
@@ -339,7 +341,7 @@ func TestAuthors(t *testing.T) {
 				t.Fatalf("Expected a random author but got an empty name for author")
 			}
 
-			secondRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			secondRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 			secondAuthor := secondRespObj[0]
 			if firstAuthor.AuthorId == secondAuthor.AuthorId {
 				t.Fatalf("Expected two different authors but got the same author twice which is higly improbable, got author with id %d and name %s", firstAuthor.AuthorId, firstAuthor.Name)
@@ -350,7 +352,7 @@ func TestAuthors(t *testing.T) {
 		t.Run("Should return a random Author with only quotes from him in Icelandic", func(t *testing.T) {
 			language := "icelandic"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s"}`, user.ApiKey, language))
-			firstRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			firstRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 
 			firstAuthor := firstRespObj[0]
 			if firstAuthor.Name == "" {
@@ -361,7 +363,7 @@ func TestAuthors(t *testing.T) {
 				t.Fatalf("Expected the quotes returned to be in icelandic")
 			}
 
-			secondRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			secondRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 			secondAuthor := secondRespObj[0]
 			if firstAuthor.AuthorId == secondAuthor.AuthorId {
 				t.Fatalf("Expected two different authors but got the same author twice which is higly improbable, got author with id %d and name %s", firstAuthor.AuthorId, firstAuthor.Name)
@@ -372,7 +374,7 @@ func TestAuthors(t *testing.T) {
 
 			language := "english"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s"}`, user.ApiKey, language))
-			firstRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			firstRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 
 			firstAuthor := firstRespObj[0]
 			if firstAuthor.Name == "" {
@@ -383,7 +385,7 @@ func TestAuthors(t *testing.T) {
 				t.Fatalf("Expected the quotes returned to be in English")
 			}
 
-			secondRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			secondRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 			secondAuthor := secondRespObj[0]
 			if firstAuthor.AuthorId == secondAuthor.AuthorId {
 				t.Fatalf("Expected two different authors but got the same author twice which is higly improbable, got author with id %d and name %s", firstAuthor.AuthorId, firstAuthor.Name)
@@ -394,7 +396,7 @@ func TestAuthors(t *testing.T) {
 		t.Run("Should return author with a maximum of 2 of his quotes", func(t *testing.T) {
 			maxQuotes := 2
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","maxQuotes":%d}`, user.ApiKey, maxQuotes))
-			firstRespObj, _ := requestAndReturnArray(jsonStr, GetRandomAuthor)
+			firstRespObj, _ := requestAndReturnArray(jsonStr, api.GetRandomAuthor)
 
 			firstAuthor := firstRespObj[0]
 			if firstAuthor.Name == "" {
@@ -414,7 +416,7 @@ func TestAuthors(t *testing.T) {
 
 			authorId := 1
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "aods": [{"id":%d, "date":""}]}`, godUser.ApiKey, authorId))
-			_, response := requestAndReturnArray(jsonStr, SetAuthorOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetAuthorOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -428,7 +430,7 @@ func TestAuthors(t *testing.T) {
 			date2 := "2020-12-21"
 			authorId2 := 3
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "aods": [{"id":%d, "date":"%s"},{"id":%d, "date":"%s"}]}`, godUser.ApiKey, authorId1, date1, authorId2, date2))
-			_, response := requestAndReturnArray(jsonStr, SetAuthorOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetAuthorOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -438,7 +440,7 @@ func TestAuthors(t *testing.T) {
 		t.Run("Should get Author of the day", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "language":"%s"}`, user.ApiKey, "english"))
-			author := requestAndReturnSingle(jsonStr, GetAuthorOfTheDay)
+			author := requestAndReturnSingle(jsonStr, api.GetAuthorOfTheDay)
 
 			if author.Name == "" {
 				t.Fatalf("Expected the author of the day but got an empty author %+v", author)
@@ -462,7 +464,7 @@ func TestAuthors(t *testing.T) {
 			authorId := 1111
 			date := "1998-06-16"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "aods": [{"id":%d, "date":"%s"}]}`, godUser.ApiKey, authorId, date))
-			_, response := requestAndReturnArray(jsonStr, SetAuthorOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetAuthorOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -470,7 +472,7 @@ func TestAuthors(t *testing.T) {
 			//Get History:
 
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s"}`, user.ApiKey, "english"))
-			authors, _ := requestAndReturnArray(jsonStr, GetAODHistory)
+			authors, _ := requestAndReturnArray(jsonStr, api.GetAODHistory)
 
 			if len(authors) == 0 {
 				t.Fatalf("Expected the history of AOD but got an empty list: %+v", authors)
@@ -509,7 +511,7 @@ func TestAuthors(t *testing.T) {
 			authorId := 666
 			date := "2021-06-04"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","aods": [{"id":%d, "date":"%s"}]}`, godUser.ApiKey, authorId, date))
-			_, response := requestAndReturnArray(jsonStr, SetAuthorOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetAuthorOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -518,7 +520,7 @@ func TestAuthors(t *testing.T) {
 
 			minimum := "2021-06-04"
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s", "minimum":"%s"}`, user.ApiKey, "english", minimum))
-			authors, _ := requestAndReturnArray(jsonStr, GetAODHistory)
+			authors, _ := requestAndReturnArray(jsonStr, api.GetAODHistory)
 
 			if len(authors) == 0 {
 				t.Fatalf("Expected the history of AOD but got an empty list: %+v", authors)
@@ -570,3 +572,156 @@ func TestAuthors(t *testing.T) {
 		handlers.Db.Exec("DELETE FROM aodice")
 	})
 }
+
+//TestAuthorsWithFixtures tests the authors by id, the list and their profiles against the fixtures
+func TestAuthorsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		t.Run("should return first authors alphabetically", func(t *testing.T) {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetAuthorsList, jsonBody{"apiKey": apiKey, "language": "english"}, &respObj)
+			if len(respObj) == 0 || respObj[0].Name != "Democritus" {
+				t.Fatalf("got %+v, want Democritus first", respObj)
+			}
+		})
+
+		t.Run("should count Icelandic quotes of the authors", func(t *testing.T) {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetAuthorsById, jsonBody{"apiKey": apiKey, "ids": []int{2}}, &respObj)
+			if len(respObj) != 1 || !respObj[0].HasIcelandicQuotes || respObj[0].NrOfIcelandicQuotes != 1 || respObj[0].NrOfEnglishQuotes != 3 {
+				t.Fatalf("got %+v, want Nietzsche with 3 English and 1 Icelandic quote", respObj)
+			}
+		})
+
+		t.Run("should set the author of the day only for authors with quotes in the language", func(t *testing.T) {
+			date := time.Now().Format("2006-01-02")
+			runStatusTests(t, []statusTest{
+				{"should refuse an author without Icelandic quotes", testApi.SetAuthorOfTheDay, jsonBody{"apiKey": godApiKey, "language": "icelandic", "aods": []jsonBody{{"id": 1, "date": date}}}, http.StatusBadRequest},
+				{"should set the Icelandic author", testApi.SetAuthorOfTheDay, jsonBody{"apiKey": godApiKey, "language": "icelandic", "aods": []jsonBody{{"id": 7, "date": date}}}, http.StatusOK},
+			})
+
+			var respObj structs.TestApiResponse
+			requestAndDecode(t, testApi.GetAuthorOfTheDay, jsonBody{"apiKey": apiKey, "language": "icelandic"}, &respObj)
+			if respObj.Name != "Hávamál" {
+				t.Fatalf("got AOD %+v, want Hávamál", respObj)
+			}
+		})
+	})
+}
+
+//TestEditAuthorsWithFixtures tests creating and updating authors against the fixtures
+func TestEditAuthorsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		authorRequest := func(fn httpRequest, body jsonBody) (structs.AuthorAPIModel, int) {
+			var author structs.AuthorAPIModel
+			statusCode := requestAndDecode(t, fn, body, &author)
+			return author, statusCode
+		}
+		getAuthors := func(ids ...int) []structs.AuthorAPIModel {
+			var authors []structs.AuthorAPIModel
+			requestAndDecode(t, testApi.GetAuthorsById, jsonBody{"apiKey": apiKey, "ids": ids}, &authors)
+			return authors
+		}
+
+		t.Run("should only let GOD-tier users create authors", func(t *testing.T) {
+			if _, statusCode := authorRequest(testApi.CreateAuthor, jsonBody{"apiKey": apiKey, "name": "Maya Angelou"}); statusCode != http.StatusUnauthorized {
+				t.Fatalf("got status code %d but expected %d", statusCode, http.StatusUnauthorized)
+			}
+		})
+
+		t.Run("should create an author and keep its counters up to date", func(t *testing.T) {
+			author, statusCode := authorRequest(testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Maya Angelou"})
+			if statusCode != http.StatusOK || author.Id == 0 || author.NrOfEnglishQuotes != 0 {
+				t.Fatalf("got status code %d and %+v, want a new author without quotes", statusCode, author)
+			}
+			if _, statusCode = authorRequest(testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Maya Angelou"}); statusCode != http.StatusConflict {
+				t.Fatalf("got status code %d for a taken name but expected %d", statusCode, http.StatusConflict)
+			}
+
+			var quote structs.QuoteAPIModel
+			requestAndDecode(t, testApi.CreateQuote, jsonBody{"apiKey": godApiKey, "quote": "Nothing will work unless you do.", "authorId": author.Id}, &quote)
+			if authors := getAuthors(author.Id); len(authors) != 1 || authors[0].NrOfEnglishQuotes != 1 {
+				t.Fatalf("got %+v, want the author with 1 English quote", authors)
+			}
+
+			requestAndDecode(t, testApi.DeleteItem, jsonBody{"apiKey": godApiKey, "type": "quotes", "id": quote.Id}, nil)
+			if authors := getAuthors(author.Id); len(authors) != 1 || authors[0].NrOfEnglishQuotes != 0 {
+				t.Fatalf("got %+v, want the author without quotes after the delete", authors)
+			}
+		})
+
+		t.Run("should tell that a name belongs to a deleted author", func(t *testing.T) {
+			requestAndDecode(t, testApi.DeleteItem, jsonBody{"apiKey": godApiKey, "type": "authors", "id": 6}, nil)
+			var errorResp structs.ErrorResponse
+			requestAndDecode(t, testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Joseph Stalin"}, &errorResp)
+			if errorResp.StatusCode != http.StatusConflict || !strings.Contains(errorResp.Message, "deleted author") {
+				t.Fatalf("got %+v, want a conflict that points to the deleted author", errorResp)
+			}
+			if _, statusCode := authorRequest(testApi.UpdateAuthor, jsonBody{"apiKey": godApiKey, "id": 4, "name": "Joseph Stalin"}); statusCode != http.StatusConflict {
+				t.Fatalf("got status code %d for the name of a deleted author but expected %d", statusCode, http.StatusConflict)
+			}
+		})
+
+		t.Run("should rename an author", func(t *testing.T) {
+			author, statusCode := authorRequest(testApi.UpdateAuthor, jsonBody{"apiKey": godApiKey, "id": 3, "name": "Democritus of Abdera"})
+			if statusCode != http.StatusOK || author.Name != "Democritus of Abdera" || author.NrOfEnglishQuotes != 1 {
+				t.Fatalf("got status code %d and %+v, want the renamed author with its quote", statusCode, author)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse a taken name", testApi.UpdateAuthor, jsonBody{"apiKey": godApiKey, "id": 3, "name": "Oscar Wilde"}, http.StatusConflict},
+				{"should refuse an unknown author", testApi.UpdateAuthor, jsonBody{"apiKey": godApiKey, "id": 9999, "name": "Nobody"}, http.StatusNotFound},
+			})
+		})
+
+		t.Run("should set the profile of an author", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse an invalid birth date", testApi.UpdateAuthorProfile, jsonBody{"apiKey": godApiKey, "id": 10, "birthDate": "1940-13-09"}, http.StatusBadRequest},
+				{"should refuse a death before the birth", testApi.UpdateAuthorProfile, jsonBody{"apiKey": godApiKey, "id": 10, "birthDate": "1980", "deathDate": "1940"}, http.StatusBadRequest},
+				{"should refuse a nationality that is not a country code", testApi.UpdateAuthorProfile, jsonBody{"apiKey": godApiKey, "id": 10, "nationality": "England"}, http.StatusBadRequest},
+				{"should refuse a link without a scheme", testApi.UpdateAuthorProfile, jsonBody{"apiKey": godApiKey, "id": 10, "links": []jsonBody{{"title": "Wikipedia", "url": "wikipedia.org"}}}, http.StatusBadRequest},
+			})
+
+			body := jsonBody{"id": 10, "birthDate": "1940-10-09", "deathDate": "1980-12-08", "nationality": "gb", "professions": []string{"Musician", "songwriter", "musician"},
+				"bio": "English singer and songwriter.", "links": []jsonBody{{"title": "Wikipedia", "url": "https://en.wikipedia.org/wiki/John_Lennon"}}}
+			body["apiKey"] = apiKey
+			if _, statusCode := authorRequest(testApi.UpdateAuthorProfile, body); statusCode != http.StatusUnauthorized {
+				t.Fatalf("got status code %d but expected %d", statusCode, http.StatusUnauthorized)
+			}
+			body["apiKey"] = godApiKey
+			author, statusCode := authorRequest(testApi.UpdateAuthorProfile, body)
+			if statusCode != http.StatusOK || author.Nationality != "GB" || len(author.Professions) != 2 || author.Professions[0] != "musician" || len(author.Links) != 1 || author.Name != "John Lennon" {
+				t.Fatalf("got status code %d and %+v, want John Lennon's profile", statusCode, author)
+			}
+
+			if authors := getAuthors(10); len(authors) != 1 || authors[0].BirthDate != "1940-10-09" || authors[0].Bio != "English singer and songwriter." {
+				t.Fatalf("got %+v, want the author with the profile", authors)
+			}
+		})
+
+		t.Run("should filter the list of authors by profession, nationality and year of birth", func(t *testing.T) {
+			for _, test := range []struct {
+				filters jsonBody
+				want    []string
+			}{
+				{jsonBody{"profession": "Philosopher"}, []string{"Democritus of Abdera", "Friedrich Nietzsche"}},
+				{jsonBody{"nationality": "is"}, []string{"Jónas Hallgrímsson"}},
+				{jsonBody{"bornBefore": 1900, "profession": "poet"}, []string{"Friedrich Nietzsche", "Jónas Hallgrímsson"}},
+				{jsonBody{"bornAfter": 1900}, []string{"John Lennon", "Muhammad Ali"}},
+			} {
+				var authors []structs.AuthorAPIModel
+				test.filters["apiKey"] = apiKey
+				requestAndDecode(t, testApi.GetAuthorsList, test.filters, &authors)
+				names := []string{}
+				for _, author := range authors {
+					names = append(names, author.Name)
+				}
+				if strings.Join(names, ", ") != strings.Join(test.want, ", ") {
+					t.Fatalf("got %v for %+v, want %v", names, test.filters, test.want)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// maxCompletions is how many authors, topics and quotes autocomplete returns at most, each
+const maxCompletions = 10
+
+// minPrefixLength is how many letters the longest word of the prefix has at least, a shorter prefix matches too many
+// words to rank them by popularity in time
+const minPrefixLength = 3
+
+// swagger:route POST /search/autocomplete SEARCH Autocomplete
+// Typeahead for a search box. Returns the most popular authors, topics and quote snippets with words starting with the prefix, a word of which
+// has at least 3 letters. Does not count against the hourly quota but each apiKey may only make so many requests per minute, see the Retry-After header
+// of the 429 response
+// responses:
+//	200: autocompleteResponse
+//  400: incorrectBodyStructureResponse
+//  429: tooManyRequestsResponse
+//  500: internalServerErrorResponse
+
+// Autocomplete handles POST requests to complete a prefix into the names of authors and topics and quote snippets
+func (api *Api) Autocomplete(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetUncountedRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+	if strings.TrimSpace(requestBody.SearchString) == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the prefix to complete as the searchString", StatusCode: http.StatusBadRequest})
+		return
+	}
+	if longestWordLength(requestBody.SearchString) < minPrefixLength {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("A word of the prefix should have at least %d letters", minPrefixLength), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	limit := requestBody.PageSize
+	if limit > maxCompletions {
+		limit = maxCompletions
+	}
+	completions, err := api.Completions.Complete(requestBody.SearchString, requestBody.Language, limit)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when completing %s in Autocomplete: %s", requestBody.SearchString, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(completions.ConvertToAPIModel())
+}
+
+// longestWordLength returns the number of letters and digits of the longest word of the text
+func longestWordLength(text string) int {
+	longest := 0
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) }) {
+		if length := utf8.RuneCountInString(word); length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
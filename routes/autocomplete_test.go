@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestAutocompleteWithFixtures tests the autocomplete of the search box against the fixtures
+func TestAutocompleteWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		complete := func(prefix string, pageSize int) (structs.AutocompleteAPIModel, int) {
+			var completions structs.AutocompleteAPIModel
+			statusCode := requestAndDecode(t, testApi.Autocomplete, jsonBody{"apiKey": apiKey, "searchString": prefix, "pageSize": pageSize}, &completions)
+			return completions, statusCode
+		}
+
+		t.Run("should complete the prefix into authors, topics and quotes", func(t *testing.T) {
+			completions, statusCode := complete("frie NIE", 0)
+			if statusCode != http.StatusOK || len(completions.Authors) != 1 || completions.Authors[0].Text != "Friedrich Nietzsche" {
+				t.Fatalf("got %+v with status code %d, want Friedrich Nietzsche", completions, statusCode)
+			}
+			completions, _ = complete("lov", 0)
+			if len(completions.Topics) != 1 || completions.Topics[0].Text != "love" {
+				t.Fatalf("got %+v, want the topic love", completions.Topics)
+			}
+			completions, _ = complete("apple", 0)
+			if len(completions.Quotes) != 1 || completions.Quotes[0].Id != 10 || !strings.HasSuffix(completions.Quotes[0].Text, "…") || len([]rune(completions.Quotes[0].Text)) > 81 {
+				t.Fatalf("got %+v, want a snippet of the quote with id 10", completions.Quotes)
+			}
+		})
+
+		t.Run("should return the most popular first and at most pageSize", func(t *testing.T) {
+			completions, _ := complete("lif", 2)
+			if len(completions.Quotes) != 2 || len(completions.Authors) > 2 {
+				t.Fatalf("got %+v, want at most 2 of each", completions)
+			}
+			quotes, _ := testApi.Quotes.GetQuotes(structs.Request{Ids: []int{completions.Quotes[0].Id, completions.Quotes[1].Id}})
+			counts := map[int]int{}
+			for _, quote := range quotes {
+				counts[quote.QuoteId] = quote.QuoteCount
+			}
+			if len(counts) != 2 || counts[completions.Quotes[0].Id] < counts[completions.Quotes[1].Id] {
+				t.Fatalf("got %+v with the counts %v, want the most popular quote first", completions.Quotes, counts)
+			}
+		})
+
+		t.Run("should not count against the hourly quota", func(t *testing.T) {
+			user, _ := testApi.Users.GetByApiKey(apiKey)
+			before, _ := testApi.RequestHistory.CountLastHour(user.Id)
+			complete("nie", 0)
+			if after, _ := testApi.RequestHistory.CountLastHour(user.Id); after != before {
+				t.Fatalf("got %d requests the past hour, want %d", after, before)
+			}
+			if _, statusCode := complete(" ", 0); statusCode != http.StatusBadRequest {
+				t.Fatalf("got status code %d for an empty prefix, want %d", statusCode, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("should refuse a prefix without a word of 3 letters", func(t *testing.T) {
+			if _, statusCode := complete("j l", 0); statusCode != http.StatusBadRequest {
+				t.Fatalf("got status code %d, want %d", statusCode, http.StatusBadRequest)
+			}
+			if completions, statusCode := complete("joh l", 0); statusCode != http.StatusOK || len(completions.Authors) != 1 {
+				t.Fatalf("got %+v with status code %d, want John Lennon", completions, statusCode)
+			}
+		})
+	})
+}
+
+//BenchmarkAutocomplete measures the autocomplete queries against the seeded database at DATABASE_URL, which should
+//stay well below the 20 ms target, e.g. go test ./routes -run '^$' -bench Autocomplete
+func BenchmarkAutocomplete(b *testing.B) {
+	if api == nil {
+		b.Skip("DATABASE_URL is not set, skipping benchmark against the seeded database")
+	}
+	prefixes := []string{"fri", "friedrich nie", "lov", "the", "mus", "ein"}
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		if _, err := api.Completions.Complete(prefixes[i%len(prefixes)], "", maxCompletions); err != nil {
+			b.Fatalf("got error %s when completing", err)
+		}
+	}
+	if perOp := time.Since(start) / time.Duration(b.N); perOp > 20*time.Millisecond {
+		b.Fatalf("took %s per completion, want below 20ms", perOp)
+	}
+}
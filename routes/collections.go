@@ -0,0 +1,377 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+	"github.com/google/uuid"
+)
+
+// swagger:route POST /favourites COLLECTIONS GetFavourites
+// Get your favourite quotes, the most recently added first
+// responses:
+//	200: searchViewsResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// GetFavourites handles POST requests to get a page of the user's favourite quotes
+func (api *Api) GetFavourites(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+
+	quotes, err := api.Collections.Favourites(user.Id, requestBody)
+	if err != nil {
+		writeCollectionError(rw, err, "GetFavourites")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToSearchViewsAPIModel(quotes))
+}
+
+// swagger:route POST /favourites/add COLLECTIONS AddFavourites
+// Add quotes to your favourites
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// AddFavourites handles POST requests to add the quotes with the given ids to the user's favourites
+func (api *Api) AddFavourites(rw http.ResponseWriter, r *http.Request) {
+	api.changeFavourites(rw, r, api.Collections.AddFavourites, "Successfully added the quotes to your favourites!", "AddFavourites")
+}
+
+// swagger:route POST /favourites/remove COLLECTIONS RemoveFavourites
+// Remove quotes from your favourites
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// RemoveFavourites handles POST requests to remove the quotes with the given ids from the user's favourites
+func (api *Api) RemoveFavourites(rw http.ResponseWriter, r *http.Request) {
+	api.changeFavourites(rw, r, api.Collections.RemoveFavourites, "Successfully removed the quotes from your favourites!", "RemoveFavourites")
+}
+
+func (api *Api) changeFavourites(rw http.ResponseWriter, r *http.Request, change func(userId int, quoteIds []int) error, message string, route string) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+	if len(requestBody.Ids) == 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the ids of the quotes", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	if err := change(user.Id, requestBody.Ids); err != nil {
+		writeCollectionError(rw, err, route)
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusOK})
+}
+
+// swagger:route POST /collections COLLECTIONS GetCollections
+// List your collections, without their quotes
+// responses:
+//	200: collectionsResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// GetCollections handles POST requests to list the user's collections
+func (api *Api) GetCollections(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+
+	collections, err := api.Collections.List(user.Id)
+	if err != nil {
+		writeCollectionError(rw, err, "GetCollections")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToCollectionsAPIModel(collections))
+}
+
+// swagger:route POST /collection COLLECTIONS GetCollection
+// Get one of your collections, by id or by its slug, or anyone's public collection by its slug, with its quotes in order.
+// The apiKey can be left out when getting a public collection by its slug
+// responses:
+//	200: collectionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  429: tooManyRequestsResponse
+//  500: internalServerErrorResponse
+
+// GetCollection handles POST requests to get a collection and its quotes
+func (api *Api) GetCollection(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetPublicRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+	if requestBody.Id <= 0 && requestBody.Slug == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id or the slug of the collection", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	//Without an apiKey the request is anonymous and only sees the public quotes of public collections
+	var user structs.UserDBModel
+	if requestBody.ApiKey != "" {
+		var err error
+		if user, err = api.Users.GetByApiKey(requestBody.ApiKey); err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			log.Printf("Got error when getting the user with the request's apiKey: %s", err)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+			return
+		}
+	}
+
+	collection, err := api.Collections.Get(requestBody.Id, requestBody.Slug)
+	//Only the owner reads a collection by its id, other users read it by its slug and only when it is public
+	if err == nil && collection.UserId != user.Id && (requestBody.Id > 0 || !collection.IsPublic) {
+		err = repository.ErrNotFound
+	}
+	if err != nil {
+		writeCollectionError(rw, err, "GetCollection")
+		return
+	}
+
+	quotes, err := api.Collections.Quotes(collection.Id, user.Id)
+	if err != nil {
+		writeCollectionError(rw, err, "GetCollection")
+		return
+	}
+	collectionAPI := collection.ConvertToAPIModel()
+	collectionAPI.Quotes = structs.ConvertToCollectionQuotesAPIModel(quotes)
+	json.NewEncoder(rw).Encode(collectionAPI)
+}
+
+// swagger:route POST /collections/new COLLECTIONS CreateCollection
+// Create a named collection of quotes, a public one can be read by anyone through its slug
+// responses:
+//	200: collectionResponse
+//  400: incorrectBodyStructureResponse
+//  409: collectionExistsResponse
+//  500: internalServerErrorResponse
+
+// CreateCollection handles POST requests to create a collection
+func (api *Api) CreateCollection(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+	if requestBody.Name = strings.TrimSpace(requestBody.Name); requestBody.Name == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the name of the collection", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	collection := structs.CollectionDBModel{UserId: user.Id, Name: requestBody.Name, Slug: newSlug(requestBody.Name), IsPublic: requestBody.Public}
+	if err := api.Collections.Create(&collection); err != nil {
+		writeCollectionError(rw, err, "CreateCollection")
+		return
+	}
+	json.NewEncoder(rw).Encode(collection.ConvertToAPIModel())
+}
+
+// swagger:route POST /collections/update COLLECTIONS UpdateCollection
+// Rename your collection and / or make it public or private
+// responses:
+//	200: collectionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: collectionExistsResponse
+//  500: internalServerErrorResponse
+
+// UpdateCollection handles POST requests to update the name and visibility of a collection
+func (api *Api) UpdateCollection(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil || !validCollectionId(rw, requestBody) {
+		return
+	}
+
+	collection, err := api.Collections.Get(requestBody.Id, "")
+	if err == nil && collection.UserId != user.Id {
+		err = repository.ErrNotFound
+	}
+	if err != nil {
+		writeCollectionError(rw, err, "UpdateCollection")
+		return
+	}
+
+	//The name is kept if none is given
+	if name := strings.TrimSpace(requestBody.Name); name != "" {
+		collection.Name = name
+	}
+	collection.IsPublic = requestBody.Public
+	collection, err = api.Collections.Update(collection)
+	if err != nil {
+		writeCollectionError(rw, err, "UpdateCollection")
+		return
+	}
+	json.NewEncoder(rw).Encode(collection.ConvertToAPIModel())
+}
+
+// swagger:route POST /collections/delete COLLECTIONS DeleteCollection
+// Delete your collection
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// DeleteCollection handles POST requests to delete a collection
+func (api *Api) DeleteCollection(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil || !validCollectionId(rw, requestBody) {
+		return
+	}
+
+	if err := api.Collections.Delete(user.Id, requestBody.Id); err != nil {
+		writeCollectionError(rw, err, "DeleteCollection")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Successfully deleted the collection!", StatusCode: http.StatusOK})
+}
+
+// swagger:route POST /collections/quotes/set COLLECTIONS SetCollectionQuote
+// Add a quote to the end of your collection, with a note, or change the note of a quote already in it
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// SetCollectionQuote handles POST requests to add a quote to a collection or update its note
+func (api *Api) SetCollectionQuote(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil || !validCollectionId(rw, requestBody) {
+		return
+	}
+	if requestBody.QuoteId <= 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the quoteId of the quote", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	if err := api.Collections.SetQuote(user.Id, requestBody.Id, requestBody.QuoteId, strings.TrimSpace(requestBody.Note)); err != nil {
+		writeCollectionError(rw, err, "SetCollectionQuote")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Successfully saved the quote in the collection!", StatusCode: http.StatusOK})
+}
+
+// swagger:route POST /collections/quotes/remove COLLECTIONS RemoveCollectionQuotes
+// Remove quotes from your collection
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// RemoveCollectionQuotes handles POST requests to remove the quotes with the given ids from a collection
+func (api *Api) RemoveCollectionQuotes(rw http.ResponseWriter, r *http.Request) {
+	api.changeCollectionQuotes(rw, r, api.Collections.RemoveQuotes, "Successfully removed the quotes from the collection!", "RemoveCollectionQuotes")
+}
+
+// swagger:route POST /collections/quotes/order COLLECTIONS ReorderCollection
+// Move the given quotes, in the given order, to the front of your collection
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// ReorderCollection handles POST requests to change the order of the quotes in a collection
+func (api *Api) ReorderCollection(rw http.ResponseWriter, r *http.Request) {
+	api.changeCollectionQuotes(rw, r, api.Collections.Reorder, "Successfully reordered the collection!", "ReorderCollection")
+}
+
+func (api *Api) changeCollectionQuotes(rw http.ResponseWriter, r *http.Request, change func(userId int, collectionId int, quoteIds []int) error, message string, route string) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil || !validCollectionId(rw, requestBody) {
+		return
+	}
+	if len(requestBody.Ids) == 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the ids of the quotes", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	if err := change(user.Id, requestBody.Id, requestBody.Ids); err != nil {
+		writeCollectionError(rw, err, route)
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusOK})
+}
+
+// getRequestUser validates the request body and returns the user with the request's apiKey
+func (api *Api) getRequestUser(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) (structs.UserDBModel, error) {
+	if err := handlers.GetRequestBody(rw, r, requestBody, api.Repositories); err != nil {
+		return structs.UserDBModel{}, err
+	}
+	user, err := api.Users.GetByApiKey(requestBody.ApiKey)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when getting the user with the request's apiKey: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	}
+	return user, err
+}
+
+func validCollectionId(rw http.ResponseWriter, requestBody structs.Request) bool {
+	if requestBody.Id > 0 {
+		return true
+	}
+	rw.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the collection", StatusCode: http.StatusBadRequest})
+	return false
+}
+
+func writeCollectionError(rw http.ResponseWriter, err error, route string) {
+	switch {
+	case errors.Is(err, repository.ErrCollectionExists):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "You already have a collection with this name", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The collection, or some of the quotes, does not exist", StatusCode: http.StatusNotFound})
+	default:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when querying DB in %s: %s", route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	}
+}
+
+// newSlug returns a unique slug for the collection, e.g. wedding-toasts-1d8db1d2
+func newSlug(name string) string {
+	var slug strings.Builder
+	dash := false
+	for _, char := range strings.ToLower(name) {
+		if (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') {
+			slug.WriteRune(char)
+			dash = false
+		} else if !dash && slug.Len() > 0 {
+			slug.WriteRune('-')
+			dash = true
+		}
+	}
+	suffix, _ := uuid.NewRandom()
+	return strings.TrimSuffix(slug.String(), "-") + "-" + suffix.String()[:8]
+}
@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestCollectionsWithFixtures tests the favourites and the collections of the users against the fixtures
+func TestCollectionsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		favourites := func(apiKey string) []structs.SearchViewAPIModel {
+			var quotes []structs.SearchViewAPIModel
+			requestAndDecode(t, testApi.GetFavourites, jsonBody{"apiKey": apiKey}, &quotes)
+			return quotes
+		}
+		getCollection := func(body jsonBody) (structs.CollectionAPIModel, int) {
+			var collection structs.CollectionAPIModel
+			statusCode := requestAndDecode(t, testApi.GetCollection, body, &collection)
+			return collection, statusCode
+		}
+
+		t.Run("should add and remove favourites, the most recent first", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should add the favourites", testApi.AddFavourites, jsonBody{"apiKey": apiKey, "ids": []int{1, 3}}, http.StatusOK},
+				{"should refuse an unknown quote", testApi.AddFavourites, jsonBody{"apiKey": apiKey, "ids": []int{9999}}, http.StatusNotFound},
+			})
+			if quotes := favourites(apiKey); len(quotes) != 2 || quotes[0].QuoteId != 3 || quotes[1].QuoteId != 1 {
+				t.Fatalf("got %+v, want the favourites 3 and 1", quotes)
+			}
+			if quotes := favourites(godApiKey); len(quotes) != 0 {
+				t.Fatalf("got %+v, want no favourites for another user", quotes)
+			}
+
+			requestAndDecode(t, testApi.RemoveFavourites, jsonBody{"apiKey": apiKey, "ids": []int{3}}, nil)
+			if quotes := favourites(apiKey); len(quotes) != 1 || quotes[0].QuoteId != 1 {
+				t.Fatalf("got %+v, want only the favourite 1 left", quotes)
+			}
+		})
+
+		t.Run("should create a collection, fill and reorder it", func(t *testing.T) {
+			var collection structs.CollectionAPIModel
+			statusCode := requestAndDecode(t, testApi.CreateCollection, jsonBody{"apiKey": apiKey, "name": "Wedding toasts"}, &collection)
+			if statusCode != http.StatusOK || collection.Id == 0 || !strings.HasPrefix(collection.Slug, "wedding-toasts-") {
+				t.Fatalf("got status code %d and %+v, want a new collection with a slug", statusCode, collection)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse a taken name", testApi.CreateCollection, jsonBody{"apiKey": apiKey, "name": "Wedding toasts"}, http.StatusConflict},
+				{"should add quote 1", testApi.SetCollectionQuote, jsonBody{"apiKey": apiKey, "id": collection.Id, "quoteId": 1}, http.StatusOK},
+				{"should add quote 2", testApi.SetCollectionQuote, jsonBody{"apiKey": apiKey, "id": collection.Id, "quoteId": 2}, http.StatusOK},
+				{"should add quote 3", testApi.SetCollectionQuote, jsonBody{"apiKey": apiKey, "id": collection.Id, "quoteId": 3}, http.StatusOK},
+				{"should set the note of quote 2", testApi.SetCollectionQuote, jsonBody{"apiKey": apiKey, "id": collection.Id, "quoteId": 2, "note": "For the speech"}, http.StatusOK},
+				{"should move quote 3 to the front", testApi.ReorderCollection, jsonBody{"apiKey": apiKey, "id": collection.Id, "ids": []int{3}}, http.StatusOK},
+			})
+
+			collection, statusCode = getCollection(jsonBody{"apiKey": apiKey, "id": collection.Id})
+			if statusCode != http.StatusOK || collection.NrOfQuotes != 3 || len(collection.Quotes) != 3 {
+				t.Fatalf("got status code %d and %+v, want the collection with 3 quotes", statusCode, collection)
+			}
+			if collection.Quotes[0].QuoteId != 3 || collection.Quotes[1].QuoteId != 1 || collection.Quotes[2].QuoteId != 2 || collection.Quotes[2].Note != "For the speech" {
+				t.Fatalf("got %+v, want the quotes in the order 3, 1, 2 with the note on 2", collection.Quotes)
+			}
+
+			requestAndDecode(t, testApi.RemoveCollectionQuotes, jsonBody{"apiKey": apiKey, "id": collection.Id, "ids": []int{1}}, nil)
+			if collection, _ = getCollection(jsonBody{"apiKey": apiKey, "id": collection.Id}); len(collection.Quotes) != 2 {
+				t.Fatalf("got %+v, want 2 quotes left", collection.Quotes)
+			}
+		})
+
+		t.Run("should only share a collection by its slug once it is public", func(t *testing.T) {
+			var collection structs.CollectionAPIModel
+			requestAndDecode(t, testApi.CreateCollection, jsonBody{"apiKey": apiKey, "name": "Shared"}, &collection)
+			runStatusTests(t, []statusTest{
+				{"should hide another user's private collection", testApi.GetCollection, jsonBody{"apiKey": godApiKey, "slug": collection.Slug}, http.StatusNotFound},
+				{"should hide a private collection without an apiKey", testApi.GetCollection, jsonBody{"slug": collection.Slug}, http.StatusNotFound},
+				{"should not update another user's collection", testApi.UpdateCollection, jsonBody{"apiKey": godApiKey, "id": collection.Id, "public": true}, http.StatusNotFound},
+			})
+
+			var updated structs.CollectionAPIModel
+			statusCode := requestAndDecode(t, testApi.UpdateCollection, jsonBody{"apiKey": apiKey, "id": collection.Id, "public": true}, &updated)
+			if statusCode != http.StatusOK || !updated.IsPublic || updated.Name != "Shared" {
+				t.Fatalf("got status code %d and %+v, want the public collection with its name kept", statusCode, updated)
+			}
+			for _, body := range []jsonBody{{"apiKey": godApiKey, "slug": collection.Slug}, {"slug": collection.Slug}} {
+				if shared, statusCode := getCollection(body); statusCode != http.StatusOK || shared.Id != collection.Id {
+					t.Fatalf("got status code %d and %+v for %+v, want the shared collection", statusCode, shared, body)
+				}
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should hide another user's public collection by its id", testApi.GetCollection, jsonBody{"apiKey": godApiKey, "id": collection.Id}, http.StatusNotFound},
+				{"should hide a collection by its id without an apiKey", testApi.GetCollection, jsonBody{"id": collection.Id}, http.StatusNotFound},
+				{"should delete the collection", testApi.DeleteCollection, jsonBody{"apiKey": apiKey, "id": collection.Id}, http.StatusOK},
+				{"should hide a deleted collection", testApi.GetCollection, jsonBody{"apiKey": godApiKey, "slug": collection.Slug}, http.StatusNotFound},
+			})
+		})
+	})
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// swagger:route POST /delete DELETED DeleteItem
+// Soft delete an author, quote or topic, i.e. hide it from all the other routes (is password protected)
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// DeleteItem handles POST requests to soft delete the author, quote or topic with the given id
+func (api *Api) DeleteItem(rw http.ResponseWriter, r *http.Request) {
+	api.setDeleted(rw, r, api.Deleted.Delete, "deleted")
+}
+
+// swagger:route POST /restore DELETED RestoreItem
+// Restore a soft deleted author, quote or topic (is password protected). A quote can not be restored while another
+// quote with the same text has taken its place
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: quoteExistsResponse
+//  500: internalServerErrorResponse
+
+// RestoreItem handles POST requests to restore the deleted author, quote or topic with the given id
+func (api *Api) RestoreItem(rw http.ResponseWriter, r *http.Request) {
+	api.setDeleted(rw, r, api.Deleted.Restore, "restored")
+}
+
+// swagger:route POST /deleted DELETED ListDeleted
+// List the soft deleted authors, quotes or topics, most recently deleted first (is password protected)
+// responses:
+//	200: deletedResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// ListDeleted handles POST requests to list the deleted authors, quotes or topics
+func (api *Api) ListDeleted(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getDeletedRequestBody(rw, r, &requestBody); err != nil {
+		return
+	}
+
+	deleted, err := api.Deleted.List(requestBody.Type, requestBody)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when listing the deleted %s: %s", requestBody.Type, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	json.NewEncoder(rw).Encode(structs.ConvertToDeletedAPIModel(deleted))
+}
+
+//setDeleted deletes or restores the row and refreshes the views right away so that the change is seen everywhere
+func (api *Api) setDeleted(rw http.ResponseWriter, r *http.Request, action func(table string, id int) error, done string) {
+	var requestBody structs.Request
+	if err := api.getDeletedRequestBody(rw, r, &requestBody); err != nil {
+		return
+	}
+
+	if requestBody.Id <= 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the " + requestBody.Type, StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	if err := action(requestBody.Type, requestBody.Id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			rw.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No %s with id %d that can be %s", requestBody.Type, requestBody.Id, done), StatusCode: http.StatusNotFound})
+			return
+		}
+		if errors.Is(err, repository.ErrQuoteExists) {
+			rw.WriteHeader(http.StatusConflict)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("Another quote with the same text has taken the place of the quote with id %d", requestBody.Id), StatusCode: http.StatusConflict})
+			return
+		}
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when setting %s %d as %s: %s", requestBody.Type, requestBody.Id, done, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	message := fmt.Sprintf("Successfully %s the %s with id %d!", done, requestBody.Type, requestBody.Id)
+	if !api.refreshNow(repository.MaterializedViews...) {
+		message += " The search results will reflect it after the next refresh of the views."
+	}
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusOK})
+}
+
+//getDeletedRequestBody authorizes the GOD-tier user and validates the type, i.e. authors, quotes or topics
+func (api *Api) getDeletedRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) error {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return err
+	}
+	if err := handlers.GetRequestBody(rw, r, requestBody, api.Repositories); err != nil {
+		return err
+	}
+
+	requestBody.Type = strings.ToLower(requestBody.Type)
+	for _, table := range repository.DeletableTables {
+		if requestBody.Type == table {
+			return nil
+		}
+	}
+	err := errors.New("the type should be one of authors, quotes or topics")
+	rw.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+	return err
+}
@@ -0,0 +1,86 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestDeletedWithFixtures tests soft deleting and restoring authors, quotes and topics against the fixtures
+func TestDeletedWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		deleteQuote := jsonBody{"apiKey": godApiKey, "type": "quotes", "id": 1}
+
+		t.Run("should only delete known types as GOD", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.DeleteItem, jsonBody{"apiKey": apiKey, "type": "quotes", "id": 1}, http.StatusUnauthorized},
+				{"should refuse an unknown type", testApi.DeleteItem, jsonBody{"apiKey": godApiKey, "type": "users", "id": 1}, http.StatusBadRequest},
+			})
+		})
+
+		t.Run("should hide a deleted quote, list it and restore it", func(t *testing.T) {
+			if statusCode := requestAndDecode(t, testApi.DeleteItem, deleteQuote, nil); statusCode != http.StatusOK {
+				t.Fatalf("got status code %d but expected %d", statusCode, http.StatusOK)
+			}
+
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": []int{1}}, &respObj)
+			if len(respObj) != 0 {
+				t.Fatalf("got %+v, want the deleted quote to be hidden", respObj)
+			}
+			requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "butterfly"}, &respObj)
+			if len(respObj) != 0 {
+				t.Fatalf("got %+v, want the deleted quote to be hidden from search", respObj)
+			}
+
+			var deleted []structs.DeletedAPIModel
+			requestAndDecode(t, testApi.ListDeleted, jsonBody{"apiKey": godApiKey, "type": "quotes"}, &deleted)
+			if len(deleted) != 1 || deleted[0].Id != 1 || deleted[0].AuthorId != 1 {
+				t.Fatalf("got %+v, want only the quote with id 1", deleted)
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should restore the quote", testApi.RestoreItem, deleteQuote, http.StatusOK},
+				{"should not restore it twice", testApi.RestoreItem, deleteQuote, http.StatusNotFound},
+			})
+
+			requestAndDecode(t, testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": []int{1}}, &respObj)
+			if len(respObj) != 1 {
+				t.Fatalf("got %+v, want the restored quote", respObj)
+			}
+		})
+
+		t.Run("should recount the topics and the author when deleting and restoring an author", func(t *testing.T) {
+			topicCounts := func() map[int]int {
+				var topics []structs.TopicAPIModel
+				requestAndDecode(t, testApi.GetTopics, jsonBody{"apiKey": apiKey}, &topics)
+				counts := map[int]int{}
+				for _, topic := range topics {
+					counts[topic.Id] = topic.NrOfQuotes
+				}
+				return counts
+			}
+			deleteAuthor := jsonBody{"apiKey": godApiKey, "type": "authors", "id": 7}
+
+			//Author 7 has the Icelandic quotes 14 and 15, which are in topic 5 along with quote 7
+			before := topicCounts()
+			runStatusTests(t, []statusTest{{"should delete the author", testApi.DeleteItem, deleteAuthor, http.StatusOK}})
+			if after := topicCounts(); after[5] != before[5]-2 {
+				t.Fatalf("got %d quotes in topic 5 after deleting their author, want %d", after[5], before[5]-2)
+			}
+
+			runStatusTests(t, []statusTest{{"should restore the author", testApi.RestoreItem, deleteAuthor, http.StatusOK}})
+			if after := topicCounts(); after[5] != before[5] {
+				t.Fatalf("got %d quotes in topic 5 after restoring their author, want %d", after[5], before[5])
+			}
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetAuthorsById, jsonBody{"apiKey": apiKey, "ids": []int{7}}, &respObj)
+			if len(respObj) != 1 || !respObj[0].HasIcelandicQuotes {
+				t.Fatalf("got %+v, want the restored author with its Icelandic quotes", respObj)
+			}
+		})
+	})
+}
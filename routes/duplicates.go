@@ -0,0 +1,124 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// defaultDuplicateSimilarity is the trigram similarity of the normalized quotes from which they count as near duplicates
+const defaultDuplicateSimilarity = 0.7
+
+// maxSimilarQuotes is how many similar quotes are listed in the warning for a new quote
+const maxSimilarQuotes = 5
+
+// swagger:route POST /duplicates DUPLICATES ListDuplicates
+// List the clusters of near duplicate quotes, i.e. quotes that only differ by punctuation, case, a trailing attribution or a few characters (is password protected)
+// responses:
+//	200: duplicatesResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// ListDuplicates handles POST requests to list a page of the clusters of near duplicate quotes
+func (api *Api) ListDuplicates(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getDuplicatesRequestBody(rw, r, &requestBody); err != nil {
+		return
+	}
+	if requestBody.Similarity == 0 {
+		requestBody.Similarity = defaultDuplicateSimilarity
+	}
+	if requestBody.Similarity < repository.MinDuplicateSimilarity || requestBody.Similarity > 1 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("The similarity should be between %g and 1", repository.MinDuplicateSimilarity), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	clusters, err := api.Duplicates.Clusters(requestBody.Similarity, requestBody)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when listing the duplicates: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToDuplicateClustersAPIModel(clusters))
+}
+
+// swagger:route POST /duplicates/merge DUPLICATES MergeDuplicates
+// Merge near duplicate quotes into the surviving quote, which gets their topics, popularity, favourites, places in collections, days as the quote of the day and citation, the duplicates are soft deleted (is password protected)
+// responses:
+//	200: quoteResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// MergeDuplicates handles POST requests to merge the quotes with the given ids into the quote with the given id
+func (api *Api) MergeDuplicates(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getDuplicatesRequestBody(rw, r, &requestBody); err != nil {
+		return
+	}
+	if requestBody.Id <= 0 || len(requestBody.Ids) == 0 || containsInt(requestBody.Ids, requestBody.Id) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the surviving quote and the ids of its duplicates", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	survivor, err := api.Duplicates.Merge(requestBody.Id, requestBody.Ids)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Some of the quotes do not exist, are deleted or are private", StatusCode: http.StatusNotFound})
+		return
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when merging %v into the quote %d: %s", requestBody.Ids, requestBody.Id, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	api.refreshNow(repository.MaterializedViews...)
+	json.NewEncoder(rw).Encode(survivor.ConvertToAPIModel())
+}
+
+func (api *Api) getDuplicatesRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) error {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return err
+	}
+	return handlers.GetRequestBody(rw, r, requestBody, api.Repositories)
+}
+
+// similarQuotes returns the quotes visible to the user that are near duplicates of the quote, other than the quote
+// itself, as a warning. It is only a warning so errors are logged and left out
+func (api *Api) similarQuotes(quote string, userId int, quoteId int) []structs.SimilarQuoteAPIModel {
+	similar, err := api.Duplicates.Similar(quote, userId, defaultDuplicateSimilarity, maxSimilarQuotes+1)
+	if err != nil {
+		log.Printf("Got error when looking for quotes similar to %q: %s", quote, err)
+		return nil
+	}
+	others := []structs.SimilarQuoteDBModel{}
+	for _, row := range similar {
+		if row.QuoteId != quoteId && len(others) < maxSimilarQuotes {
+			others = append(others, row)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return structs.ConvertToSimilarQuotesAPIModel(others)
+}
+
+func containsInt(list []int, item int) bool {
+	for _, listItem := range list {
+		if listItem == item {
+			return true
+		}
+	}
+	return false
+}
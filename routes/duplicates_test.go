@@ -0,0 +1,110 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestDuplicatesWithFixtures tests the near duplicates report and the merge of duplicates against the fixtures
+func TestDuplicatesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		var duplicate int
+
+		createQuote := func(body jsonBody) (structs.CreatedQuoteAPIModel, int) {
+			var quote structs.CreatedQuoteAPIModel
+			statusCode := requestAndDecode(t, testApi.CreateQuote, body, &quote)
+			return quote, statusCode
+		}
+		duplicates := func() []structs.DuplicateClusterAPIModel {
+			var clusters []structs.DuplicateClusterAPIModel
+			requestAndDecode(t, testApi.ListDuplicates, jsonBody{"apiKey": godApiKey}, &clusters)
+			return clusters
+		}
+
+		t.Run("should refuse a quote that only differs by punctuation and attribution", func(t *testing.T) {
+			_, statusCode := createQuote(jsonBody{"apiKey": godApiKey, "quote": "“Float like a butterfly – sting like a bee!” — Muhammad Ali", "authorId": 1})
+			if statusCode != http.StatusConflict {
+				t.Fatalf("got status code %d for a normalized duplicate but expected %d", statusCode, http.StatusConflict)
+			}
+		})
+
+		t.Run("should warn about a similar quote and list them as a cluster", func(t *testing.T) {
+			if clusters := duplicates(); len(clusters) != 0 {
+				t.Fatalf("got %+v, want no clusters in the fixtures", clusters)
+			}
+
+			quote, statusCode := createQuote(jsonBody{"apiKey": godApiKey, "quote": "Float like a butterfly and sting like a bee.", "authorId": 1})
+			if statusCode != http.StatusOK || quote.Id == 0 || len(quote.SimilarQuotes) != 1 || quote.SimilarQuotes[0].QuoteId != 1 {
+				t.Fatalf("got status code %d and %+v, want the new quote with quote 1 as a similar quote", statusCode, quote)
+			}
+			duplicate = quote.Id
+			refreshViews(t, testApi)
+
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.ListDuplicates, jsonBody{"apiKey": apiKey}, http.StatusUnauthorized},
+				{"should refuse a similarity below the pairs that are recorded", testApi.ListDuplicates, jsonBody{"apiKey": godApiKey, "similarity": 0.3}, http.StatusBadRequest},
+			})
+			if clusters := duplicates(); len(clusters) != 1 || len(clusters[0].Quotes) != 2 || clusters[0].Quotes[0].QuoteId != 1 || clusters[0].Quotes[1].QuoteId != quote.Id {
+				t.Fatalf("got %+v, want the cluster of quote 1 and the new quote, the popular one first", clusters)
+			}
+		})
+
+		t.Run("should list a restored quote in its cluster again", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should delete the duplicate", testApi.DeleteItem, jsonBody{"apiKey": godApiKey, "type": "quotes", "id": duplicate}, http.StatusOK},
+				{"should restore the duplicate", testApi.RestoreItem, jsonBody{"apiKey": godApiKey, "type": "quotes", "id": duplicate}, http.StatusOK},
+			})
+			if clusters := duplicates(); len(clusters) != 1 || len(clusters[0].Quotes) != 2 || clusters[0].Quotes[1].QuoteId != duplicate {
+				t.Fatalf("got %+v, want the cluster of quote 1 and the restored quote", clusters)
+			}
+		})
+
+		t.Run("should merge the duplicates into the survivor", func(t *testing.T) {
+			testApi.Quotes.IncrementCount([]int{duplicate}, 25)
+			requestAndDecode(t, testApi.AddFavourites, jsonBody{"apiKey": apiKey, "ids": []int{duplicate}}, nil)
+
+			//The duplicate is the quote of the day and has the citation, quote 1 has neither
+			var source structs.SourceAPIModel
+			requestAndDecode(t, testApi.CreateSource, jsonBody{"apiKey": godApiKey, "title": "The Greatest", "type": "book"}, &source)
+			runStatusTests(t, []statusTest{
+				{"should cite the duplicate", testApi.CiteQuote, jsonBody{"apiKey": godApiKey, "quoteId": duplicate, "sourceId": source.Id, "status": "verified"}, http.StatusOK},
+				{"should set the duplicate as the quote of the day", testApi.SetQuoteOfTheDay, jsonBody{"apiKey": godApiKey, "qods": []jsonBody{{"id": duplicate}}}, http.StatusOK},
+				{"should refuse merging a quote into itself", testApi.MergeDuplicates, jsonBody{"apiKey": godApiKey, "id": 1, "ids": []int{1}}, http.StatusBadRequest},
+			})
+
+			var survivor structs.QuoteAPIModel
+			statusCode := requestAndDecode(t, testApi.MergeDuplicates, jsonBody{"apiKey": godApiKey, "id": 1, "ids": []int{duplicate}}, &survivor)
+			if statusCode != http.StatusOK || survivor.Id != 1 || survivor.Count != 525 {
+				t.Fatalf("got status code %d and %+v, want quote 1 with the popularity of both", statusCode, survivor)
+			}
+
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": []int{duplicate}}, &respObj)
+			if len(respObj) != 0 {
+				t.Fatalf("got %+v, want the duplicate deleted", respObj)
+			}
+			requestAndDecode(t, testApi.GetFavourites, jsonBody{"apiKey": apiKey}, &respObj)
+			if len(respObj) != 1 || respObj[0].QuoteId != 1 {
+				t.Fatalf("got %+v, want the favourite moved to the survivor", respObj)
+			}
+			if clusters := duplicates(); len(clusters) != 0 {
+				t.Fatalf("got %+v, want no clusters after the merge", clusters)
+			}
+
+			var quotes []structs.SearchViewAPIModel
+			requestAndDecode(t, testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": []int{1}, "withSource": true}, &quotes)
+			if len(quotes) != 1 || quotes[0].Source == nil || quotes[0].Source.Id != source.Id || quotes[0].Source.Status != structs.SourceVerified {
+				t.Fatalf("got %+v, want the citation moved to the survivor", quotes)
+			}
+			var qod structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuoteOfTheDay, jsonBody{"apiKey": apiKey}, &qod)
+			if qod.QuoteId != 1 {
+				t.Fatalf("got QOD %+v, want the survivor in place of the duplicate", qod)
+			}
+		})
+	})
+}
@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//The formats the corpus can be exported in
+const (
+	exportFormatNDJSON = "ndjson"
+	exportFormatCSV    = "csv"
+)
+
+//exportFlushEvery is how many rows are written before flushing them to the client
+const exportFlushEvery = 500
+
+// swagger:route POST /export EXPORT ExportQuotes
+// Export the authors, the topics and the quotes (with their authors and optionally topics) as NDJSON or CSV. An NDJSON export
+// has the records of every type, told apart by their type field, unless a type is given, a CSV export the records of one
+// type, the quotes if none is given.
+// The time of the snapshot the records are read from is in the X-Snapshot-Timestamp header. Needs the lilleBoy tier or higher.
+// responses:
+//	200: exportResponse
+//  400: incorrectBodyStructureResponse
+//  401: unauthorizedResponse
+//  500: internalServerErrorResponse
+
+// ExportQuotes handles POST requests to stream the whole corpus, filtered by language and topic
+func (api *Api) ExportQuotes(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeTier(rw, r, api.Repositories, handlers.EXPORT_TIER); err != nil {
+		return
+	}
+
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	format := strings.ToLower(requestBody.Format)
+	if format == "" {
+		format = exportFormatNDJSON
+	}
+	if format != exportFormatNDJSON && format != exportFormatCSV {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The format should be either ndjson or csv", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	recordType := strings.ToLower(requestBody.Type)
+	if recordType == "" && format == exportFormatCSV {
+		recordType = structs.ExportRecordQuote
+	}
+	if recordType != "" && recordType != structs.ExportRecordAuthor && recordType != structs.ExportRecordTopic && recordType != structs.ExportRecordQuote {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The type should be either author, topic or quote", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	withTopics := repository.ExportsTopics(requestBody)
+	flusher, _ := rw.(http.Flusher)
+	encoder := json.NewEncoder(rw)
+	csvWriter := csv.NewWriter(rw)
+	started := false
+	nrOfRows := 0
+
+	var csvHeader []string
+	switch recordType {
+	case structs.ExportRecordAuthor:
+		csvHeader = structs.ExportAuthorCSVHeader()
+	case structs.ExportRecordTopic:
+		csvHeader = structs.ExportTopicCSVHeader()
+	default:
+		csvHeader = structs.ExportCSVHeader(withTopics)
+	}
+
+	begin := func(snapshot time.Time) error {
+		started = true
+		rw.Header().Set("X-Snapshot-Timestamp", snapshot.UTC().Format(time.RFC3339Nano))
+		name := "quotes"
+		if recordType == structs.ExportRecordAuthor || recordType == structs.ExportRecordTopic {
+			name = recordType + "s"
+		}
+		fileName := name + "-" + snapshot.UTC().Format("20060102T150405Z") + "." + format
+		rw.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+		if format == exportFormatCSV {
+			rw.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			return csvWriter.Write(csvHeader)
+		}
+		rw.Header().Set("Content-Type", "application/x-ndjson")
+		return nil
+	}
+
+	//write writes the record as a line of NDJSON or, with the csvRecord, of CSV
+	write := func(record interface{}, csvRecord []string) error {
+		var err error
+		if format == exportFormatCSV {
+			err = csvWriter.Write(csvRecord)
+		} else {
+			err = encoder.Encode(record)
+		}
+		if err != nil {
+			return err
+		}
+
+		nrOfRows++
+		if nrOfRows%exportFlushEvery == 0 {
+			csvWriter.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return nil
+	}
+
+	callbacks := repository.ExportCallbacks{Begin: begin}
+	if recordType == "" || recordType == structs.ExportRecordAuthor {
+		callbacks.Author = func(dbAuthor structs.AuthorDBModel) error {
+			author := dbAuthor.ConvertToExportAuthorAPIModel()
+			return write(author, author.CSVRecord())
+		}
+	}
+	if recordType == "" || recordType == structs.ExportRecordTopic {
+		callbacks.Topic = func(dbTopic structs.TopicDBModel) error {
+			topic := dbTopic.ConvertToExportTopicAPIModel()
+			return write(topic, topic.CSVRecord())
+		}
+	}
+	if recordType == "" || recordType == structs.ExportRecordQuote {
+		callbacks.Quote = func(dbRow structs.TopicViewDBModel) error {
+			row := dbRow.ConvertToExportRowAPIModel()
+			return write(row, row.CSVRecord(withTopics))
+		}
+	}
+
+	err := api.Quotes.Export(requestBody, callbacks)
+	csvWriter.Flush()
+	if err != nil {
+		log.Printf("Got error when exporting the quotes after %d rows: %s", nrOfRows, err)
+		//Once the rows have started streaming the status code can not be changed, the client gets a truncated export
+		if !started {
+			rw.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package routes
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestExportWithFixtures tests the export of the quotes against the fixtures
+func TestExportWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		//exportRecord has the fields of every type of record
+		type exportRecord struct {
+			Type        string `json:"type"`
+			Id          int    `json:"id"`
+			Name        string `json:"name"`
+			QuoteId     int    `json:"quoteId"`
+			IsIcelandic bool   `json:"isIcelandic"`
+		}
+
+		exportNDJSON := func(body jsonBody) (*httptest.ResponseRecorder, map[string][]exportRecord) {
+			response := callRoute(t, testApi.ExportQuotes, body)
+			decoder := json.NewDecoder(response.Body)
+			records := map[string][]exportRecord{}
+			for decoder.More() {
+				var record exportRecord
+				if err := decoder.Decode(&record); err != nil {
+					t.Fatalf("got error %s when decoding the export", err)
+				}
+				records[record.Type] = append(records[record.Type], record)
+			}
+			return response, records
+		}
+		exportCSV := func(body jsonBody) [][]string {
+			records, err := csv.NewReader(callRoute(t, testApi.ExportQuotes, body).Body).ReadAll()
+			if err != nil {
+				t.Fatalf("got error %s when reading the CSV", err)
+			}
+			return records
+		}
+
+		runStatusTests(t, []statusTest{
+			{"should refuse users below the export tier", testApi.ExportQuotes, jsonBody{"apiKey": apiKey}, http.StatusUnauthorized},
+			{"should refuse an unknown format", testApi.ExportQuotes, jsonBody{"apiKey": godApiKey, "format": "xml"}, http.StatusBadRequest},
+			{"should refuse an unknown type", testApi.ExportQuotes, jsonBody{"apiKey": godApiKey, "type": "user"}, http.StatusBadRequest},
+		})
+
+		t.Run("should stream the Icelandic quotes as NDJSON with the snapshot timestamp", func(t *testing.T) {
+			response, records := exportNDJSON(jsonBody{"apiKey": godApiKey, "language": "icelandic"})
+			if _, err := time.Parse(time.RFC3339Nano, response.Header().Get("X-Snapshot-Timestamp")); err != nil {
+				t.Fatalf("expected a snapshot timestamp but got %q", response.Header().Get("X-Snapshot-Timestamp"))
+			}
+			rows := records["quote"]
+			if len(rows) != 4 || !rows[0].IsIcelandic || rows[0].QuoteId != 7 {
+				t.Fatalf("got %+v, want the 4 Icelandic quotes starting with quote 7", rows)
+			}
+			topics := records["topic"]
+			if len(topics) != 2 || topics[0].Id != 5 || topics[0].Name != "Speki" || !topics[0].IsIcelandic {
+				t.Fatalf("got %+v, want the 2 Icelandic topics starting with Speki", topics)
+			}
+		})
+
+		t.Run("should export the authors, also the ones without quotes, before the quotes", func(t *testing.T) {
+			var author structs.AuthorAPIModel
+			if statusCode := requestAndDecode(t, testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Maya Angelou"}, &author); statusCode != http.StatusOK {
+				t.Fatalf("got status code %d when creating the author", statusCode)
+			}
+			var first exportRecord
+			json.NewDecoder(callRoute(t, testApi.ExportQuotes, jsonBody{"apiKey": godApiKey}).Body).Decode(&first)
+			if first.Type != "author" || first.Id != 1 {
+				t.Fatalf("got %+v as the first record, want author 1", first)
+			}
+
+			_, records := exportNDJSON(jsonBody{"apiKey": godApiKey})
+			authors := records["author"]
+			if len(authors) != 12 || authors[11].Id != author.Id || authors[11].Name != "Maya Angelou" {
+				t.Fatalf("got %+v, want the 11 authors of the fixtures and Maya Angelou", authors)
+			}
+			if len(records["topic"]) != 6 || len(records["quote"]) == 0 {
+				t.Fatalf("got %d topics and %d quotes, want the 6 topics and the quotes", len(records["topic"]), len(records["quote"]))
+			}
+		})
+
+		t.Run("should export the topics as CSV", func(t *testing.T) {
+			records := exportCSV(jsonBody{"apiKey": godApiKey, "format": "csv", "type": "topic", "language": "english"})
+			if len(records) != 5 || records[0][2] != "isIcelandic" || records[1][1] != "inspirational" || records[1][2] != "false" {
+				t.Fatalf("got %+v, want the header and the 4 English topics", records)
+			}
+		})
+
+		t.Run("should export the topic links of a topic as CSV", func(t *testing.T) {
+			records := exportCSV(jsonBody{"apiKey": godApiKey, "format": "csv", "topic": "love"})
+			if len(records) != 3 || records[0][6] != "topicName" || records[1][2] != "18" || records[1][6] != "love" {
+				t.Fatalf("got %+v, want the header and the 2 quotes of topic love", records)
+			}
+		})
+	})
+}
@@ -7,9 +7,9 @@ import (
 	"github.com/Skjaldbaka17/quotes-api/structs"
 )
 
-func Home(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) Home(rw http.ResponseWriter, r *http.Request) {
 	var templates = template.Must(template.ParseFiles("./tmpl/home.html"))
-	q, _ := getRandomQuoteFromDb(&structs.Request{})
+	q, _ := api.getRandomQuote(&structs.Request{})
 	err := templates.ExecuteTemplate(rw, "home.html", q)
 	if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
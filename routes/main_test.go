@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/migrations"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+//The api keys of the users in the fixtures, a free-tier one and a GOD-tier one
+var apiKey, godApiKey = repository.FixtureApiKey, repository.FixtureGodApiKey
+
+//api runs against the Postgres database at DATABASE_URL, it is nil if no database is configured
+var api *Api
+
+//TEST_DATABASE_URL is an empty Postgres database, e.g. the one of make test-db, that the tests of the fixtures also run
+//against. It is migrated up and every test seeds it again, so it must not be a database with data that matters
+const TEST_DATABASE_URL = "TEST_DATABASE_URL"
+
+//fixturesDb is the database at TEST_DATABASE_URL, it is nil if none is configured
+var fixturesDb *gorm.DB
+
+func TestMain(m *testing.M) {
+	if handlers.GetEnvVariable(handlers.DATABASE_URL) != "" {
+		if err := handlers.ConnectDatabase(); err != nil {
+			log.Fatalf("failed to connect database: %s", err)
+		}
+		api = NewApi(repository.NewPostgresRepositories(handlers.Db))
+	}
+	if dsn := os.Getenv(TEST_DATABASE_URL); dsn != "" {
+		if err := connectFixturesDb(dsn); err != nil {
+			log.Fatalf("failed to set up the database at %s: %s", TEST_DATABASE_URL, err)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+//connectFixturesDb connects to the database of the fixtures and migrates it up
+func connectFixturesDb(dsn string) error {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return err
+	}
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	if _, err := migrator.Up(); err != nil {
+		return err
+	}
+	fixturesDb = db
+	return nil
+}
+
+//requireDatabase skips tests that need the fully seeded Postgres database when there is none
+func requireDatabase(t *testing.T) {
+	if api == nil {
+		t.Skip("DATABASE_URL is not set, skipping test against the seeded database")
+	}
+}
+
+//newMemoryApi returns the routes backed by the in-memory repositories seeded with the fixtures
+func newMemoryApi() *Api {
+	return NewApi(repository.NewMemoryRepositories(repository.SeedFixtures()))
+}
+
+//forEachBackend runs the test against the in-memory repositories and, if TEST_DATABASE_URL is set, against Postgres.
+//newApi returns the routes backed by freshly seeded fixtures
+func forEachBackend(t *testing.T, test func(t *testing.T, newApi func() *Api)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, newMemoryApi)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		if fixturesDb == nil {
+			t.Skip("TEST_DATABASE_URL is not set, skipping test against Postgres")
+		}
+		test(t, func() *Api {
+			if err := repository.SeedPostgres(fixturesDb, repository.SeedFixtures()); err != nil {
+				t.Fatalf("failed to seed the fixtures: %s", err)
+			}
+			return NewApi(repository.NewPostgresRepositories(fixturesDb))
+		})
+	})
+}
+
+//refreshViews refreshes the materialized views, so that the writes of a test are seen by the searches and lists on
+//Postgres right away. The in-memory views are always up to date
+func refreshViews(t *testing.T, testApi *Api) {
+	if err := testApi.Refresher.Refresh(repository.MaterializedViews...); err != nil {
+		t.Fatalf("failed to refresh the views: %s", err)
+	}
+}
+
+//jsonBody is the JSON body of a request to a route
+type jsonBody map[string]interface{}
+
+//callRoute calls the route fn with the JSON body and returns the recorded response
+func callRoute(t *testing.T, fn httpRequest, body jsonBody) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonStr, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal the request body %+v: %s", body, err)
+	}
+	response, request := getRequestAndResponseForTest(jsonStr)
+	fn(response, request)
+	return response
+}
+
+//requestAndDecode calls the route fn with the JSON body, decodes the response into respObj, unless it is nil, and
+//returns the status code. An error response is decoded too, e.g. into a structs.ErrorResponse
+func requestAndDecode(t *testing.T, fn httpRequest, body jsonBody, respObj interface{}) int {
+	t.Helper()
+	response := callRoute(t, fn, body)
+	if respObj != nil {
+		if err := json.Unmarshal(response.Body.Bytes(), respObj); err != nil && response.Code == http.StatusOK {
+			t.Fatalf("failed to decode the response %s: %s", response.Body.String(), err)
+		}
+	}
+	return response.Code
+}
+
+//statusTest is a request to a route and the status code it should get
+type statusTest struct {
+	name       string
+	fn         httpRequest
+	body       jsonBody
+	statusCode int
+}
+
+//runStatusTests makes the requests of the tests in order, each in a subtest, and checks their status codes
+func runStatusTests(t *testing.T, tests []statusTest) {
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if statusCode := requestAndDecode(t, test.fn, test.body, nil); statusCode != test.statusCode {
+				t.Fatalf("got status code %d but expected %d", statusCode, test.statusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// writePage writes the items of a list, wrapped in a page with the total that total counts if withPagination is set.
+// nextCursor is the cursor right after the last item, for the lists that page through cursors, and hasMore whether
+// such a list has items after it, see listRequest
+func writePage(rw http.ResponseWriter, requestBody structs.Request, items interface{}, nrOfItems int, total func(structs.Request) (structs.TotalDBModel, error), nextCursor string, hasMore bool, route string) {
+	if !requestBody.WithPagination {
+		json.NewEncoder(rw).Encode(items)
+		return
+	}
+	page, err := newPage(requestBody, items, nrOfItems, total, nextCursor, hasMore)
+	if err != nil {
+		writeSearchError(rw, err, route)
+		return
+	}
+	json.NewEncoder(rw).Encode(page)
+}
+
+// newPage wraps the items in a page along with the total that total counts, whether there is a next page and the
+// request body for it. An estimated total can be off, so then there is a next page as long as this one is full.
+// With a cursor the total is counted from the cursor, i.e. the page is the first one of the rest of the list, whether
+// there is a next page is hasMore, see listRequest, and the next page continues from nextCursor on the same page
+func newPage(requestBody structs.Request, items interface{}, nrOfItems int, total func(structs.Request) (structs.TotalDBModel, error), nextCursor string, hasMore bool) (structs.PageAPIModel, error) {
+	counted, err := total(requestBody)
+	if err != nil {
+		return structs.PageAPIModel{}, err
+	}
+
+	page := structs.PageAPIModel{
+		Items:           items,
+		Page:            requestBody.Page,
+		PageSize:        requestBody.PageSize,
+		Total:           counted.Total,
+		TotalIsEstimate: counted.Estimated,
+		HasMore:         nrOfItems == requestBody.PageSize && (counted.Estimated || (requestBody.Page+1)*requestBody.PageSize < counted.Total),
+	}
+	if requestBody.Cursor != "" && nextCursor != "" {
+		page.HasMore = hasMore
+	}
+	if page.HasMore {
+		next := requestBody
+		next.ApiKey = ""
+		if nextCursor != "" {
+			next.Cursor = nextCursor
+			page.NextCursor = nextCursor
+		} else {
+			next.Page++
+		}
+		page.Next = &next
+	}
+	return page, nil
+}
+
+// listRequest returns the request a list that pages through cursors is fetched with. With a cursor it asks for one
+// item more than the page, which is not on the page but tells whether there is a next page, see listedPage
+func listRequest(requestBody structs.Request) structs.Request {
+	if requestBody.Cursor != "" {
+		requestBody.PageSize++
+	}
+	return requestBody
+}
+
+// listedPage returns how many of the nrOfListed items fetched with listRequest are on the page and whether there are
+// more after them, only known with a cursor
+func listedPage(requestBody structs.Request, nrOfListed int) (int, bool) {
+	if nrOfListed > requestBody.PageSize {
+		return requestBody.PageSize, true
+	}
+	return nrOfListed, false
+}
+
+// writeListError writes the error of a list that pages through cursors, 400 if the cursor is not one of the list
+func writeListError(rw http.ResponseWriter, err error, route string) {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The cursor is not a cursor of this list, a cursor can only be used with the orderConfig and language of the request it came from", StatusCode: http.StatusBadRequest})
+		return
+	}
+	rw.WriteHeader(http.StatusInternalServerError)
+	log.Printf("Got error when querying DB in %s: %s", route, err)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+}
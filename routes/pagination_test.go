@@ -0,0 +1,176 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestPaginationWithFixtures tests the pages and cursors of the lists and searches against the fixtures
+func TestPaginationWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		//Paging through the lists takes more requests than the hourly quota of the free tier, the GOD tier has none
+		page := func(request structs.Request, fn httpRequest) (structs.PageAPIModel, int) {
+			request.ApiKey = godApiKey
+			jsonStr, _ := json.Marshal(request)
+			response, httpRequest := getRequestAndResponseForTest(jsonStr)
+			fn(response, httpRequest)
+			var page structs.PageAPIModel
+			json.NewDecoder(response.Body).Decode(&page)
+			return page, response.Result().StatusCode
+		}
+		count := func(request structs.Request, fn httpRequest) int {
+			request.ApiKey = godApiKey
+			request.PageSize = 200
+			jsonStr, _ := json.Marshal(request)
+			respObj, _ := requestAndReturnArray(jsonStr, fn)
+			return len(respObj)
+		}
+
+		t.Run("should wrap the lists in a page with the total and the next page", func(t *testing.T) {
+			for _, test := range []struct {
+				request structs.Request
+				fn      httpRequest
+			}{
+				{structs.Request{PageSize: 5}, testApi.GetQuotesList},
+				{structs.Request{PageSize: 2, Language: "english"}, testApi.GetAuthorsList},
+				{structs.Request{PageSize: 2, Id: 3}, testApi.GetTopic},
+			} {
+				total := count(test.request, test.fn)
+				test.request.WithPagination = true
+				first, statusCode := page(test.request, test.fn)
+				if statusCode != http.StatusOK || first.Total != total || first.TotalIsEstimate || !first.HasMore {
+					t.Fatalf("got %+v for %+v, want a total of %d and more pages", first, test.request, total)
+				}
+				if items, ok := first.Items.([]interface{}); !ok || len(items) != test.request.PageSize {
+					t.Fatalf("got items %+v, want a full page of %d", first.Items, test.request.PageSize)
+				}
+				//The lists that page through cursors continue from the cursor on the same page
+				wantPage := 1
+				if first.NextCursor != "" {
+					wantPage = 0
+				}
+				if first.Next == nil || first.Next.Page != wantPage || first.Next.ApiKey != "" || !first.Next.WithPagination {
+					t.Fatalf("got next %+v, want the request body of page %d without the apiKey", first.Next, wantPage)
+				}
+
+				test.request.Page = (total - 1) / test.request.PageSize
+				last, _ := page(test.request, test.fn)
+				if last.Total != total || last.HasMore || last.Next != nil {
+					t.Fatalf("got %+v for the last page, want no more pages", last)
+				}
+			}
+		})
+
+		t.Run("should page through the lists with cursors in the order of the list", func(t *testing.T) {
+			ids := func(items interface{}, idKey string) []int {
+				ids := []int{}
+				list, _ := items.([]interface{})
+				for _, item := range list {
+					ids = append(ids, int(item.(map[string]interface{})[idKey].(float64)))
+				}
+				return ids
+			}
+			for _, test := range []struct {
+				request structs.Request
+				fn      httpRequest
+				idKey   string
+			}{
+				{structs.Request{PageSize: 4}, testApi.GetQuotesList, "quoteId"},
+				{structs.Request{PageSize: 4, OrderConfig: structs.OrderConfig{OrderBy: "length", Reverse: true}}, testApi.GetQuotesList, "quoteId"},
+				{structs.Request{PageSize: 3}, testApi.GetAuthorsList, "id"},
+				{structs.Request{PageSize: 3, Language: "english", OrderConfig: structs.OrderConfig{OrderBy: "nrOfQuotes"}}, testApi.GetAuthorsList, "id"},
+			} {
+				all := test.request
+				all.PageSize, all.WithPagination = 200, true
+				listed, _ := page(all, test.fn)
+				want := ids(listed.Items, test.idKey)
+
+				got := []int{}
+				test.request.WithPagination = true
+				for next := &test.request; next != nil; {
+					paged, statusCode := page(*next, test.fn)
+					if statusCode != http.StatusOK {
+						t.Fatalf("got status code %d for %+v, want %d", statusCode, next, http.StatusOK)
+					}
+					if next.Cursor != "" && paged.Total != len(want)-len(got) {
+						t.Fatalf("got a total of %d from the cursor, want the %d items left", paged.Total, len(want)-len(got))
+					}
+					if next.Cursor != "" && len(ids(paged.Items, test.idKey)) == 0 {
+						t.Fatalf("got an empty page from the cursor of %+v, want no next page after the last item", test.request)
+					}
+					got = append(got, ids(paged.Items, test.idKey)...)
+					if paged.Next != nil && (paged.NextCursor == "" || paged.Next.Cursor != paged.NextCursor) {
+						t.Fatalf("got %+v, want the next page to continue from the nextCursor", paged)
+					}
+					next = paged.Next
+				}
+				if fmt.Sprint(got) != fmt.Sprint(want) {
+					t.Fatalf("got %v through the cursors of %+v, want %v", got, test.request, want)
+				}
+			}
+		})
+
+		t.Run("should know from the cursor that a full page is the last one", func(t *testing.T) {
+			total := count(structs.Request{}, testApi.GetQuotesList)
+			first, _ := page(structs.Request{PageSize: total / 2, WithPagination: true}, testApi.GetQuotesList)
+			rest := *first.Next
+			rest.PageSize = total - total/2
+			last, statusCode := page(rest, testApi.GetQuotesList)
+			if items, _ := last.Items.([]interface{}); statusCode != http.StatusOK || len(items) != rest.PageSize || last.HasMore || last.Next != nil {
+				t.Fatalf("got %+v, want the full last page of %d quotes and no next page", last, rest.PageSize)
+			}
+		})
+
+		t.Run("should order the names by code point, whatever the collation of the database", func(t *testing.T) {
+			if statusCode := requestAndDecode(t, testApi.CreateAuthor, jsonBody{"apiKey": godApiKey, "name": "Ólafur Kárason"}, nil); statusCode != http.StatusOK {
+				t.Fatalf("got status code %d when creating the author", statusCode)
+			}
+
+			//Ó comes after every ASCII letter, a language's collation would put it next to O
+			first, _ := page(structs.Request{PageSize: 200, WithPagination: true}, testApi.GetAuthorsList)
+			names := []string{}
+			for _, item := range first.Items.([]interface{}) {
+				names = append(names, item.(map[string]interface{})["name"].(string))
+			}
+			if len(names) < 2 || names[len(names)-1] != "Ólafur Kárason" || names[len(names)-2] != "William Shakespeare" {
+				t.Fatalf("got %v, want Ólafur Kárason after William Shakespeare", names)
+			}
+
+			first, _ = page(structs.Request{PageSize: len(names) - 1, WithPagination: true}, testApi.GetAuthorsList)
+			last, _ := page(*first.Next, testApi.GetAuthorsList)
+			if items, _ := last.Items.([]interface{}); len(items) != 1 || items[0].(map[string]interface{})["name"] != "Ólafur Kárason" || last.HasMore {
+				t.Fatalf("got %+v from the cursor after William Shakespeare, want only Ólafur Kárason", last)
+			}
+		})
+
+		t.Run("should refuse a cursor of another list", func(t *testing.T) {
+			first, _ := page(structs.Request{PageSize: 2, WithPagination: true, OrderConfig: structs.OrderConfig{OrderBy: "popularity"}}, testApi.GetQuotesList)
+			for _, cursor := range []string{first.NextCursor, "not a cursor"} {
+				if _, statusCode := page(structs.Request{PageSize: 2, Cursor: cursor}, testApi.GetQuotesList); statusCode != http.StatusBadRequest {
+					t.Fatalf("got status code %d for the cursor %q, want %d", statusCode, cursor, http.StatusBadRequest)
+				}
+			}
+			if _, statusCode := page(structs.Request{PageSize: 2, Cursor: first.NextCursor}, testApi.GetAuthorsList); statusCode != http.StatusBadRequest {
+				t.Fatalf("got status code %d for a cursor of the quotes list, want %d", statusCode, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("should wrap the results of a search along with its facets", func(t *testing.T) {
+			searched, statusCode := page(structs.Request{SearchString: "life OR mig", PageSize: 1, WithPagination: true, Facets: &structs.FacetConfig{}}, testApi.SearchQuotesByString)
+			if statusCode != http.StatusOK || searched.Total != 4 || !searched.HasMore || searched.Facets == nil || searched.Facets.Total != 4 {
+				t.Fatalf("got %+v, want a total of 4 matches along with the facets", searched)
+			}
+
+			searched, _ = page(structs.Request{SearchString: "nietzsche", WithPagination: true}, testApi.SearchAuthorsByString)
+			if searched.Total != 1 || searched.HasMore || searched.Next != nil {
+				t.Fatalf("got %+v, want a single author", searched)
+			}
+		})
+	})
+}
@@ -2,19 +2,22 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"math/rand"
 	"net/http"
-	"regexp"
+	"sort"
 	"strings"
-	"time"
+	"unicode/utf8"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
-	"gorm.io/gorm"
 )
 
+// maxQuoteLength is the maximum number of characters in a quote created through the api
+const maxQuoteLength = 2000
+
 // swagger:route POST /quotes QUOTES GetQuotes
 // Get quotes by their ids
 //
@@ -23,26 +26,20 @@ import (
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-// GetQuotes handles POST requests to get the quotes, and their authors, that have the given ids
-func GetQuotes(rw http.ResponseWriter, r *http.Request) {
+// GetQuotes handles POST requests to get the quotes, and their authors, that have the given ids. The private quotes
+// are only returned for the user that created them.
+func (api *Api) GetQuotes(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
-	var quotes []structs.SearchViewDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-
-	dbPointer := handlers.Db.Table("searchview").Order("quote_id ASC")
-	if requestBody.AuthorId > 0 {
-		dbPointer = dbPointer.
-			Where("author_id = ?", requestBody.AuthorId)
-		dbPointer = pagination(requestBody, dbPointer)
-	} else {
-		dbPointer = dbPointer.Where("quote_id in ?", requestBody.Ids)
+	if err := api.resolveAuthor(rw, &requestBody); err != nil {
+		return
+	}
+	quotes, err := api.Quotes.GetQuotes(requestBody)
+	if err == nil {
+		quotes, err = api.withPrivateQuotes(requestBody, quotes)
 	}
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-
-	err := dbPointer.Find(&quotes).Error
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -51,13 +48,152 @@ func GetQuotes(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//Update popularity in background!
-	go handlers.DirectFetchQuotesCountIncrement(requestBody.Ids)
-
 	searchViewsAPI := structs.ConvertToSearchViewsAPIModel(quotes)
+	if err = api.citeSearchViews(requestBody, searchViewsAPI); err != nil {
+		writeSourcesError(rw, err, "GetQuotes")
+		return
+	}
+
+	//Update popularity in background!
+	api.offline(func() error { return handlers.DirectFetchQuotesCountIncrement(api.Repositories, requestBody.Ids) })
 	json.NewEncoder(rw).Encode(searchViewsAPI)
 }
 
+// withPrivateQuotes adds the requesting user's private quotes that match the request to the quotes, ordered by quote id
+func (api *Api) withPrivateQuotes(requestBody structs.Request, quotes []structs.SearchViewDBModel) ([]structs.SearchViewDBModel, error) {
+	user, err := api.Users.GetByApiKey(requestBody.ApiKey)
+	if err != nil {
+		return nil, err
+	}
+	private, err := api.Quotes.GetPrivateQuotes(user.Id, requestBody)
+	if err != nil || len(private) == 0 {
+		return quotes, err
+	}
+	quotes = append(quotes, private...)
+	sort.SliceStable(quotes, func(i, j int) bool { return quotes[i].QuoteId < quotes[j].QuoteId })
+	return quotes, nil
+}
+
+// swagger:route POST /quotes/new QUOTES CreateQuote
+// Create a new quote for an existing author (authorId) or a new one (author). A private quote is only returned for your apiKey.
+// A public quote is published right away for GOD-tier users, for other users it is submitted to the moderation queue.
+// A quote that only differs from an existing one by punctuation, case or a trailing attribution is refused, very similar
+// existing quotes are listed in similarQuotes as a warning
+// responses:
+//	200: createdQuoteResponse
+//  202: submissionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: quoteExistsResponse
+//  500: internalServerErrorResponse
+
+// CreateQuote handles POST requests to insert a new public or private quote
+func (api *Api) CreateQuote(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	newQuote, err := newQuoteFromRequest(requestBody)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	user, err := api.Users.GetByApiKey(requestBody.ApiKey)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when getting the user in CreateQuote: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	newQuote.UserId = user.Id
+
+	//The public quotes of users below the GOD tier wait in the moderation queue
+	if !newQuote.IsPrivate && !handlers.IsGOD(user.Tier) {
+		api.submitQuote(rw, newQuote)
+		return
+	}
+
+	quote, err := api.Quotes.Insert(newQuote)
+	switch {
+	case errors.Is(err, repository.ErrQuoteExists):
+		//Only the live quotes that are public or the user's own are duplicates, the id is unknown when the duplicate
+		//was inserted at the same time
+		message := "This quote already exists"
+		if quote.Id != 0 {
+			message = fmt.Sprintf("This quote already exists, it has the id %d", quote.Id)
+		}
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusConflict})
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No author exists with the id %d", newQuote.AuthorId), StatusCode: http.StatusNotFound})
+		return
+	case errors.Is(err, repository.ErrAuthorDeleted), errors.Is(err, repository.ErrTopicDeleted):
+		writeDeletedNameError(rw, err, newQuote)
+		return
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when inserting the quote in CreateQuote: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	//The public quotes show up in search once the views have been refreshed
+	if !quote.IsPrivate {
+		api.Refresher.MarkStale()
+	}
+	json.NewEncoder(rw).Encode(structs.CreatedQuoteAPIModel{QuoteAPIModel: quote.ConvertToAPIModel(), SimilarQuotes: api.similarQuotes(quote.Quote, user.Id, quote.Id)})
+}
+
+// writeDeletedNameError answers a new quote whose author or topic is given by the name of a deleted one, which a GOD-tier
+// user has to restore first
+func writeDeletedNameError(rw http.ResponseWriter, err error, newQuote structs.QuoteInsertModel) {
+	message := fmt.Sprintf("The author %s has been deleted, it has to be restored before it gets new quotes", newQuote.Author)
+	if errors.Is(err, repository.ErrTopicDeleted) {
+		message = fmt.Sprintf("One of the topics %s has been deleted, it has to be restored before it gets new quotes", strings.Join(newQuote.Topics, ", "))
+	}
+	rw.WriteHeader(http.StatusConflict)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusConflict})
+}
+
+// newQuoteFromRequest validates the quote, its language and author
+func newQuoteFromRequest(requestBody structs.Request) (structs.QuoteInsertModel, error) {
+	newQuote := structs.QuoteInsertModel{
+		Quote:     strings.TrimSpace(requestBody.Quote),
+		Author:    strings.TrimSpace(requestBody.Author),
+		AuthorId:  requestBody.AuthorId,
+		IsPrivate: requestBody.Private,
+	}
+	if newQuote.Quote == "" {
+		return newQuote, errors.New("please supply the quote")
+	}
+	if utf8.RuneCountInString(newQuote.Quote) > maxQuoteLength {
+		return newQuote, fmt.Errorf("the quote can be at most %d characters long", maxQuoteLength)
+	}
+	if newQuote.AuthorId <= 0 && newQuote.Author == "" {
+		return newQuote, errors.New("please supply either the authorId of an existing author or the name of the author")
+	}
+
+	switch strings.ToLower(requestBody.Language) {
+	case "", "english":
+	case "icelandic":
+		newQuote.IsIcelandic = true
+	default:
+		return newQuote, fmt.Errorf("the language %s is not supported, it should be either english or icelandic", requestBody.Language)
+	}
+
+	for _, topic := range requestBody.Topics {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			newQuote.Topics = append(newQuote.Topics, topic)
+		}
+	}
+	return newQuote, nil
+}
+
 // swagger:route POST /quotes/list QUOTES GetQuotesList
 //
 // Get list of quotes according to some ordering / parameters
@@ -69,51 +205,83 @@ func GetQuotes(rw http.ResponseWriter, r *http.Request) {
 
 // GetQuotesList handles POST requests to get the quotes that fit the parameters
 
-func GetQuotesList(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetQuotesList(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
-	var quotes []structs.SearchViewDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := handlers.Db.Table("searchview")
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
+	quotes, err := api.Quotes.List(listRequest(requestBody))
 
-	orderDirection := "ASC"
-	if requestBody.OrderConfig.Reverse {
-		orderDirection = "DESC"
+	if err != nil {
+		writeListError(rw, err, "GetQuotesList")
+		return
 	}
+	nrOfQuotes, hasMore := listedPage(requestBody, len(quotes))
+	quotes = quotes[:nrOfQuotes]
 
-	switch strings.ToLower(requestBody.OrderConfig.OrderBy) {
-	case "popularity": //TODO: add popularity ordering
-		orderDirection = "DESC"
-		if requestBody.OrderConfig.Reverse {
-			orderDirection = "ASC"
-		}
-		dbPointer = dbPointer.Order("quote_count " + orderDirection)
-	case "length":
-		dbPointer = setMaxMinNumber(requestBody.OrderConfig, "length(quote)", orderDirection, dbPointer)
-	default:
-		dbPointer = setMaxMinNumber(requestBody.OrderConfig, "quote_id", orderDirection, dbPointer)
+	searchViewsAPI := structs.ConvertToSearchViewsAPIModel(quotes)
+	if err = api.citeSearchViews(requestBody, searchViewsAPI); err != nil {
+		writeSourcesError(rw, err, "GetQuotesList")
+		return
 	}
 
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
+	//Update popularity in background!
+	api.offline(func() error { return handlers.QuotesAppearInSearchCountIncrement(api.Repositories, quotes) })
+	nextCursor := ""
+	if len(quotes) > 0 {
+		nextCursor = repository.QuotesListCursor(requestBody, quotes[len(quotes)-1])
+	}
+	writePage(rw, requestBody, searchViewsAPI, len(searchViewsAPI), api.Quotes.ListTotal, nextCursor, hasMore, "GetQuotesList")
+}
+
+// swagger:route POST /quotes/related QUOTES GetRelatedQuotes
+// Get the quotes most similar to a quote, i.e. that share the most words and topics with it and, optionally, its author
+// responses:
+//	200: searchViewsResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
 
-	err := pagination(requestBody, dbPointer).Order("quote_id").
-		Find(&quotes).
-		Error
+// GetRelatedQuotes handles POST requests for the quotes related to the quote with the given id, the most similar first
+func (api *Api) GetRelatedQuotes(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
 
-	if err != nil {
+	if requestBody.Id <= 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the quote", StatusCode: http.StatusBadRequest})
+		return
+	}
+	if requestBody.SameAuthor && requestBody.ExcludeSameAuthor {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "sameAuthor and excludeSameAuthor can not both be set", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	quotes, err := api.Quotes.Related(requestBody)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No public quote exists with the id %d", requestBody.Id), StatusCode: http.StatusNotFound})
+		return
+	case err != nil:
 		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when querying DB in GetQuotesList: %s", err)
+		log.Printf("Got error when querying DB in GetRelatedQuotes: %s", err)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
 		return
 	}
 
-	//Update popularity in background!
-	go handlers.QuotesAppearInSearchCountIncrement(quotes)
 	searchViewsAPI := structs.ConvertToSearchViewsAPIModel(quotes)
+	if err = api.citeSearchViews(requestBody, searchViewsAPI); err != nil {
+		writeSourcesError(rw, err, "GetRelatedQuotes")
+		return
+	}
+
+	//Update popularity in background!
+	api.offline(func() error { return handlers.QuotesAppearInSearchCountIncrement(api.Repositories, quotes) })
 	json.NewEncoder(rw).Encode(searchViewsAPI)
 }
 
@@ -126,17 +294,18 @@ func GetQuotesList(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // GetRandomQuote handles POST requests for getting a random quote
-func GetRandomQuote(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetRandomQuote(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+	if err := api.resolveAuthor(rw, &requestBody); err != nil {
 		return
 	}
 
-	result, err := getRandomQuoteFromDb(&requestBody)
+	result, err := api.getRandomQuote(&requestBody)
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when querying DB in GetRandomQuote: %s", err)
-		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		writeSearchError(rw, err, "GetRandomQuote")
 		return
 	}
 
@@ -146,63 +315,18 @@ func GetRandomQuote(rw http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "No quote exists that matches the given parameters"})
 		return
 	}
-	json.NewEncoder(rw).Encode(result)
-
-}
-
-func getRandomQuoteFromDb(requestBody *structs.Request) (structs.TopicViewAPIModel, error) {
-	const NR_OF_QUOTES = 639028
-	const NR_OF_ENGLISH_QUOTES = 634841
-	var dbPointer *gorm.DB
-	var topicResult structs.TopicViewDBModel
-
-	var shouldDoQuick = true
-
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	m1 := regexp.MustCompile(` `)
-	phrasesearch := m1.ReplaceAllString(requestBody.SearchString, " <-> ")
-
-	//Random quote from a particular topic
-	if requestBody.TopicId > 0 {
-		dbPointer = handlers.Db.Table("topicsview, plainto_tsquery(?) as plainq, to_tsquery(?) as phraseq", requestBody.SearchString, phrasesearch).Where("topic_id = ?", requestBody.TopicId)
-		shouldDoQuick = false
-	} else {
-		dbPointer = handlers.Db.Table("searchview, plainto_tsquery(?) as plainq, to_tsquery(?) as phraseq", requestBody.SearchString, phrasesearch)
-	}
-
-	//Random quote from a particular author
-	if requestBody.AuthorId > 0 {
-		dbPointer = dbPointer.Where("author_id = ?", requestBody.AuthorId)
-		shouldDoQuick = false
-	}
-
-	//Random quote from a particular language
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
-
-	if strings.ToLower(requestBody.Language) == "icelandic" {
-		shouldDoQuick = false
-	}
-
-	if requestBody.SearchString != "" {
-		dbPointer = dbPointer.Where("( quote_tsv @@ plainq OR quote_tsv @@ phraseq)")
-		shouldDoQuick = false
+	results := []structs.TopicViewAPIModel{result}
+	if err = api.citeTopicViews(requestBody, results); err != nil {
+		writeSourcesError(rw, err, "GetRandomQuote")
+		return
 	}
+	json.NewEncoder(rw).Encode(results[0])
 
-	//Order by used to get random quote if there are "few" rows returned
-	if !shouldDoQuick {
-		dbPointer = dbPointer.Order("random()") //Randomized, O( n*log(n) )
-	} else {
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		if strings.ToLower(requestBody.Language) == "english" {
-			dbPointer = dbPointer.Offset(r.Intn(NR_OF_ENGLISH_QUOTES))
-		} else {
-			dbPointer = dbPointer.Offset(r.Intn(NR_OF_QUOTES))
-		}
-
-	}
+}
 
-	//** ---------- Paramater configuratino for DB query ends ---------- **//
-	err := dbPointer.Limit(1).Find(&topicResult).Error
+// getRandomQuote returns a random quote according to the given parameters
+func (api *Api) getRandomQuote(requestBody *structs.Request) (structs.TopicViewAPIModel, error) {
+	topicResult, err := api.Quotes.Random(*requestBody)
 	if err != nil {
 		return structs.TopicViewAPIModel{}, err
 	}
@@ -216,14 +340,14 @@ func getRandomQuoteFromDb(requestBody *structs.Request) (structs.TopicViewAPIMod
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//SetQuoteOfTheyDay sets the quote of the day (is password protected)
-func SetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
-	if err := handlers.AuthorizeGODApiKey(rw, r); err != nil {
+// SetQuoteOfTheyDay sets the quote of the day (is password protected)
+func (api *Api) SetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
 		return
 	}
 
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
@@ -239,7 +363,7 @@ func SetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, qod := range requestBody.Qods {
-		err := setQOD(requestBody.Language, qod.Date, qod.Id)
+		err := api.OfTheDay.SetQuoteOfTheDay(requestBody.Language, qod.Date, qod.Id)
 		if err != nil {
 			log.Printf("Got error when settin the qod %+v as QOD: %s", qod, err)
 			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Some of the quotes (ids) you supplied are not in " + requestBody.Language, StatusCode: http.StatusBadRequest})
@@ -258,22 +382,17 @@ func SetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//GetQuoteOfTheyDay gets the quote of the day
-func GetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
+// GetQuoteOfTheyDay gets the quote of the day
+func (api *Api) GetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 	if requestBody.Language == "" {
 		requestBody.Language = "English"
 	}
 
-	var quote structs.QodViewDBModel
-	var err error
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := qodLanguageSQL(requestBody.Language).Where("date = current_date")
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err = dbPointer.Scan(&quote).Error
+	quote, err := api.OfTheDay.GetQuoteOfTheDay(requestBody.Language)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -284,7 +403,7 @@ func GetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 
 	if (structs.QodViewDBModel{}) == quote {
 		fmt.Println("Setting a brand new QOD for today")
-		err = setNewRandomQOD(requestBody.Language)
+		err = api.OfTheDay.SetRandomQuoteOfTheDay(requestBody.Language)
 		if err != nil {
 			rw.WriteHeader(http.StatusInternalServerError)
 			log.Printf("Got error when setting new random qod: %s", err)
@@ -292,11 +411,16 @@ func GetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		GetQuoteOfTheDay(rw, r)
+		api.GetQuoteOfTheDay(rw, r)
 		return
 	}
 
-	json.NewEncoder(rw).Encode(quote.ConvertToAPIModel())
+	qods := []structs.QodViewAPIModel{quote.ConvertToAPIModel()}
+	if err = api.citeQods(requestBody, qods); err != nil {
+		writeSourcesError(rw, err, "GetQuoteOfTheDay")
+		return
+	}
+	json.NewEncoder(rw).Encode(qods[0])
 }
 
 // swagger:route POST /quotes/qod/history QUOTES GetQODHistory
@@ -306,28 +430,17 @@ func GetQuoteOfTheDay(rw http.ResponseWriter, r *http.Request) {
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
-//GetQODHistory gets Qod history starting from some point
-func GetQODHistory(rw http.ResponseWriter, r *http.Request) {
+// GetQODHistory gets Qod history starting from some point
+func (api *Api) GetQODHistory(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 	if requestBody.Language == "" {
 		requestBody.Language = "English"
 	}
 
-	var quotes []structs.QodViewDBModel
-	var err error
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := qodLanguageSQL(requestBody.Language)
-
-	//Not maximum because then possibility of endless cycle with the if statement below!
-	if requestBody.Minimum != "" {
-		dbPointer = dbPointer.Where("date >= ?", requestBody.Minimum)
-	}
-	dbPointer = dbPointer.Where("date <= current_date").Order("date DESC")
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err = dbPointer.Find(&quotes).Error
+	quotes, err := api.OfTheDay.QuoteOfTheDayHistory(requestBody.Language, requestBody.Minimum)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -337,45 +450,21 @@ func GetQODHistory(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(quotes) == 0 {
-		err = setNewRandomQOD(requestBody.Language)
+		err = api.OfTheDay.SetRandomQuoteOfTheDay(requestBody.Language)
 		if err != nil {
 			rw.WriteHeader(http.StatusInternalServerError)
 			log.Printf("Got error when querying setting new Random QOD in history: %s", err)
 			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
 			return
 		}
-		GetQODHistory(rw, r)
+		api.GetQODHistory(rw, r)
 		return
 	}
 
 	qodHistoryAPI := structs.ConvertToQodViewsAPIModel(quotes)
-	json.NewEncoder(rw).Encode(qodHistoryAPI)
-}
-
-//setQOD inserts a new row into qod/qodice table
-func setQOD(language string, date string, quoteId int) error {
-	switch strings.ToLower(language) {
-	case "icelandic":
-		return handlers.Db.Exec("insert into qodice (quote_id, date) values((select id from quotes where id = ? and is_icelandic), ?) on conflict (date) do update set quote_id = ?", quoteId, date, quoteId).Error
-	default:
-		return handlers.Db.Exec("insert into qod (quote_id, date) values((select id from quotes where id = ? and not is_icelandic), ?) on conflict (date) do update set quote_id = ?", quoteId, date, quoteId).Error
-	}
-}
-
-//SetNewRandomQOD sets a random quote as the qod for today (if language=icelandic is supplied then it adds the random qod to the icelandic qod table)
-func setNewRandomQOD(language string) error {
-	var quoteItem structs.QuoteDBModel
-	var dbPointer *gorm.DB
-	dbPointer = handlers.Db.Table("quotes")
-	dbPointer = quoteLanguageSQL(language, dbPointer)
-	if strings.ToLower(language) != "icelandic" {
-		dbPointer = dbPointer.Where("Random() < 0.005")
-	}
-
-	err := dbPointer.Order("random()").Limit(1).Scan(&quoteItem).Error
-	if err != nil {
-		return err
+	if err = api.citeQods(requestBody, qodHistoryAPI); err != nil {
+		writeSourcesError(rw, err, "GetQODHistory")
+		return
 	}
-
-	return setQOD(language, time.Now().Format("2006-01-02"), quoteItem.Id)
+	json.NewEncoder(rw).Encode(qodHistoryAPI)
 }
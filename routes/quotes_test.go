@@ -22,6 +22,7 @@ type httpRequest func(http.ResponseWriter, *http.Request)
 type Set []int
 
 func TestQuotes(t *testing.T) {
+	requireDatabase(t)
 	user := createUser(t)
 	godUser := getGODModeUser(t)
 	t.Run("Get Quotes", func(t *testing.T) {
@@ -29,7 +30,7 @@ func TestQuotes(t *testing.T) {
 
 			var quoteIds = Set{1, 2, 3}
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","ids":  [%s]}`, user.ApiKey, quoteIds.toString()))
-			respObj, _ := requestAndReturnArray(jsonStr, GetQuotes)
+			respObj, _ := requestAndReturnArray(jsonStr, api.GetQuotes)
 
 			if len(respObj) != len(quoteIds) {
 				t.Fatalf("got list of length %d but expected list of length %d", len(respObj), len(quoteIds))
@@ -45,7 +46,7 @@ func TestQuotes(t *testing.T) {
 		t.Run("should get Quotes for author with id 1", func(t *testing.T) {
 			authorId := 1
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","authorId":  %d}`, user.ApiKey, authorId))
-			respObj, _ := requestAndReturnArray(jsonStr, GetQuotes)
+			respObj, _ := requestAndReturnArray(jsonStr, api.GetQuotes)
 
 			if len(respObj) == 0 {
 				t.Fatalf("got list of length 0 but expected some quotes, response : %+v", respObj)
@@ -65,7 +66,7 @@ func TestQuotes(t *testing.T) {
 			pageSize := 50
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize": %d}`, user.ApiKey, pageSize))
 
-			respObj, _ := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, _ := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if len(respObj) != 50 {
 				t.Fatalf("got list of length %d, but expected list of length %d", len(respObj), pageSize)
@@ -83,7 +84,7 @@ func TestQuotes(t *testing.T) {
 			language := "icelandic"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s"}`, user.ApiKey, language))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -101,7 +102,7 @@ func TestQuotes(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"reverse":%s}}`, user.ApiKey, "true"))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -120,7 +121,7 @@ func TestQuotes(t *testing.T) {
 			orderBy := "id"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"%s","minimum":"%d"}}`, user.ApiKey, orderBy, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -139,7 +140,7 @@ func TestQuotes(t *testing.T) {
 			maximum := 5
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"length","maximum":"%d"}}`, user.ApiKey, maximum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -159,7 +160,7 @@ func TestQuotes(t *testing.T) {
 			maximum := 11
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"length","maximum":"%d", "minimum":"%d"}}`, user.ApiKey, maximum, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -178,7 +179,7 @@ func TestQuotes(t *testing.T) {
 			maximum := 10
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"length","maximum":"%d","reverse":true}}`, user.ApiKey, maximum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -193,11 +194,11 @@ func TestQuotes(t *testing.T) {
 		})
 
 		t.Run("Should return first 50 quotes (ordered by most popular, i.e. DESC count)", func(t *testing.T) {
-			handlers.DirectFetchQuotesCountIncrement([]int{1})
+			handlers.DirectFetchQuotesCountIncrement(api.Repositories, []int{1})
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"%s"}}`, user.ApiKey, "popularity"))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -215,7 +216,7 @@ func TestQuotes(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","orderConfig":{"orderBy":"%s","reverse":true}}`, user.ApiKey, "popularity"))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -233,7 +234,7 @@ func TestQuotes(t *testing.T) {
 			pageSize := 100
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize":%d}}`, user.ApiKey, pageSize))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if errResponse.StatusCode != 200 {
 				t.Fatalf("got error %s, but expected an empty errormessage", errResponse.Message)
@@ -250,7 +251,7 @@ func TestQuotes(t *testing.T) {
 			minimum := 250000
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize":%d, "orderConfig":{"minimum":"%d"}}}`, user.ApiKey, pageSize, minimum))
 
-			respObj, errResponse := requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse := requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			objToFetch := respObj[50]
 
@@ -266,7 +267,7 @@ func TestQuotes(t *testing.T) {
 			page := 1
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","pageSize":%d, "page":%d, "orderConfig":{"minimum":"%d"}}}`, user.ApiKey, pageSize, page, minimum))
 
-			respObj, errResponse = requestAndReturnArray(jsonStr, GetQuotesList)
+			respObj, errResponse = requestAndReturnArray(jsonStr, api.GetQuotesList)
 
 			if objToFetch.QuoteId != respObj[0].QuoteId {
 				t.Fatalf("got %+v, but expected %+v", respObj[0], objToFetch)
@@ -282,7 +283,7 @@ func TestQuotes(t *testing.T) {
 
 			quoteId := 1
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","qods": [{"id":%d, "date":""}]}`, godUser.ApiKey, quoteId))
-			_, response := requestAndReturnArray(jsonStr, SetQuoteOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetQuoteOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -296,7 +297,7 @@ func TestQuotes(t *testing.T) {
 			date2 := "2020-12-21"
 			quoteId2 := 3
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","qods": [{"id":%d, "date":"%s"},{"id":%d, "date":"%s"}]}`, godUser.ApiKey, quoteId1, date1, quoteId2, date2))
-			_, response := requestAndReturnArray(jsonStr, SetQuoteOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetQuoteOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -305,7 +306,7 @@ func TestQuotes(t *testing.T) {
 
 		t.Run("Should get Quote of the day", func(t *testing.T) {
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s"}`, user.ApiKey, "english"))
-			quote := requestAndReturnSingle(jsonStr, GetQuoteOfTheDay)
+			quote := requestAndReturnSingle(jsonStr, api.GetQuoteOfTheDay)
 
 			if quote.Quote == "" {
 				t.Fatalf("Expected the quote of the day but got an empty quote %+v", quote)
@@ -324,7 +325,7 @@ func TestQuotes(t *testing.T) {
 			quoteId := 1111
 			date := "1998-06-16"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","qods": [{"id":%d, "date":"%s"}]}`, godUser.ApiKey, quoteId, date))
-			_, response := requestAndReturnArray(jsonStr, SetQuoteOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetQuoteOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -332,7 +333,7 @@ func TestQuotes(t *testing.T) {
 			//Get History:
 
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s"}`, user.ApiKey, "english"))
-			quotes, _ := requestAndReturnArray(jsonStr, GetQODHistory)
+			quotes, _ := requestAndReturnArray(jsonStr, api.GetQODHistory)
 
 			if len(quotes) == 0 {
 				t.Fatalf("Expected the history of QOD but got an empty list: %+v", quotes)
@@ -368,7 +369,7 @@ func TestQuotes(t *testing.T) {
 			quoteId := 666
 			date := "2021-06-04"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","qods": [{"id":%d, "date":"%s"}]}`, godUser.ApiKey, quoteId, date))
-			_, response := requestAndReturnArray(jsonStr, SetQuoteOfTheDay)
+			_, response := requestAndReturnArray(jsonStr, api.SetQuoteOfTheDay)
 			if response.StatusCode != 200 {
 				t.Fatalf("Expected a succesful insert but got %+v", response)
 			}
@@ -377,7 +378,7 @@ func TestQuotes(t *testing.T) {
 
 			minimum := "2021-06-04"
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language":"%s", "minimum":"%s"}`, user.ApiKey, "english", minimum))
-			quotes, _ := requestAndReturnArray(jsonStr, GetQODHistory)
+			quotes, _ := requestAndReturnArray(jsonStr, api.GetQODHistory)
 
 			if len(quotes) == 0 {
 				t.Fatalf("Expected the history of QOD but got an empty list: %+v", quotes)
@@ -423,13 +424,13 @@ func TestQuotes(t *testing.T) {
 		t.Run("Should return a random quote", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s"}`, user.ApiKey))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 
 			if firstRespObj.Quote == "" {
 				t.Fatalf("Expected a random quote but got an empty quote")
 			}
 
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 
 			if secondRespObj.QuoteId == firstRespObj.QuoteId {
 				t.Fatalf("Expected two different quotes but got the same quote twice which is higly improbable")
@@ -441,13 +442,13 @@ func TestQuotes(t *testing.T) {
 			teddyName := "Theodore Roosevelt"
 			teddyAuthor := getAuthor(teddyName, user.ApiKey)
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","authorId": %d}`, user.ApiKey, teddyAuthor.Id))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 
 			if firstRespObj.Name != teddyName {
 				t.Fatalf("got %s, expected %s", firstRespObj.Name, teddyName)
 			}
 
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 
 			if secondRespObj.AuthorId != firstRespObj.AuthorId {
 				t.Fatalf("got author with id %d, expected author with id %d", secondRespObj.AuthorId, firstRespObj.AuthorId)
@@ -464,11 +465,11 @@ func TestQuotes(t *testing.T) {
 			topicName := "motivational"
 			topicId := getTopicId(topicName, user.ApiKey)
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","topicId": %d}`, user.ApiKey, topicId))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if firstRespObj.TopicName != topicName {
 				t.Fatalf("got topicname: %s, expected %s", firstRespObj.TopicName, topicName)
 			}
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if secondRespObj.TopicId != firstRespObj.TopicId {
 				t.Fatalf("got topic with id %d, expected topic with id %d", secondRespObj.TopicId, firstRespObj.TopicId)
 			}
@@ -482,11 +483,11 @@ func TestQuotes(t *testing.T) {
 
 			language := "english"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s"}`, user.ApiKey, language))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if firstRespObj.IsIcelandic {
 				t.Fatalf("first response, got an IcelandicQuote but expected an English quote")
 			}
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if secondRespObj.IsIcelandic {
 				t.Fatalf("second response, got an IcelandicQuote but expected an English quote")
 			}
@@ -500,11 +501,11 @@ func TestQuotes(t *testing.T) {
 
 			language := "Icelandic"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s"}`, user.ApiKey, language))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if !firstRespObj.IsIcelandic {
 				t.Fatalf("first response, got an EnglishQuote but expected an Icelandic quote")
 			}
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if !secondRespObj.IsIcelandic {
 				t.Fatalf("second response, got an EnglishQuote, %+v, but expected an Icelandic quote", secondRespObj)
 			}
@@ -518,14 +519,14 @@ func TestQuotes(t *testing.T) {
 
 			searchString := "love"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString":"%s"}`, user.ApiKey, searchString))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			regexStub := searchString[:3]
 			m1 := regexp.MustCompile(regexStub)
 			if !m1.Match([]byte(strings.ToLower(firstRespObj.Quote))) {
 				t.Fatalf("first response, got the quote %+v that does not contain the searchString %s", firstRespObj, regexStub)
 			}
 
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if !m1.Match([]byte(strings.ToLower(secondRespObj.Quote))) {
 				t.Fatalf("second response, got the quote %+v that does not contain the searchString %s", secondRespObj, regexStub)
 			}
@@ -540,7 +541,7 @@ func TestQuotes(t *testing.T) {
 
 			searchString := "þitt"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString":"%s"}`, user.ApiKey, searchString))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			m1 := regexp.MustCompile(searchString)
 			if !m1.Match([]byte(firstRespObj.Quote)) {
 				t.Fatalf("first response, got the quote %+v that does not contain the searchString %s", firstRespObj, searchString)
@@ -550,7 +551,7 @@ func TestQuotes(t *testing.T) {
 				t.Fatalf("first response, got the quote %+v which is in English but expected it to be in icelandic", firstRespObj)
 			}
 
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if !m1.Match([]byte(secondRespObj.Quote)) {
 				t.Fatalf("second response, got the quote %+v that does not contain the searchString %s", secondRespObj, searchString)
 			}
@@ -566,7 +567,7 @@ func TestQuotes(t *testing.T) {
 			topicId := getTopicId(topicName, user.ApiKey)
 			searchString := "strong"
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString":"%s","topicId": %d}`, user.ApiKey, searchString, topicId))
-			firstRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			firstRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 
 			if firstRespObj.TopicName != topicName {
 				t.Fatalf("got %s, expected %s", firstRespObj.TopicName, topicName)
@@ -577,7 +578,7 @@ func TestQuotes(t *testing.T) {
 				t.Fatalf("first response, got the quote %+v that does not contain the searchString %s", firstRespObj, searchString)
 			}
 
-			secondRespObj := requestAndReturnSingle(jsonStr, GetRandomQuote)
+			secondRespObj := requestAndReturnSingle(jsonStr, api.GetRandomQuote)
 			if !m1.Match([]byte(secondRespObj.Quote)) {
 				t.Fatalf("second response, got the quote %+v that does not contain the searchString %s", secondRespObj, searchString)
 			}
@@ -607,7 +608,7 @@ func getTopicId(topicName string, apiKey string) int {
 	request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 	response := httptest.NewRecorder()
 
-	GetTopic(response, request)
+	api.GetTopic(response, request)
 
 	var respObj []structs.TestApiResponse
 	_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -629,7 +630,7 @@ func getAuthor(searchString string, apiKey string) structs.TestApiResponse {
 	request, _ := http.NewRequest(http.MethodGet, "/api/search/authors", bytes.NewBuffer(jsonStr))
 	response := httptest.NewRecorder()
 
-	SearchAuthorsByString(response, request)
+	api.SearchAuthorsByString(response, request)
 
 	var respObj []structs.TestApiResponse
 	_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -642,7 +643,7 @@ func getAuthorsById(authorIds Set, apiKey string) []structs.TestApiResponse {
 	request, _ := http.NewRequest(http.MethodGet, "/api/search/authors", bytes.NewBuffer(jsonStr))
 	response := httptest.NewRecorder()
 
-	GetAuthorsById(response, request)
+	api.GetAuthorsById(response, request)
 
 	var respObj []structs.TestApiResponse
 	_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -655,7 +656,7 @@ func getQuotes(searchString string, apiKey string) []structs.TestApiResponse {
 	request, _ := http.NewRequest(http.MethodGet, "/api/search/quotes", bytes.NewBuffer(jsonStr))
 	response := httptest.NewRecorder()
 
-	SearchQuotesByString(response, request)
+	api.SearchQuotesByString(response, request)
 
 	var respObj []structs.TestApiResponse
 	_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -668,7 +669,7 @@ func getRequestAndResponseForTest(jsonStr []byte) (*httptest.ResponseRecorder, *
 	return response, request
 }
 
-//TODO: Give a better name,more intuitive
+// TODO: Give a better name,more intuitive
 func getObjNr26(searchString string, fn httpRequest, apiKey string) (structs.TestApiResponse, error) {
 	pageSize := 100
 	var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "%s", "pageSize":%d}`, apiKey, searchString, pageSize))
@@ -722,3 +723,202 @@ func requestAndReturnArrayAuthors(jsonStr []byte, fn httpRequest) ([]structs.Tes
 	}
 	return respObj, errorResp
 }
+
+//TestQuotesWithFixtures tests the quotes by id, the lists, the random quote and the quote of the day against the fixtures
+func TestQuotesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		t.Run("should return Quotes with id 1, 2 and 3", func(t *testing.T) {
+			quoteIds := Set{1, 2, 3}
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": quoteIds}, &respObj)
+			if len(respObj) != len(quoteIds) {
+				t.Fatalf("got list of length %d but expected list of length %d", len(respObj), len(quoteIds))
+			}
+			for idx, quote := range respObj {
+				if quote.QuoteId != quoteIds[idx] {
+					t.Fatalf("got %d, expected %d", quote.QuoteId, quoteIds[idx])
+				}
+			}
+		})
+
+		t.Run("should return the most popular quote first", func(t *testing.T) {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuotesList, jsonBody{"apiKey": apiKey, "orderConfig": jsonBody{"orderBy": "popularity"}}, &respObj)
+			if len(respObj) < 2 || respObj[0].QuoteCount < respObj[1].QuoteCount {
+				t.Fatalf("expected quotes ordered by popularity but got %+v", respObj)
+			}
+		})
+
+		t.Run("should return a random Icelandic quote", func(t *testing.T) {
+			var respObj structs.TestApiResponse
+			requestAndDecode(t, testApi.GetRandomQuote, jsonBody{"apiKey": apiKey, "language": "icelandic"}, &respObj)
+			if respObj.QuoteId == 0 {
+				t.Fatalf("expected a random quote but got %+v", respObj)
+			}
+		})
+
+		runStatusTests(t, []statusTest{
+			{"should return 404 when no quote matches", testApi.GetRandomQuote, jsonBody{"apiKey": apiKey, "searchString": "xylophone"}, http.StatusNotFound},
+		})
+
+		t.Run("should set and get the quote of the day", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should set the quote of the day", testApi.SetQuoteOfTheDay, jsonBody{"apiKey": godApiKey, "qods": []jsonBody{{"id": 17}}}, http.StatusOK},
+			})
+
+			var respObj structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQuoteOfTheDay, jsonBody{"apiKey": apiKey}, &respObj)
+			if respObj.QuoteId != 17 {
+				t.Fatalf("got QOD %+v but expected the quote with id 17", respObj)
+			}
+
+			var history []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetQODHistory, jsonBody{"apiKey": apiKey}, &history)
+			if len(history) != 1 || history[0].QuoteId != 17 {
+				t.Fatalf("got QOD history %+v but expected only the quote with id 17", history)
+			}
+		})
+	})
+}
+
+//TestCreateQuotesWithFixtures tests creating public and private quotes against the fixtures
+func TestCreateQuotesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		createQuote := func(body jsonBody) (structs.QuoteAPIModel, int) {
+			var quote structs.QuoteAPIModel
+			statusCode := requestAndDecode(t, testApi.CreateQuote, body, &quote)
+			return quote, statusCode
+		}
+		quoteIds := func(fn httpRequest, body jsonBody) []int {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, fn, body, &respObj)
+			ids := []int{}
+			for _, quote := range respObj {
+				ids = append(ids, quote.QuoteId)
+			}
+			return ids
+		}
+
+		t.Run("should create a public quote for a new author", func(t *testing.T) {
+			quote, statusCode := createQuote(jsonBody{"apiKey": godApiKey, "quote": "Stay hungry, stay foolish.", "author": "Steve Jobs", "topics": []string{"Motivational"}})
+			if statusCode != http.StatusOK || quote.Id == 0 || quote.IsPrivate {
+				t.Fatalf("got status code %d and %+v, want a new public quote", statusCode, quote)
+			}
+			refreshViews(t, testApi)
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": godApiKey, "searchString": "hungry foolish"}, &respObj)
+			if len(respObj) == 0 || respObj[0].QuoteId != quote.Id || respObj[0].Name != "Steve Jobs" {
+				t.Fatalf("got %+v, want the new quote to be searchable", respObj)
+			}
+		})
+
+		t.Run("should only return a private quote for its creator", func(t *testing.T) {
+			quote, statusCode := createQuote(jsonBody{"apiKey": apiKey, "quote": "Ég er kominn heim.", "authorId": 8, "language": "icelandic", "private": true})
+			if statusCode != http.StatusOK || !quote.IsPrivate || quote.AuthorId != 8 || !quote.IsIcelandic {
+				t.Fatalf("got status code %d and %+v, want a new private Icelandic quote by author 8", statusCode, quote)
+			}
+			refreshViews(t, testApi)
+
+			for _, test := range []struct {
+				name string
+				fn   httpRequest
+				body jsonBody
+				want []int
+			}{
+				{"the creator", testApi.GetQuotes, jsonBody{"apiKey": apiKey, "ids": []int{quote.Id}}, []int{quote.Id}},
+				{"another user", testApi.GetQuotes, jsonBody{"apiKey": godApiKey, "ids": []int{quote.Id}}, []int{}},
+				{"the search", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "kominn heim"}, []int{}},
+			} {
+				if ids := quoteIds(test.fn, test.body); fmt.Sprint(ids) != fmt.Sprint(test.want) {
+					t.Fatalf("got %v for %s, want %v", ids, test.name, test.want)
+				}
+			}
+
+			var errorResp structs.ErrorResponse
+			requestAndDecode(t, testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "Ég er kominn heim.", "authorId": 8, "private": true}, &errorResp)
+			if errorResp.StatusCode != http.StatusConflict || !strings.Contains(errorResp.Message, fmt.Sprintf("the id %d", quote.Id)) {
+				t.Fatalf("got %+v for the creator's own duplicate, want a conflict with the id %d", errorResp, quote.Id)
+			}
+
+			//Another user's private quote is not a duplicate, a 409 would give it away
+			public, statusCode := createQuote(jsonBody{"apiKey": godApiKey, "quote": "Ég er kominn heim.", "author": "Someone"})
+			if statusCode != http.StatusOK || public.Id == quote.Id || public.IsPrivate {
+				t.Fatalf("got status code %d and %+v, want a new public quote beside the private one", statusCode, public)
+			}
+		})
+
+		t.Run("should not count a deleted quote as a duplicate", func(t *testing.T) {
+			deleteQuote := jsonBody{"apiKey": godApiKey, "type": "quotes", "id": 1}
+			runStatusTests(t, []statusTest{
+				{"should delete the quote", testApi.DeleteItem, deleteQuote, http.StatusOK},
+			})
+			quote, statusCode := createQuote(jsonBody{"apiKey": godApiKey, "quote": "Float like a butterfly, sting like a bee.", "authorId": 1})
+			if statusCode != http.StatusOK || quote.Id == 1 {
+				t.Fatalf("got status code %d and %+v, want a new quote in place of the deleted one", statusCode, quote)
+			}
+			runStatusTests(t, []statusTest{
+				{"should not restore the replaced quote", testApi.RestoreItem, deleteQuote, http.StatusConflict},
+			})
+		})
+
+		runStatusTests(t, []statusTest{
+			{"should refuse a duplicate", testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "Float like a butterfly, sting like a bee.", "authorId": 1}, http.StatusConflict},
+			{"should refuse an unknown author", testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "A brand new quote.", "authorId": 9999}, http.StatusNotFound},
+			{"should refuse an unknown language", testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "A brand new quote.", "author": "Someone", "language": "klingon"}, http.StatusBadRequest},
+			{"should refuse an empty quote", testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "  ", "author": "Someone"}, http.StatusBadRequest},
+		})
+	})
+}
+
+//TestRelatedQuotesWithFixtures tests the quotes related to a quote against the fixtures
+func TestRelatedQuotesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		related := func(body jsonBody) ([]int, int) {
+			var respObj []structs.TestApiResponse
+			body["apiKey"] = apiKey
+			statusCode := requestAndDecode(t, testApi.GetRelatedQuotes, body, &respObj)
+			quoteIds := []int{}
+			for _, quote := range respObj {
+				quoteIds = append(quoteIds, quote.QuoteId)
+			}
+			return quoteIds, statusCode
+		}
+
+		t.Run("should rank the quotes with the same words and topics first", func(t *testing.T) {
+			//Quote 19 has the word life and is in the topic life, 6 and 17 are only in the topic and 11 and 10 only share a
+			//word, life and would
+			quoteIds, _ := related(jsonBody{"id": 5})
+			if fmt.Sprint(quoteIds) != "[19 6 17 11 10]" {
+				t.Fatalf("got %v, want 19 first, then 6, 17, 11 and 10", quoteIds)
+			}
+		})
+
+		t.Run("should rank, or leave out, the quotes by the same author", func(t *testing.T) {
+			quoteIds, _ := related(jsonBody{"id": 5, "sameAuthor": true})
+			if len(quoteIds) != 7 || quoteIds[0] != 6 || !containsInt(quoteIds, 4) || !containsInt(quoteIds, 7) {
+				t.Fatalf("got %v, want Nietzsche's 6 first and his 4 and 7 as well", quoteIds)
+			}
+
+			quoteIds, _ = related(jsonBody{"id": 5, "excludeSameAuthor": true, "pageSize": 2})
+			if len(quoteIds) != 2 || quoteIds[0] != 19 || quoteIds[1] != 17 {
+				t.Fatalf("got %v, want 19 and 17 without Nietzsche's 6", quoteIds)
+			}
+
+			quoteIds, _ = related(jsonBody{"id": 5, "sameAuthor": true, "language": "icelandic"})
+			if len(quoteIds) != 1 || quoteIds[0] != 7 {
+				t.Fatalf("got %v, want only Nietzsche's Icelandic quote", quoteIds)
+			}
+		})
+
+		runStatusTests(t, []statusTest{
+			{"should answer an unknown quote with 404", testApi.GetRelatedQuotes, jsonBody{"apiKey": apiKey, "id": 999}, http.StatusNotFound},
+			{"should refuse both sameAuthor and excludeSameAuthor", testApi.GetRelatedQuotes, jsonBody{"apiKey": apiKey, "id": 5, "sameAuthor": true, "excludeSameAuthor": true}, http.StatusBadRequest},
+		})
+	})
+}
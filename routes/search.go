@@ -2,58 +2,53 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"regexp"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
+// searchStrategyHeader is the header with the strategy the quote searches matched the first result with, fulltext, or
+// phrase / trigram when the searchString is only stop words
+const searchStrategyHeader = "X-Search-Strategy"
+
 // swagger:route POST /search SEARCH SearchByString
-// Search for quotes / authors by a general string-search that searches both in the names of the authors and the quotes themselves
+// Search for quotes / authors by a general string-search that searches both in the names of the authors and the quotes themselves.
+// Each result has the strategy it was matched with, the one of the first result is in the X-Search-Strategy header
 //
 // responses:
-//  200: topicViewsResponse
+//  200: searchQuotesResponse
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
 // SearchByString handles POST requests to search for quotes / authors by a search-string
-func SearchByString(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) SearchByString(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
-	var topicResults []structs.TopicViewDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := getBasePointer(requestBody)
-	//Order by authorid to have definitive order (when for examplke some quotes rank the same for plain, phrase, general and similarity)
-	dbPointer = dbPointer.
-		Where("( tsv @@ plainq OR tsv @@ phraseq OR ? % ANY(STRING_TO_ARRAY(name,' ')) OR tsv @@ generalq)", requestBody.SearchString).
-		Clauses(clause.OrderBy{
-			Expression: clause.Expr{SQL: "phraserank DESC,similarity(name, ?) DESC, plainrank DESC, generalrank DESC, author_id DESC", Vars: []interface{}{requestBody.SearchString}, WithoutParentheses: true},
-		})
-
-	//Particular language search
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err := pagination(requestBody, dbPointer).
-		Find(&topicResults).Error
+	searched, err := api.Quotes.Search(requestBody)
 
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when querying DB in SearchByString: %s", err)
-		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		writeSearchError(rw, err, "SearchByString")
 		return
 	}
+	rw.Header().Set(searchStrategyHeader, searched.Strategy)
 
-	//Update popularity in background!
-	go handlers.TopicViewAppearInSearchCountIncrement(topicResults)
+	topicResults := searched.Results
 	apiResults := structs.ConvertToTopicViewsAPIModel(topicResults)
-	json.NewEncoder(rw).Encode(apiResults)
+	if err = api.citeTopicViews(requestBody, apiResults); err != nil {
+		writeSourcesError(rw, err, "SearchByString")
+		return
+	}
+
+	//Update popularity in background!
+	api.offline(func() error { return handlers.TopicViewAppearInSearchCountIncrement(api.Repositories, topicResults) })
+	api.writeSearchResults(rw, requestBody, apiResults, len(apiResults), repository.LexemeView, searchedFacets(searched), searchedTotal(searched), "SearchByString")
 }
 
 // swagger:route POST /search/authors SEARCH SearchAuthorsByString
@@ -66,28 +61,13 @@ func SearchByString(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // SearchAuthorsByString handles POST requests to search for authors by a search-string
-func SearchAuthorsByString(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) SearchAuthorsByString(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
 
-	var results []structs.AuthorDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	//Order by authorid to have definitive order (when for examplke some names rank the same for similarity), same for why quote_id
-	//% is same as SIMILARITY but with default threshold 0.3
-	dbPointer := handlers.Db.Table("authors").
-		Where("( tsv @@ plainto_tsquery(?) OR (?) % ANY(STRING_TO_ARRAY(name,' ')) )", requestBody.SearchString, requestBody.SearchString).
-		Clauses(clause.OrderBy{
-			Expression: clause.Expr{SQL: "similarity(name, ?) DESC, id DESC", Vars: []interface{}{requestBody.SearchString}, WithoutParentheses: true},
-		})
-
-	//Particular language search
-	dbPointer = authorLanguageSQL(requestBody.Language, dbPointer)
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err := pagination(requestBody, dbPointer).
-		Find(&results).Error
-	//  500: internalServerErrorResponse
+	results, err := api.Authors.Search(requestBody)
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
 		log.Printf("Got error when querying DB in SearchAuthorsByString: %s", err)
@@ -96,81 +76,75 @@ func SearchAuthorsByString(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	//Update popularity in background!
-	go handlers.AuthorsAppearInSearchCountIncrement(results)
+	api.offline(func() error { return handlers.AuthorsAppearInSearchCountIncrement(api.Repositories, results) })
 
-	authorsAPI := structs.ConvertToAuthorsAPIModel(results)
-	json.NewEncoder(rw).Encode(authorsAPI)
+	authorsAPI, err := api.withAliases(results)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when getting the aliases in SearchAuthorsByString: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	api.writeSearchResults(rw, requestBody, authorsAPI, len(authorsAPI), repository.AuthorsLexemeView, nil, api.Authors.SearchTotal, "SearchAuthorsByString")
 }
 
 // swagger:route POST /search/quotes SEARCH SearchQuotesByString
-// Quotes search. Searching quotes by a given search string
+// Quotes search. Searching quotes by a given search string. Each result has the strategy it was matched with, the one
+// of the first result is in the X-Search-Strategy header
 // responses:
-//  200: topicViewsResponse
+//  200: searchQuotesResponse
 //  400: incorrectBodyStructureResponse
 //  500: internalServerErrorResponse
 
 // SearchQuotesByString handles POST requests to search for quotes by a search-string
-func SearchQuotesByString(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) SearchQuotesByString(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
-
-	var topicResults []structs.TopicViewDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := getBasePointer(requestBody)
-	dbPointer = dbPointer.Where("( quote_tsv @@ plainq OR quote_tsv @@ phraseq OR quote_tsv @@ generalq)")
-
-	if requestBody.AuthorId > 0 {
-		dbPointer = dbPointer.Where("author_id = ?", requestBody.AuthorId)
+	if err := api.resolveAuthor(rw, &requestBody); err != nil {
+		return
 	}
 
-	//Order by quote_id to have definitive order (when for examplke some quotes rank the same for plain, phrase and general)
-	dbPointer = dbPointer.
-		Clauses(clause.OrderBy{
-			Expression: clause.Expr{SQL: "plainrank DESC, phraserank DESC, generalrank DESC, quote_id DESC", Vars: []interface{}{}, WithoutParentheses: true},
-		})
-
-	//Particular language search
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err := pagination(requestBody, dbPointer).
-		Find(&topicResults).Error
+	searched, err := api.Quotes.SearchQuotes(requestBody)
 
 	if err != nil {
-		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when querying DB in SearchQuotesByString: %s", err)
-		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		writeSearchError(rw, err, "SearchQuotesByString")
 		return
 	}
+	rw.Header().Set(searchStrategyHeader, searched.Strategy)
 
-	//Update popularity in background!
-	go handlers.TopicViewAppearInSearchCountIncrement(topicResults)
+	topicResults := searched.Results
 	apiResults := structs.ConvertToTopicViewsAPIModel(topicResults)
-	json.NewEncoder(rw).Encode(apiResults)
+	if err = api.citeTopicViews(requestBody, apiResults); err != nil {
+		writeSourcesError(rw, err, "SearchQuotesByString")
+		return
+	}
 
+	//Update popularity in background!
+	api.offline(func() error { return handlers.TopicViewAppearInSearchCountIncrement(api.Repositories, topicResults) })
+	api.writeSearchResults(rw, requestBody, apiResults, len(apiResults), repository.QuotesLexemeView, searchedFacets(searched), searchedTotal(searched), "SearchQuotesByString")
 }
 
-//getBasePointer returns a base DB pointer for a table for a thorough full text search
-func getBasePointer(requestBody structs.Request) *gorm.DB {
-	table := "searchview"
-	//TODO: Validate that this topicId exists
-	if requestBody.TopicId > 0 {
-		table = "topicsview"
-	}
-	m1 := regexp.MustCompile(` `)
-	phrasesearch := m1.ReplaceAllString(requestBody.SearchString, " <-> ")
-	generalsearch := m1.ReplaceAllString(requestBody.SearchString, " | ")
-	dbPointer := handlers.Db.Table(table+", plainto_tsquery(?) as plainq, to_tsquery(?) as phraseq,to_tsquery(?) as generalq ",
-		requestBody.SearchString, phrasesearch, generalsearch).Select("*, ts_rank(quote_tsv, plainq) as plainrank, ts_rank(quote_tsv, phraseq) as phraserank, ts_rank(quote_tsv, generalq) as generalrank")
-
-	if requestBody.TopicId > 0 {
-		dbPointer = dbPointer.Where("topic_id = ?", requestBody.TopicId)
-	}
-	return dbPointer
+// searchedFacets returns the facets the quote search counted along with its results, see writeSearchResults
+func searchedFacets(searched structs.SearchResultsDBModel) func(structs.Request, int) (structs.FacetsDBModel, error) {
+	return func(structs.Request, int) (structs.FacetsDBModel, error) { return searched.Facets, nil }
 }
 
-func pagination(requestBody structs.Request, dbPointer *gorm.DB) *gorm.DB {
-	return dbPointer.Limit(requestBody.PageSize).
-		Offset(requestBody.Page * requestBody.PageSize)
+// searchedTotal returns the total the quote search counted along with its results, see newPage
+func searchedTotal(searched structs.SearchResultsDBModel) func(structs.Request) (structs.TotalDBModel, error) {
+	return func(structs.Request) (structs.TotalDBModel, error) { return searched.Total, nil }
+}
+
+// writeSearchError writes a 400 with the position of the error if the searchString is not a valid query, otherwise a 500
+func writeSearchError(rw http.ResponseWriter, err error, route string) {
+	var queryErr *repository.QueryError
+	if errors.As(err, &queryErr) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: queryErr.Error(), StatusCode: http.StatusBadRequest, Position: queryErr.Position})
+		return
+	}
+	rw.WriteHeader(http.StatusInternalServerError)
+	log.Printf("Got error when querying DB in %s: %s", route, err)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
 }
@@ -7,18 +7,20 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/Skjaldbaka17/quotes-api/structs"
 )
 
 func TestSearch(t *testing.T) {
+	requireDatabase(t)
 	user := createUser(t)
 	t.Run("Search Quotes By String", func(t *testing.T) {
 		t.Run("easy search should return list of quotes with Muhammad Ali as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Float like a butterfly sting like a bee"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchQuotesByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchQuotesByString)
 			firstAuthor := respObj[0].Name
 			want := "Muhammad Ali"
 			if firstAuthor != want {
@@ -29,7 +31,7 @@ func TestSearch(t *testing.T) {
 		t.Run("intermediate search should return list of quotes with Muhammad Ali as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "bee sting like a butterfly"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchQuotesByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchQuotesByString)
 			firstAuthor := respObj[0].Name
 			want := "Muhammad Ali"
 			if firstAuthor != want {
@@ -40,7 +42,7 @@ func TestSearch(t *testing.T) {
 		t.Run("hard search should return list of quotes with Muhammad Ali as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "bee butterfly float"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchQuotesByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchQuotesByString)
 			firstAuthor := respObj[0].Name
 			want := "Muhammad Ali"
 			if firstAuthor != want {
@@ -51,7 +53,7 @@ func TestSearch(t *testing.T) {
 		t.Run("Search for quote 'Happiness resides not in possessions...' inside topic 'inspirational' by supplying its topicid", func(t *testing.T) {
 			topicId := getTopicId("inspirational", user.ApiKey)
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Happiness resides not in possessions", "topicId":%d}`, user.ApiKey, topicId))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchQuotesByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchQuotesByString)
 			firstAuthorName := respObj[0].Name
 			want_author := "Democritus"
 			if firstAuthorName != want_author {
@@ -75,7 +77,7 @@ func TestSearch(t *testing.T) {
 		t.Run("easy search should return list of quotes with Friedrich Nietzsche as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Friedrich Nietzsche"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchAuthorsByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchAuthorsByString)
 			firstAuthor := respObj[0].Name
 			want := "Friedrich Nietzsche"
 			if firstAuthor != want {
@@ -86,7 +88,7 @@ func TestSearch(t *testing.T) {
 		t.Run("intermediate search should Return list of quotes with Joseph Stalin as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Stalin jseph"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchAuthorsByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchAuthorsByString)
 			firstAuthor := respObj[0].Name
 			want := "Joseph Stalin"
 			if firstAuthor != want {
@@ -97,7 +99,7 @@ func TestSearch(t *testing.T) {
 		t.Run("hard search should return list of quotes with Friedrich Nietzsche as first author", func(t *testing.T) {
 
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Niet Friedric"}`, user.ApiKey))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchAuthorsByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchAuthorsByString)
 			firstAuthor := respObj[0].Name
 			want := "Friedrich Nietzsche"
 			if firstAuthor != want {
@@ -115,7 +117,7 @@ func TestSearch(t *testing.T) {
 			t.Run("easy search should return list of quotes with Friedrich Nietzsche as first author", func(t *testing.T) {
 
 				var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Friedrich Nietzsche"}`, user.ApiKey))
-				respObj, _ := requestAndReturnArray(jsonStr, SearchByString)
+				respObj, _ := requestAndReturnArray(jsonStr, api.SearchByString)
 				firstAuthor := respObj[1].Name //Use index 1 because in index 0 there is an author talking extensively about Nietzsche
 				want := "Friedrich Nietzsche"
 				if firstAuthor != want {
@@ -126,7 +128,7 @@ func TestSearch(t *testing.T) {
 			t.Run("hard search should return list of quotes with Friedrich Nietzsche as first author", func(t *testing.T) {
 
 				var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Nietshe Friedr"}`, user.ApiKey))
-				respObj, _ := requestAndReturnArray(jsonStr, SearchByString)
+				respObj, _ := requestAndReturnArray(jsonStr, api.SearchByString)
 				firstAuthor := respObj[1].Name //Use index 1 because in index 0 there is an author talking extensively about Nietzsche
 				want := "Friedrich Nietzsche"
 				if firstAuthor != want {
@@ -139,7 +141,7 @@ func TestSearch(t *testing.T) {
 			t.Run("easy search should return list of quotes with Martin Luther as first author", func(t *testing.T) {
 
 				var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "If you are not allowed to Laugh in Heaven"}`, user.ApiKey))
-				respObj, _ := requestAndReturnArray(jsonStr, SearchByString)
+				respObj, _ := requestAndReturnArray(jsonStr, api.SearchByString)
 				firstAuthor := respObj[1].Name //Use index 1 because in index 0 there is an author talking extensively about Nietzsche
 				want := "Martin Luther"
 				if firstAuthor != want {
@@ -151,7 +153,7 @@ func TestSearch(t *testing.T) {
 		t.Run("General Search inside topic 'inspirational' by supplying its id, should return 'Michael Jordan' Quote", func(t *testing.T) {
 			topicId := getTopicId("inspirational", user.ApiKey)
 			var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","searchString": "Jordan Michel", "topicId":%d}`, user.ApiKey, topicId))
-			respObj, _ := requestAndReturnArray(jsonStr, SearchByString)
+			respObj, _ := requestAndReturnArray(jsonStr, api.SearchByString)
 			firstAuthorName := respObj[0].Name
 			want_author := "Michael Jordan"
 			if firstAuthorName != want_author {
@@ -169,7 +171,7 @@ func TestSearch(t *testing.T) {
 		t.Run("Search By string pagination", func(t *testing.T) {
 
 			searchString := "Love"
-			obj26, err := getObjNr26(searchString, SearchByString, user.ApiKey)
+			obj26, err := getObjNr26(searchString, api.SearchByString, user.ApiKey)
 
 			if err != nil {
 				t.Error(err)
@@ -180,7 +182,7 @@ func TestSearch(t *testing.T) {
 			request, _ := http.NewRequest(http.MethodPost, "/api/search", bytes.NewBuffer(jsonStr))
 			response := httptest.NewRecorder()
 
-			SearchByString(response, request)
+			api.SearchByString(response, request)
 			var respObj []structs.TestApiResponse
 			_ = json.Unmarshal(response.Body.Bytes(), &respObj)
 
@@ -196,7 +198,7 @@ func TestSearch(t *testing.T) {
 		t.Run("Search Authors By string pagination", func(t *testing.T) {
 
 			searchString := "Martin"
-			obj26, err := getObjNr26(searchString, SearchAuthorsByString, user.ApiKey)
+			obj26, err := getObjNr26(searchString, api.SearchAuthorsByString, user.ApiKey)
 
 			if err != nil {
 				t.Error(err)
@@ -207,7 +209,7 @@ func TestSearch(t *testing.T) {
 			request, _ := http.NewRequest(http.MethodPost, "/api/search/authors", bytes.NewBuffer(jsonStr))
 			response := httptest.NewRecorder()
 
-			SearchAuthorsByString(response, request)
+			api.SearchAuthorsByString(response, request)
 
 			var respObj []structs.TestApiResponse
 			_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -224,7 +226,7 @@ func TestSearch(t *testing.T) {
 		t.Run("Search Quotes By string pagination", func(t *testing.T) {
 
 			searchString := "Hate"
-			obj26, err := getObjNr26(searchString, SearchQuotesByString, user.ApiKey)
+			obj26, err := getObjNr26(searchString, api.SearchQuotesByString, user.ApiKey)
 
 			if err != nil {
 				t.Error(err)
@@ -235,7 +237,7 @@ func TestSearch(t *testing.T) {
 			request, _ := http.NewRequest(http.MethodPost, "/api/search/quotes", bytes.NewBuffer(jsonStr))
 			response := httptest.NewRecorder()
 
-			SearchQuotesByString(response, request)
+			api.SearchQuotesByString(response, request)
 
 			var respObj []structs.TestApiResponse
 			_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -256,3 +258,308 @@ func TestSearch(t *testing.T) {
 	})
 
 }
+
+//TestSearchWithFixtures tests the search routes and the query language against the fixtures
+func TestSearchWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		t.Run("should return the best match first", func(t *testing.T) {
+			for _, test := range []struct {
+				name string
+				fn   httpRequest
+				body jsonBody
+				want string
+			}{
+				{"quotes search", testApi.SearchQuotesByString, jsonBody{"searchString": "bee butterfly float"}, "Muhammad Ali"},
+				{"authors search", testApi.SearchAuthorsByString, jsonBody{"searchString": "Niet Friedric"}, "Friedrich Nietzsche"},
+				{"general search inside topic 1", testApi.SearchByString, jsonBody{"searchString": "Jordan Michel", "topicId": 1}, "Michael Jordan"},
+			} {
+				var respObj []structs.TestApiResponse
+				test.body["apiKey"] = apiKey
+				requestAndDecode(t, test.fn, test.body, &respObj)
+				if len(respObj) == 0 || respObj[0].Name != test.want || (test.body["topicId"] != nil && respObj[0].TopicId != test.body["topicId"]) {
+					t.Fatalf("got %+v from the %s, want %s first", respObj, test.name, test.want)
+				}
+			}
+		})
+
+		searchQuotes := func(searchString string) ([]int, int) {
+			var respObj []structs.TestApiResponse
+			statusCode := requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": searchString, "pageSize": 100}, &respObj)
+			quoteIds := []int{}
+			for _, row := range respObj {
+				quoteIds = append(quoteIds, row.QuoteId)
+			}
+			return quoteIds, statusCode
+		}
+
+		t.Run("should search with phrases, exclusions and OR", func(t *testing.T) {
+			if quoteIds, _ := searchQuotes(`"make the days count"`); len(quoteIds) != 1 || quoteIds[0] != 3 {
+				t.Fatalf("got %v, want only the quote with the phrase", quoteIds)
+			}
+			quoteIds, _ := searchQuotes("life -music")
+			if containsInt(quoteIds, 5) || !containsInt(quoteIds, 19) {
+				t.Fatalf("got %v, want the quotes about life without the one about music", quoteIds)
+			}
+			quoteIds, _ = searchQuotes("butterfly OR temptation")
+			if len(quoteIds) != 2 || !containsInt(quoteIds, 1) || !containsInt(quoteIds, 21) {
+				t.Fatalf("got %v, want the quotes with either word", quoteIds)
+			}
+			//The phrases are "busy life" and "music life", quote 5 has the latter and 19 has both words of the former apart
+			if quoteIds, _ = searchQuotes("busy OR music life"); len(quoteIds) < 2 || quoteIds[0] != 5 || !containsInt(quoteIds, 19) {
+				t.Fatalf("got %v, want the quote with the phrase of one of the alternatives first", quoteIds)
+			}
+			if quoteIds, statusCode := searchQuotes("don't & count it's"); statusCode != http.StatusOK || len(quoteIds) == 0 || quoteIds[0] != 3 {
+				t.Fatalf("got %v and status code %d, want punctuation to be searchable", quoteIds, statusCode)
+			}
+		})
+
+		t.Run("should filter the search by author, topic and language", func(t *testing.T) {
+			for searchString, want := range map[string][]int{
+				`author:"oscar wilde"`:                     {20, 21},
+				`topic:love -author:"William Shakespeare"`: {20},
+				"mig lang:Icelandic":                       {7},
+				"mig -lang:icelandic":                      {},
+			} {
+				quoteIds, _ := searchQuotes(searchString)
+				if len(quoteIds) != len(want) {
+					t.Fatalf("got %v for %s, want %v", quoteIds, searchString, want)
+				}
+				for _, quoteId := range want {
+					if !containsInt(quoteIds, quoteId) {
+						t.Fatalf("got %v for %s, want %v", quoteIds, searchString, want)
+					}
+				}
+			}
+		})
+
+		t.Run("should match Icelandic quotes typed without þ, ð, æ or accents", func(t *testing.T) {
+			for searchString, quoteId := range map[string]int{
+				"thad sem drepur":  7,
+				"Það sem drepur":   7,
+				`"deyr fe"`:        14,
+				"farsaelda fron":   16,
+				"island -fraendur": 16,
+			} {
+				if quoteIds, _ := searchQuotes(searchString); len(quoteIds) != 1 || quoteIds[0] != quoteId {
+					t.Fatalf("got %v for %s, want the quote with id %d", quoteIds, searchString, quoteId)
+				}
+			}
+
+			var highlighted []structs.TopicViewAPIModel
+			requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "fraendur", "highlight": structs.HighlightConfig{}}, &highlighted)
+			if len(highlighted) != 1 || !strings.Contains(highlighted[0].Highlight, "<b>frændur</b>") {
+				t.Fatalf("got %+v, want frændur highlighted", highlighted)
+			}
+
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.SearchAuthorsByString, jsonBody{"apiKey": apiKey, "searchString": "hallgrimsson"}, &respObj)
+			if len(respObj) != 1 || respObj[0].Name != "Jónas Hallgrímsson" {
+				t.Fatalf("got %+v, want Jónas Hallgrímsson", respObj)
+			}
+		})
+
+		t.Run("should refuse invalid queries with the position of the error", func(t *testing.T) {
+			for searchString, position := range map[string]int{
+				`don't "count`:  7,
+				"life OR":       6,
+				"OR life":       1,
+				"life - death":  6,
+				"author: Ali":   8,
+				"lang:klingon":  6,
+				`life author:"`: 13,
+			} {
+				var errorResp structs.ErrorResponse
+				statusCode := requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": searchString}, &errorResp)
+				if statusCode != http.StatusBadRequest || errorResp.Position != position {
+					t.Fatalf("got %+v for %s, want a 400 at position %d", errorResp, searchString, position)
+				}
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should refuse it in the general search", testApi.SearchByString, jsonBody{"apiKey": apiKey, "searchString": `"life`}, http.StatusBadRequest},
+				{"should refuse it in the random quote", testApi.GetRandomQuote, jsonBody{"apiKey": apiKey, "searchString": `"life`}, http.StatusBadRequest},
+			})
+		})
+	})
+}
+
+//TestHighlightWithFixtures tests the highlighted matches of the quote searches against the fixtures
+func TestHighlightWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		highlight := func(fn httpRequest, body jsonBody) ([]structs.TopicViewAPIModel, int) {
+			var results []structs.TopicViewAPIModel
+			body["apiKey"] = apiKey
+			statusCode := requestAndDecode(t, fn, body, &results)
+			return results, statusCode
+		}
+
+		t.Run("should highlight the matched words with the given markers", func(t *testing.T) {
+			results, _ := highlight(testApi.SearchQuotesByString, jsonBody{"searchString": "butterfly bee", "highlight": structs.HighlightConfig{Start: "[", Stop: "]"}})
+			if len(results) == 0 || results[0].Highlight != "Float like a [butterfly], sting like a [bee]." {
+				t.Fatalf("got %+v, want butterfly and bee highlighted", results)
+			}
+			results, _ = highlight(testApi.SearchByString, jsonBody{"searchString": "life", "topicId": 3, "highlight": structs.HighlightConfig{}})
+			if len(results) == 0 || !strings.Contains(strings.ToLower(results[0].Highlight), "<b>life</b>") {
+				t.Fatalf("got %+v, want life highlighted with the default markers inside the topic", results)
+			}
+			results, _ = highlight(testApi.SearchQuotesByString, jsonBody{"searchString": "butterfly"})
+			if len(results) == 0 || results[0].Highlight != "" {
+				t.Fatalf("got %+v, want no highlight unless asked for", results)
+			}
+		})
+
+		t.Run("should cut the highlight into fragments of at most maxWords words", func(t *testing.T) {
+			results, _ := highlight(testApi.SearchQuotesByString, jsonBody{"searchString": "apple", "highlight": structs.HighlightConfig{MaxWords: 5}})
+			if len(results) != 1 || results[0].Highlight != "still plant my <b>apple</b> tree" {
+				t.Fatalf("got %+v, want the last five words", results)
+			}
+			results, _ = highlight(testApi.SearchQuotesByString, jsonBody{"searchString": "failed OR life", "highlight": structs.HighlightConfig{MaxWords: 2, MaxFragments: 2}})
+			found := false
+			for _, result := range results {
+				if result.QuoteId == 11 {
+					found = result.Highlight == "<b>failed</b> over ... <b>life</b>. And"
+				}
+			}
+			if !found {
+				t.Fatalf("got %+v, want two fragments of two words of the quote with id 11", results)
+			}
+		})
+
+		runStatusTests(t, []statusTest{
+			{"should refuse markers with attributes", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "life", "highlight": structs.HighlightConfig{Start: `<b class="x">`}}, http.StatusBadRequest},
+			{"should refuse fragments of 1 word", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "life", "highlight": structs.HighlightConfig{MaxWords: 1}}, http.StatusBadRequest},
+			{"should refuse more than 10 fragments", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "life", "highlight": structs.HighlightConfig{MaxFragments: 11}}, http.StatusBadRequest},
+		})
+	})
+}
+
+//TestFacetsWithFixtures tests the facet counts and filters of the quote searches against the fixtures
+func TestFacetsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		search := func(fn httpRequest, body jsonBody) (structs.SearchResponseAPIModel, []structs.TopicViewAPIModel) {
+			body["apiKey"] = apiKey
+			response := callRoute(t, fn, body)
+			//Without facets the response is just the results
+			results := []structs.TopicViewAPIModel{}
+			searchResponse := structs.SearchResponseAPIModel{Results: &results}
+			if strings.HasPrefix(response.Body.String(), "[") {
+				json.Unmarshal(response.Body.Bytes(), &results)
+			} else {
+				json.Unmarshal(response.Body.Bytes(), &searchResponse)
+			}
+			return searchResponse, results
+		}
+
+		t.Run("should count all the matches by language, topic and author", func(t *testing.T) {
+			response, results := search(testApi.SearchQuotesByString, jsonBody{"searchString": "life OR mig", "pageSize": 1, "facets": structs.FacetConfig{Limit: 2}})
+			facets := response.Facets
+			if len(results) != 1 || facets == nil {
+				t.Fatalf("got %+v, want a page of 1 quote along with the facets", response)
+			}
+			if facets.Total != 4 || facets.Languages.English != 3 || facets.Languages.Icelandic != 1 {
+				t.Fatalf("got %+v, want 3 English and 1 Icelandic quote", facets)
+			}
+			if len(facets.Topics) != 2 || facets.Topics[0].Id != 3 || facets.Topics[0].Count != 2 || facets.Topics[1].Id != 1 {
+				t.Fatalf("got %+v, want life with 2 quotes and inspirational with 1", facets.Topics)
+			}
+			if len(facets.Authors) != 2 || facets.Authors[0].Name != "Friedrich Nietzsche" || facets.Authors[0].Count != 2 || facets.Authors[1].Id != 5 {
+				t.Fatalf("got %+v, want Friedrich Nietzsche with 2 quotes and Michael Jordan with 1", facets.Authors)
+			}
+
+			response, _ = search(testApi.SearchByString, jsonBody{"searchString": "life", "facets": structs.FacetConfig{}})
+			if response.Facets == nil || response.Facets.Total == 0 {
+				t.Fatalf("got %+v, want the facets of the general search", response)
+			}
+		})
+
+		t.Run("should narrow the search by the clicked facets", func(t *testing.T) {
+			for _, test := range []struct {
+				body     jsonBody
+				quoteIds []int
+			}{
+				{jsonBody{"searchString": "life OR mig", "topicIds": []int{3}}, []int{5, 19}},
+				{jsonBody{"searchString": "life OR mig", "topicIds": []int{3, 5}, "authorIds": []int{2}}, []int{5, 7}},
+				{jsonBody{"searchString": "life OR mig", "authorIds": []int{5, 10}, "language": "english"}, []int{11, 19}},
+			} {
+				_, results := search(testApi.SearchQuotesByString, test.body)
+				quoteIds := []int{}
+				for _, result := range results {
+					quoteIds = append(quoteIds, result.QuoteId)
+				}
+				if len(quoteIds) != len(test.quoteIds) || !containsInt(quoteIds, test.quoteIds[0]) || !containsInt(quoteIds, test.quoteIds[1]) {
+					t.Fatalf("got %v for %+v, want %v", quoteIds, test.body, test.quoteIds)
+				}
+			}
+		})
+
+		runStatusTests(t, []statusTest{
+			{"should refuse a facet limit above 50", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "life", "facets": structs.FacetConfig{Limit: 51}}, http.StatusBadRequest},
+		})
+	})
+}
+
+//TestSearchFallbackWithFixtures tests the fallback strategies of searches that are only stop words against the fixtures
+func TestSearchFallbackWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		search := func(searchString string, fn httpRequest) ([]int, string) {
+			response := callRoute(t, fn, jsonBody{"apiKey": godApiKey, "searchString": searchString})
+			var respObj []structs.TopicViewAPIModel
+			json.NewDecoder(response.Body).Decode(&respObj)
+			quoteIds := []int{}
+			for _, quote := range respObj {
+				quoteIds = append(quoteIds, quote.QuoteId)
+			}
+			strategy := response.Header().Get("X-Search-Strategy")
+			if len(respObj) > 0 && respObj[0].Strategy != strategy {
+				t.Fatalf("got %q in the header but the first result was matched with %q", strategy, respObj[0].Strategy)
+			}
+			return quoteIds, strategy
+		}
+
+		t.Run("should match a search string of stop words as a phrase", func(t *testing.T) {
+			for _, fn := range []httpRequest{testApi.SearchByString, testApi.SearchQuotesByString} {
+				quoteIds, strategy := search("To be, or not to be", fn)
+				if strategy != "phrase" || len(quoteIds) != 1 || quoteIds[0] != 17 {
+					t.Fatalf("got %v matched with %q, want Shakespeare's 17 matched with phrase", quoteIds, strategy)
+				}
+			}
+		})
+
+		t.Run("should match stop words no quote has in a row by trigrams", func(t *testing.T) {
+			quoteIds, strategy := search("to be or not to the", testApi.SearchQuotesByString)
+			if strategy != "trigram" || len(quoteIds) == 0 || quoteIds[0] != 17 {
+				t.Fatalf("got %v matched with %q, want Shakespeare's 17 first matched with trigram", quoteIds, strategy)
+			}
+		})
+
+		t.Run("should use the full text search for stop words with an OR or an excluded term", func(t *testing.T) {
+			for _, searchString := range []string{"to be OR not to be", "to be or not to be -question"} {
+				if _, strategy := search(searchString, testApi.SearchQuotesByString); strategy != "fulltext" {
+					t.Fatalf("got %q matched with %q, want fulltext", searchString, strategy)
+				}
+			}
+		})
+
+		t.Run("should use the full text search for mostly stop words with a word that is not one", func(t *testing.T) {
+			quoteIds, strategy := search("would be a music", testApi.SearchQuotesByString)
+			if strategy != "fulltext" || !containsInt(quoteIds, 5) {
+				t.Fatalf("got %v matched with %q, want 5 matched with fulltext", quoteIds, strategy)
+			}
+		})
+
+		t.Run("should use the full text search otherwise", func(t *testing.T) {
+			quoteIds, strategy := search("music", testApi.SearchQuotesByString)
+			if strategy != "fulltext" || !containsInt(quoteIds, 5) {
+				t.Fatalf("got %v matched with %q, want 5 matched with fulltext", quoteIds, strategy)
+			}
+		})
+	})
+}
@@ -0,0 +1,268 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// swagger:route POST /sources SOURCES ListSources
+// List the sources, i.e. the books, speeches, films and interviews quotes are cited from, ordered by title
+// responses:
+//	200: sourcesResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// ListSources handles POST requests to list a page of the sources whose title contains the searchString, of the given type
+func (api *Api) ListSources(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+	requestBody.Type = strings.ToLower(requestBody.Type)
+	if requestBody.Type != "" && !containsString(structs.SourceTypes, requestBody.Type) {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("The type should be one of %s", strings.Join(structs.SourceTypes, ", ")), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	sources, err := api.Sources.List(requestBody)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when querying DB in ListSources: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToSourcesAPIModel(sources))
+}
+
+// swagger:route POST /sources/new SOURCES CreateSource
+// Create a new source, a work that quotes can be cited from (is password protected)
+// responses:
+//	200: sourceResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// CreateSource handles POST requests to create a source
+func (api *Api) CreateSource(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	source, err := api.getSourceRequestBody(rw, r, &requestBody, false)
+	if err != nil {
+		return
+	}
+
+	if err = api.Sources.Create(&source); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when creating the source %+v: %s", source, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(source.ConvertToAPIModel())
+}
+
+// swagger:route POST /sources/update SOURCES UpdateSource
+// Change the title, type, year and url of a source (is password protected)
+// responses:
+//	200: sourceResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// UpdateSource handles POST requests to update the source with the given id
+func (api *Api) UpdateSource(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	source, err := api.getSourceRequestBody(rw, r, &requestBody, true)
+	if err != nil {
+		return
+	}
+
+	source, err = api.Sources.Update(source)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No source exists with the id %d", requestBody.Id), StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when updating the source %d: %s", requestBody.Id, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		json.NewEncoder(rw).Encode(source.ConvertToAPIModel())
+	}
+}
+
+// swagger:route POST /quotes/source SOURCES CiteQuote
+// Cite a quote's source (sourceId, 0 to remove it) and set whether its attribution is unverified, verified or misattributed (is password protected)
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// CiteQuote handles POST requests to set the source of the quote with the given quoteId and the status of its attribution
+func (api *Api) CiteQuote(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	status := strings.ToLower(requestBody.Status)
+	if status == "" {
+		status = structs.SourceUnverified
+	}
+	var message string
+	switch {
+	case requestBody.QuoteId <= 0:
+		message = "Please supply the quoteId of the quote"
+	case requestBody.SourceId < 0:
+		message = "The sourceId should be the id of a source, or 0 to remove the quote's source"
+	case !containsString(structs.SourceStatuses, status):
+		message = fmt.Sprintf("The status should be one of %s", strings.Join(structs.SourceStatuses, ", "))
+	}
+	if message != "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	err := api.Sources.Cite(requestBody.QuoteId, requestBody.SourceId, status)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The quote, or the source, does not exist", StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when citing the source %d for the quote %d: %s", requestBody.SourceId, requestBody.QuoteId, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("Successfully set the source of the quote %d as %s!", requestBody.QuoteId, status), StatusCode: http.StatusOK})
+	}
+}
+
+// getSourceRequestBody authorizes the GOD-tier user and validates the source in the request
+func (api *Api) getSourceRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, needsId bool) (structs.SourceDBModel, error) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return structs.SourceDBModel{}, err
+	}
+	if err := handlers.GetRequestBody(rw, r, requestBody, api.Repositories); err != nil {
+		return structs.SourceDBModel{}, err
+	}
+
+	source := structs.SourceDBModel{
+		Id:    requestBody.Id,
+		Title: strings.TrimSpace(requestBody.Title),
+		Type:  strings.ToLower(requestBody.Type),
+		Year:  requestBody.Year,
+		Url:   strings.TrimSpace(requestBody.Url),
+	}
+	if source.Type == "" {
+		source.Type = structs.SourceOther
+	}
+
+	var err error
+	switch {
+	case needsId && source.Id <= 0:
+		err = errors.New("please supply the id of the source")
+	case source.Title == "":
+		err = errors.New("please supply the title of the source")
+	case !containsString(structs.SourceTypes, source.Type):
+		err = fmt.Errorf("the type should be one of %s", strings.Join(structs.SourceTypes, ", "))
+	case source.Year > time.Now().Year():
+		err = errors.New("the year of the source can not be in the future")
+	case source.Url != "" && !validUrl(source.Url):
+		err = errors.New("the url of the source should be an absolute http or https url")
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+	}
+	return source, err
+}
+
+// sourcesOf returns the sources of the quotes by quote id, none unless the request asks for them through withSource
+func (api *Api) sourcesOf(requestBody structs.Request, quoteIds []int) (map[int]*structs.SourceAPIModel, error) {
+	sources := map[int]*structs.SourceAPIModel{}
+	if !requestBody.WithSource || len(quoteIds) == 0 {
+		return sources, nil
+	}
+	citations, err := api.Sources.ForQuotes(quoteIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, citation := range citations {
+		source := citation.ConvertToAPIModel()
+		sources[citation.QuoteId] = &source
+	}
+	return sources, nil
+}
+
+// citeSearchViews sets the sources of the quotes if the request asks for them
+func (api *Api) citeSearchViews(requestBody structs.Request, views []structs.SearchViewAPIModel) error {
+	quoteIds := []int{}
+	for _, view := range views {
+		quoteIds = append(quoteIds, view.QuoteId)
+	}
+	sources, err := api.sourcesOf(requestBody, quoteIds)
+	for idx := range views {
+		views[idx].Source = sources[views[idx].QuoteId]
+	}
+	return err
+}
+
+// citeTopicViews sets the sources of the quotes if the request asks for them
+func (api *Api) citeTopicViews(requestBody structs.Request, views []structs.TopicViewAPIModel) error {
+	quoteIds := []int{}
+	for _, view := range views {
+		quoteIds = append(quoteIds, view.QuoteId)
+	}
+	sources, err := api.sourcesOf(requestBody, quoteIds)
+	for idx := range views {
+		views[idx].Source = sources[views[idx].QuoteId]
+	}
+	return err
+}
+
+// citeQods sets the sources of the quotes of the day if the request asks for them
+func (api *Api) citeQods(requestBody structs.Request, qods []structs.QodViewAPIModel) error {
+	quoteIds := []int{}
+	for _, qod := range qods {
+		quoteIds = append(quoteIds, qod.QuoteId)
+	}
+	sources, err := api.sourcesOf(requestBody, quoteIds)
+	for idx := range qods {
+		qods[idx].Source = sources[qods[idx].QuoteId]
+	}
+	return err
+}
+
+// writeSourcesError writes the error from getting the sources of the quotes in the response
+func writeSourcesError(rw http.ResponseWriter, err error, route string) {
+	rw.WriteHeader(http.StatusInternalServerError)
+	log.Printf("Got error when getting the sources of the quotes in %s: %s", route, err)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+}
+
+func validUrl(link string) bool {
+	parsed, err := url.ParseRequestURI(link)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func containsString(list []string, item string) bool {
+	for _, listItem := range list {
+		if listItem == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestSourcesWithFixtures tests the sources and the citations of the quotes against the fixtures
+func TestSourcesWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		var source structs.SourceAPIModel
+
+		getQuotes := func(body jsonBody) []structs.SearchViewAPIModel {
+			var quotes []structs.SearchViewAPIModel
+			requestAndDecode(t, testApi.GetQuotes, body, &quotes)
+			return quotes
+		}
+
+		t.Run("should create and update a source as GOD", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.CreateSource, jsonBody{"apiKey": apiKey, "title": "The Greatest", "type": "book", "year": 1975}, http.StatusUnauthorized},
+				{"should refuse an unknown type", testApi.CreateSource, jsonBody{"apiKey": godApiKey, "title": "The Greatest", "type": "podcast"}, http.StatusBadRequest},
+			})
+
+			body := jsonBody{"apiKey": godApiKey, "title": "The Greatest", "type": "book", "year": 1975, "url": "https://example.com/the-greatest"}
+			statusCode := requestAndDecode(t, testApi.CreateSource, body, &source)
+			if statusCode != http.StatusOK || source.Id == 0 || source.Type != structs.SourceBook || source.Year != 1975 {
+				t.Fatalf("got status code %d and %+v, want the new book", statusCode, source)
+			}
+
+			body = jsonBody{"apiKey": godApiKey, "id": source.Id, "title": "The Greatest: My Own Story", "type": "book", "year": 1975}
+			source = structs.SourceAPIModel{}
+			statusCode = requestAndDecode(t, testApi.UpdateSource, body, &source)
+			if statusCode != http.StatusOK || source.Title != "The Greatest: My Own Story" || source.Url != "" {
+				t.Fatalf("got status code %d and %+v, want the renamed source", statusCode, source)
+			}
+
+			var sources []structs.SourceAPIModel
+			requestAndDecode(t, testApi.ListSources, jsonBody{"apiKey": apiKey, "searchString": "greatest"}, &sources)
+			if len(sources) != 1 || sources[0].Id != source.Id {
+				t.Fatalf("got %+v, want the source", sources)
+			}
+		})
+
+		t.Run("should cite a quote and return its source when asked", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse an unknown source", testApi.CiteQuote, jsonBody{"apiKey": godApiKey, "quoteId": 1, "sourceId": 999, "status": "verified"}, http.StatusNotFound},
+				{"should cite the quote", testApi.CiteQuote, jsonBody{"apiKey": godApiKey, "quoteId": 1, "sourceId": source.Id, "status": "verified"}, http.StatusOK},
+			})
+
+			if quotes := getQuotes(jsonBody{"apiKey": apiKey, "ids": []int{1, 2}}); len(quotes) != 2 || quotes[0].Source != nil {
+				t.Fatalf("got %+v, want no sources without withSource", quotes)
+			}
+			quotes := getQuotes(jsonBody{"apiKey": apiKey, "ids": []int{1, 2}, "withSource": true})
+			if len(quotes) != 2 || quotes[0].Source == nil || quotes[0].Source.Id != source.Id || quotes[0].Source.Status != structs.SourceVerified || quotes[1].Source != nil {
+				t.Fatalf("got %+v, want the source of quote 1 only", quotes)
+			}
+
+			requestAndDecode(t, testApi.SetQuoteOfTheDay, jsonBody{"apiKey": godApiKey, "qods": []jsonBody{{"id": 1}}}, nil)
+			var qod structs.QodViewAPIModel
+			requestAndDecode(t, testApi.GetQuoteOfTheDay, jsonBody{"apiKey": apiKey, "withSource": true}, &qod)
+			if qod.QuoteId != 1 || qod.Source == nil || qod.Source.Title != source.Title {
+				t.Fatalf("got %+v, want the QOD with its source", qod)
+			}
+		})
+
+		t.Run("should only return the verified quotes", func(t *testing.T) {
+			if statusCode := requestAndDecode(t, testApi.CiteQuote, jsonBody{"apiKey": godApiKey, "quoteId": 4, "status": "misattributed"}, nil); statusCode != http.StatusOK {
+				t.Fatalf("got status code %d but expected %d", statusCode, http.StatusOK)
+			}
+
+			for _, test := range []struct {
+				name string
+				fn   httpRequest
+				body jsonBody
+				want []int
+			}{
+				{"the list", testApi.GetQuotesList, jsonBody{"apiKey": apiKey, "verified": true}, []int{1}},
+				{"the topic", testApi.GetTopic, jsonBody{"apiKey": apiKey, "id": 1, "verified": true}, []int{1}},
+				{"the search", testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "stronger", "verified": true}, []int{}},
+			} {
+				var respObj []structs.TestApiResponse
+				requestAndDecode(t, test.fn, test.body, &respObj)
+				if len(respObj) != len(test.want) || (len(respObj) > 0 && respObj[0].QuoteId != test.want[0]) {
+					t.Fatalf("got %+v from %s, want only the verified quotes %v", respObj, test.name, test.want)
+				}
+			}
+
+			quotes := getQuotes(jsonBody{"apiKey": apiKey, "ids": []int{4}, "withSource": true})
+			if len(quotes) != 1 || quotes[0].Source == nil || quotes[0].Source.Status != structs.SourceMisattributed || quotes[0].Source.Id != 0 {
+				t.Fatalf("got %+v, want the misattributed quote without a source", quotes)
+			}
+		})
+	})
+}
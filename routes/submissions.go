@@ -0,0 +1,282 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// submitQuote puts the new public quote in the moderation queue, answering with the pending submission
+func (api *Api) submitQuote(rw http.ResponseWriter, newQuote structs.QuoteInsertModel) {
+	submission := structs.SubmissionDBModel{
+		UserId:      newQuote.UserId,
+		Quote:       newQuote.Quote,
+		Author:      newQuote.Author,
+		AuthorId:    newQuote.AuthorId,
+		IsIcelandic: newQuote.IsIcelandic,
+	}
+	submission.SetTopicNames(newQuote.Topics)
+
+	err := api.Submissions.Create(&submission)
+	switch {
+	case errors.Is(err, repository.ErrQuoteExists):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "This quote already exists", StatusCode: http.StatusConflict})
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No author exists with the id %d", newQuote.AuthorId), StatusCode: http.StatusNotFound})
+		return
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when submitting the quote in CreateQuote: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	submissionAPI := submission.ConvertToAPIModel()
+	submissionAPI.SimilarQuotes = api.similarQuotes(submission.Quote, submission.UserId, 0)
+	rw.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(rw).Encode(submissionAPI)
+}
+
+// swagger:route POST /submissions/status QUOTES GetSubmissionStatus
+// Get the status of a quote you submitted, i.e. whether it is still pending, was approved (and its quoteId) or rejected (and why)
+// responses:
+//	200: submissionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// GetSubmissionStatus handles POST requests to get one of the user's submissions
+func (api *Api) GetSubmissionStatus(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	user, err := api.getRequestUser(rw, r, &requestBody)
+	if err != nil || !validSubmissionId(rw, requestBody) {
+		return
+	}
+
+	submission, err := api.Submissions.Get(requestBody.Id)
+	//Only GOD-tier users can see the submissions of others
+	if err == nil && submission.UserId != user.Id && !handlers.IsGOD(user.Tier) {
+		err = repository.ErrNotFound
+	}
+	if err != nil {
+		writeSubmissionError(rw, err, "GetSubmissionStatus")
+		return
+	}
+	json.NewEncoder(rw).Encode(submission.ConvertToAPIModel())
+}
+
+// swagger:route POST /submissions MODERATION ListSubmissions
+// List the submitted quotes in the given status, oldest first (is password protected)
+// responses:
+//	200: submissionsResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// ListSubmissions handles POST requests to list a page of the submissions, the pending ones by default
+func (api *Api) ListSubmissions(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	status := strings.ToLower(requestBody.Status)
+	switch status {
+	case "":
+		status = structs.SubmissionPending
+	case structs.SubmissionPending, structs.SubmissionApproved, structs.SubmissionRejected:
+	default:
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("The status %s is not supported, it should be either %s, %s or %s", requestBody.Status, structs.SubmissionPending, structs.SubmissionApproved, structs.SubmissionRejected), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	submissions, err := api.Submissions.List(0, status, requestBody)
+	if err != nil {
+		writeSubmissionError(rw, err, "ListSubmissions")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToSubmissionsAPIModel(submissions))
+}
+
+// swagger:route POST /submissions/approve MODERATION ApproveSubmission
+// Approve a pending submission, optionally with edits to the quote, its author, language or topics, and publish the quote (is password protected)
+// responses:
+//	200: submissionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: quoteExistsResponse
+//  500: internalServerErrorResponse
+
+// ApproveSubmission handles POST requests to publish a submitted quote through the same insert as the import
+func (api *Api) ApproveSubmission(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	reviewer, submission, err := api.getPendingSubmission(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+
+	newQuote, err := editedQuote(submission, requestBody)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	//The submission keeps the quote as it was published
+	submission.Quote, submission.Author, submission.AuthorId, submission.IsIcelandic = newQuote.Quote, newQuote.Author, newQuote.AuthorId, newQuote.IsIcelandic
+	submission.SetTopicNames(newQuote.Topics)
+	submission.ReviewedBy = reviewer.Id
+
+	submission, quote, err := api.Submissions.Approve(submission, newQuote)
+	switch {
+	case errors.Is(err, repository.ErrSubmissionReviewed):
+		writeSubmissionError(rw, err, "ApproveSubmission")
+		return
+	case errors.Is(err, repository.ErrQuoteExists):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("This quote already exists, it has the id %d", quote.Id), StatusCode: http.StatusConflict})
+		return
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("No author exists with the id %d", newQuote.AuthorId), StatusCode: http.StatusNotFound})
+		return
+	case errors.Is(err, repository.ErrAuthorDeleted), errors.Is(err, repository.ErrTopicDeleted):
+		writeDeletedNameError(rw, err, newQuote)
+		return
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when approving the submission in ApproveSubmission: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	api.Refresher.MarkStale()
+	json.NewEncoder(rw).Encode(submission.ConvertToAPIModel())
+}
+
+// swagger:route POST /submissions/reject MODERATION RejectSubmission
+// Reject a pending submission with the reason, which the submitter sees in the status of the submission (is password protected)
+// responses:
+//	200: submissionResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: submissionReviewedResponse
+//  500: internalServerErrorResponse
+
+// RejectSubmission handles POST requests to reject a submitted quote
+func (api *Api) RejectSubmission(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	reviewer, submission, err := api.getPendingSubmission(rw, r, &requestBody)
+	if err != nil {
+		return
+	}
+	if submission.Reason = strings.TrimSpace(requestBody.Reason); submission.Reason == "" {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the reason for rejecting the submission", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	submission.Status, submission.ReviewedBy = structs.SubmissionRejected, reviewer.Id
+	api.reviewSubmission(rw, submission, "RejectSubmission")
+}
+
+// getPendingSubmission authorizes the GOD-tier user and returns the user and the pending submission with the request's id
+func (api *Api) getPendingSubmission(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request) (structs.UserDBModel, structs.SubmissionDBModel, error) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return structs.UserDBModel{}, structs.SubmissionDBModel{}, err
+	}
+	reviewer, err := api.getRequestUser(rw, r, requestBody)
+	if err != nil {
+		return reviewer, structs.SubmissionDBModel{}, err
+	}
+	if !validSubmissionId(rw, *requestBody) {
+		return reviewer, structs.SubmissionDBModel{}, errors.New("no submission id")
+	}
+
+	submission, err := api.Submissions.Get(requestBody.Id)
+	if err == nil && submission.Status != structs.SubmissionPending {
+		err = repository.ErrSubmissionReviewed
+	}
+	if err != nil {
+		writeSubmissionError(rw, err, "getPendingSubmission")
+	}
+	return reviewer, submission, err
+}
+
+func (api *Api) reviewSubmission(rw http.ResponseWriter, submission structs.SubmissionDBModel, route string) {
+	submission, err := api.Submissions.Review(submission)
+	if err != nil {
+		writeSubmissionError(rw, err, route)
+		return
+	}
+	json.NewEncoder(rw).Encode(submission.ConvertToAPIModel())
+}
+
+// editedQuote returns the submitted quote with the GOD-tier user's edits, validated like a new quote
+func editedQuote(submission structs.SubmissionDBModel, requestBody structs.Request) (structs.QuoteInsertModel, error) {
+	edited := structs.Request{
+		Quote:    submission.Quote,
+		Author:   submission.Author,
+		AuthorId: submission.AuthorId,
+		Language: "english",
+		Topics:   submission.TopicNames(),
+	}
+	if submission.IsIcelandic {
+		edited.Language = "icelandic"
+	}
+
+	if requestBody.Quote != "" {
+		edited.Quote = requestBody.Quote
+	}
+	if requestBody.AuthorId > 0 {
+		edited.Author, edited.AuthorId = "", requestBody.AuthorId
+	} else if requestBody.Author != "" {
+		edited.Author, edited.AuthorId = requestBody.Author, 0
+	}
+	if requestBody.Language != "" {
+		edited.Language = requestBody.Language
+	}
+	if requestBody.Topics != nil {
+		edited.Topics = requestBody.Topics
+	}
+
+	newQuote, err := newQuoteFromRequest(edited)
+	newQuote.UserId = submission.UserId
+	return newQuote, err
+}
+
+func validSubmissionId(rw http.ResponseWriter, requestBody structs.Request) bool {
+	if requestBody.Id > 0 {
+		return true
+	}
+	rw.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the id of the submission", StatusCode: http.StatusBadRequest})
+	return false
+}
+
+func writeSubmissionError(rw http.ResponseWriter, err error, route string) {
+	switch {
+	case errors.Is(err, repository.ErrSubmissionReviewed):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "This submission has already been reviewed", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "No submission exists with this id", StatusCode: http.StatusNotFound})
+	default:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when querying DB in %s: %s", route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	}
+}
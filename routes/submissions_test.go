@@ -0,0 +1,102 @@
+package routes
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestModerationWithFixtures tests the moderation queue of the submitted quotes against the fixtures
+func TestModerationWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		submissionRequest := func(fn httpRequest, body jsonBody) (structs.SubmissionAPIModel, int) {
+			var submission structs.SubmissionAPIModel
+			statusCode := requestAndDecode(t, fn, body, &submission)
+			return submission, statusCode
+		}
+		pending := func() []structs.SubmissionAPIModel {
+			var submissions []structs.SubmissionAPIModel
+			requestAndDecode(t, testApi.ListSubmissions, jsonBody{"apiKey": godApiKey}, &submissions)
+			return submissions
+		}
+		search := func(searchString string) []structs.TestApiResponse {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": searchString}, &respObj)
+			return respObj
+		}
+
+		t.Run("should queue the public quotes of users below the GOD tier", func(t *testing.T) {
+			submission, statusCode := submissionRequest(testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "Stay hungry, stay foolish.", "author": "Steve Jobs", "topics": []string{"Motivational"}})
+			if statusCode != http.StatusAccepted || submission.Id == 0 || submission.Status != structs.SubmissionPending {
+				t.Fatalf("got status code %d and %+v, want a pending submission", statusCode, submission)
+			}
+			refreshViews(t, testApi)
+			if respObj := search("hungry foolish"); len(respObj) != 0 {
+				t.Fatalf("got %+v, want the submitted quote left out of search", respObj)
+			}
+			if submissions := pending(); len(submissions) != 1 || submissions[0].Id != submission.Id {
+				t.Fatalf("got %+v, want the submission in the queue", submissions)
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should not list the queue for a free user", testApi.ListSubmissions, jsonBody{"apiKey": apiKey, "id": submission.Id}, http.StatusUnauthorized},
+				{"should not approve for a free user", testApi.ApproveSubmission, jsonBody{"apiKey": apiKey, "id": submission.Id}, http.StatusUnauthorized},
+			})
+		})
+
+		t.Run("should publish an approved submission with the edits", func(t *testing.T) {
+			submission := pending()[0]
+			approved, statusCode := submissionRequest(testApi.ApproveSubmission, jsonBody{"apiKey": godApiKey, "id": submission.Id, "quote": "Stay hungry. Stay foolish."})
+			if statusCode != http.StatusOK || approved.Status != structs.SubmissionApproved || approved.QuoteId == 0 || approved.Quote != "Stay hungry. Stay foolish." {
+				t.Fatalf("got status code %d and %+v, want the approved submission with the edited quote", statusCode, approved)
+			}
+
+			polled, statusCode := submissionRequest(testApi.GetSubmissionStatus, jsonBody{"apiKey": apiKey, "id": submission.Id})
+			if statusCode != http.StatusOK || polled.Status != structs.SubmissionApproved || polled.QuoteId != approved.QuoteId || polled.ReviewedAt == nil {
+				t.Fatalf("got status code %d and %+v, want the submitter to see the approval", statusCode, polled)
+			}
+			refreshViews(t, testApi)
+			if respObj := search("hungry foolish"); len(respObj) == 0 || respObj[0].QuoteId != approved.QuoteId || respObj[0].Name != "Steve Jobs" {
+				t.Fatalf("got %+v, want the approved quote to be searchable", respObj)
+			}
+
+			runStatusTests(t, []statusTest{
+				{"should not reject a reviewed submission", testApi.RejectSubmission, jsonBody{"apiKey": godApiKey, "id": submission.Id, "reason": "Too late"}, http.StatusConflict},
+			})
+			if submissions := pending(); len(submissions) != 0 {
+				t.Fatalf("got %+v, want an empty queue", submissions)
+			}
+		})
+
+		t.Run("should reject a submission with the reason", func(t *testing.T) {
+			submission, _ := submissionRequest(testApi.CreateQuote, jsonBody{"apiKey": apiKey, "quote": "Be water, my friend.", "author": "Muhammad Ali"})
+			runStatusTests(t, []statusTest{
+				{"should refuse a rejection without a reason", testApi.RejectSubmission, jsonBody{"apiKey": godApiKey, "id": submission.Id}, http.StatusBadRequest},
+				{"should reject the submission", testApi.RejectSubmission, jsonBody{"apiKey": godApiKey, "id": submission.Id, "reason": "Misattributed, Bruce Lee said it"}, http.StatusOK},
+			})
+
+			polled, _ := submissionRequest(testApi.GetSubmissionStatus, jsonBody{"apiKey": apiKey, "id": submission.Id})
+			if polled.Status != structs.SubmissionRejected || polled.Reason != "Misattributed, Bruce Lee said it" || polled.QuoteId != 0 {
+				t.Fatalf("got %+v, want the rejected submission with the reason", polled)
+			}
+			//An approval that read the submission before it was rejected must not publish the quote
+			_, _, err := testApi.Submissions.Approve(structs.SubmissionDBModel{Id: submission.Id}, structs.QuoteInsertModel{Quote: "Be water, my friend.", Author: "Muhammad Ali"})
+			if !errors.Is(err, repository.ErrSubmissionReviewed) {
+				t.Fatalf("got error %v when approving the rejected submission, want %v", err, repository.ErrSubmissionReviewed)
+			}
+
+			refreshViews(t, testApi)
+			respObj := search("be water")
+			for _, quote := range respObj {
+				if quote.Quote == "Be water, my friend." {
+					t.Fatalf("got %+v, want the rejected quote left out of search", respObj)
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,100 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// maxSuggestions is how many corrected search strings are suggested at most
+const maxSuggestions = 5
+
+// fewSearchResults is the number of results below which the search routes suggest corrections when didYouMean is set
+const fewSearchResults = 3
+
+// suggestionViews are the lexeme views the suggestions for each type of search are made from
+var suggestionViews = map[string]string{
+	"":        repository.LexemeView,
+	"all":     repository.LexemeView,
+	"quotes":  repository.QuotesLexemeView,
+	"authors": repository.AuthorsLexemeView,
+}
+
+// swagger:route POST /search/suggestions SEARCH SuggestSearchStrings
+// Did you mean. Suggests corrections of a search string whose words are misspelled, the most similar first
+// responses:
+//	200: suggestionsResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// SuggestSearchStrings handles POST requests for corrections of a search-string
+func (api *Api) SuggestSearchStrings(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	view, ok := suggestionViews[strings.ToLower(requestBody.Type)]
+	if strings.TrimSpace(requestBody.SearchString) == "" || !ok {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply a searchString and, if any, a type that is all, quotes or authors", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	suggestions, err := api.Suggestions.Suggest(requestBody.SearchString, view, maxSuggestions)
+	if err != nil {
+		writeSearchError(rw, err, "SuggestSearchStrings")
+		return
+	}
+	json.NewEncoder(rw).Encode(structs.ConvertToSuggestionsAPIModel(suggestions))
+}
+
+// writeSearchResults writes the results of a search, along with suggested corrections of the searchString if
+// didYouMean is set and the first page has few results, and the facets of all the matches if they are asked for and
+// the search has facets, i.e. facets is not nil. With withPagination they are all written in a page with the total
+// that total counts
+func (api *Api) writeSearchResults(rw http.ResponseWriter, requestBody structs.Request, results interface{}, nrOfResults int, view string, facets func(structs.Request, int) (structs.FacetsDBModel, error), total func(structs.Request) (structs.TotalDBModel, error), route string) {
+	withFacets := requestBody.Facets != nil && facets != nil
+	if !requestBody.DidYouMean && !withFacets {
+		writePage(rw, requestBody, results, nrOfResults, total, "", false, route)
+		return
+	}
+
+	response := structs.SearchResponseAPIModel{Results: results}
+	if requestBody.DidYouMean && nrOfResults < fewSearchResults && requestBody.Page == 0 {
+		suggestions, err := api.Suggestions.Suggest(requestBody.SearchString, view, maxSuggestions)
+		//The author search does not parse the searchString as a query, an invalid query just gets no suggestions
+		var queryErr *repository.QueryError
+		if err != nil && !errors.As(err, &queryErr) {
+			writeSearchError(rw, err, route)
+			return
+		}
+		response.Suggestions = structs.ConvertToSuggestionsAPIModel(suggestions)
+	}
+	if withFacets {
+		counts, err := facets(requestBody, requestBody.Facets.Limit)
+		if err != nil {
+			writeSearchError(rw, err, route)
+			return
+		}
+		facetsAPI := counts.ConvertToAPIModel()
+		response.Facets = &facetsAPI
+	}
+	if !requestBody.WithPagination {
+		json.NewEncoder(rw).Encode(response)
+		return
+	}
+
+	page, err := newPage(requestBody, results, nrOfResults, total, "", false)
+	if err != nil {
+		writeSearchError(rw, err, route)
+		return
+	}
+	page.Suggestions, page.Facets = response.Suggestions, response.Facets
+	json.NewEncoder(rw).Encode(page)
+}
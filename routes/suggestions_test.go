@@ -0,0 +1,66 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestDidYouMeanWithFixtures tests the suggested corrections of misspelled search strings against the fixtures
+func TestDidYouMeanWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		suggest := func(searchString string, searchType string) ([]structs.SuggestionAPIModel, int) {
+			var suggestions []structs.SuggestionAPIModel
+			statusCode := requestAndDecode(t, testApi.SuggestSearchStrings, jsonBody{"apiKey": apiKey, "searchString": searchString, "type": searchType}, &suggestions)
+			return suggestions, statusCode
+		}
+
+		t.Run("should suggest corrections of the misspelled words", func(t *testing.T) {
+			suggestions, statusCode := suggest("nietshe", "authors")
+			if statusCode != http.StatusOK || len(suggestions) == 0 || suggestions[0].SearchString != "nietzsche" {
+				t.Fatalf("got %+v with status code %d, want nietzsche first", suggestions, statusCode)
+			}
+			suggestions, _ = suggest(`"Butterfy like" -stingg`, "")
+			if len(suggestions) == 0 || suggestions[0].SearchString != `"Butterfly like" -sting` {
+				t.Fatalf("got %+v, want the phrase and exclusion corrected", suggestions)
+			}
+			for idx := 1; idx < len(suggestions); idx++ {
+				if suggestions[idx].Similarity > suggestions[idx-1].Similarity {
+					t.Fatalf("got %+v, want the most similar suggestions first", suggestions)
+				}
+			}
+		})
+
+		t.Run("should not suggest anything for known words", func(t *testing.T) {
+			if suggestions, statusCode := suggest("float like a butterfly", "quotes"); statusCode != http.StatusOK || len(suggestions) != 0 {
+				t.Fatalf("got %+v with status code %d, want no suggestions", suggestions, statusCode)
+			}
+			if _, statusCode := suggest("nietshe", "topics"); statusCode != http.StatusBadRequest {
+				t.Fatalf("got status code %d for an unknown type, want %d", statusCode, http.StatusBadRequest)
+			}
+		})
+
+		t.Run("should add suggestions to searches with few results when didYouMean is set", func(t *testing.T) {
+			var respObj struct {
+				Results     []structs.TopicViewAPIModel  `json:"results"`
+				Suggestions []structs.SuggestionAPIModel `json:"suggestions"`
+			}
+			requestAndDecode(t, testApi.SearchQuotesByString, jsonBody{"apiKey": apiKey, "searchString": "butterfy sting", "didYouMean": true}, &respObj)
+			if len(respObj.Results) >= 3 || len(respObj.Suggestions) == 0 || respObj.Suggestions[0].SearchString != "butterfly sting" {
+				t.Fatalf("got %+v, want few results and butterfly sting suggested", respObj)
+			}
+
+			var authorsObj struct {
+				Results     []structs.AuthorAPIModel     `json:"results"`
+				Suggestions []structs.SuggestionAPIModel `json:"suggestions"`
+			}
+			requestAndDecode(t, testApi.SearchAuthorsByString, jsonBody{"apiKey": apiKey, "searchString": "Muhamad", "didYouMean": true}, &authorsObj)
+			if len(authorsObj.Suggestions) == 0 || authorsObj.Suggestions[0].SearchString != "Muhammad" {
+				t.Fatalf("got %+v, want Muhammad suggested", authorsObj)
+			}
+		})
+	})
+}
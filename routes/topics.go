@@ -2,12 +2,15 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
-	"gorm.io/gorm/clause"
 )
 
 // swagger:route POST /topics TOPICS GetTopics
@@ -18,18 +21,12 @@ import (
 //  500: internalServerErrorResponse
 
 // GetTopics handles POST requests for listing the available quote-topics
-func GetTopics(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetTopics(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
-	var results []structs.TopicDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	dbPointer := handlers.Db.Table("topics")
-
-	dbPointer = quoteLanguageSQL(requestBody.Language, dbPointer)
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err := dbPointer.Find(&results).Error
+	results, err := api.Topics.List(requestBody.Language)
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
 		log.Printf("Got error when querying DB in GetTopics: %s", err)
@@ -49,26 +46,12 @@ func GetTopics(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // GetTopic handles POST requests for getting quotes from a particular topic
-func GetTopic(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) GetTopic(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.Request
-	if err := handlers.GetRequestBody(rw, r, &requestBody); err != nil {
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
 		return
 	}
-	var results []structs.TopicViewDBModel
-	//** ---------- Paramatere configuratino for DB query begins ---------- **//
-	//Order by quoteid to have definitive order (when for examplke some quotes rank the same for plain, phrase and general)
-	dbPoint := handlers.Db.Table("topicsview").Clauses(clause.OrderBy{
-		Expression: clause.Expr{SQL: "quote_id DESC", Vars: []interface{}{}, WithoutParentheses: true},
-	})
-
-	if requestBody.Topic != "" {
-		dbPoint = dbPoint.Where("lower(topic_name) = lower(?)", requestBody.Topic)
-	} else {
-		dbPoint = dbPoint.Where("topic_id = ?", requestBody.Id)
-	}
-
-	//** ---------- Paramatere configuratino for DB query ends ---------- **//
-	err := pagination(requestBody, dbPoint).Find(&results).Error
+	results, err := api.Topics.GetTopic(requestBody)
 
 	if err != nil {
 		rw.WriteHeader(http.StatusInternalServerError)
@@ -77,9 +60,175 @@ func GetTopic(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	//Update popularity in background!
-	go handlers.DirectFetchTopicCountIncrement(requestBody.Id, requestBody.Topic)
 	topicViewsAPI := structs.ConvertToTopicViewsAPIModel(results)
+	if err = api.citeTopicViews(requestBody, topicViewsAPI); err != nil {
+		writeSourcesError(rw, err, "GetTopic")
+		return
+	}
+
+	//Update popularity in background!
+	api.offline(func() error { return handlers.DirectFetchTopicCountIncrement(api.Repositories, requestBody.Id, requestBody.Topic) })
+	writePage(rw, requestBody, topicViewsAPI, len(topicViewsAPI), api.Topics.GetTopicTotal, "", false, "GetTopic")
+}
+
+// swagger:route POST /topics/new TOPICS CreateTopic
+// Create a new topic, without any quotes (is password protected)
+// responses:
+//	200: topicResponse
+//  400: incorrectBodyStructureResponse
+//  409: topicExistsResponse
+//  500: internalServerErrorResponse
+
+// CreateTopic handles POST requests to create a topic
+func (api *Api) CreateTopic(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getTopicRequestBody(rw, r, &requestBody, true, false); err != nil {
+		return
+	}
+
+	var isIcelandic bool
+	switch strings.ToLower(requestBody.Language) {
+	case "", "english":
+	case "icelandic":
+		isIcelandic = true
+	default:
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("the language %s is not supported, it should be either english or icelandic", requestBody.Language), StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	topic, err := api.Topics.Create(requestBody.Name, isIcelandic)
+	//A topic without quotes is not in the topicsview
+	api.writeTopic(rw, topic, err, "CreateTopic", false)
+}
+
+// swagger:route POST /topics/update TOPICS UpdateTopic
+// Rename a topic (is password protected)
+// responses:
+//	200: topicResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  409: topicExistsResponse
+//  500: internalServerErrorResponse
+
+// UpdateTopic handles POST requests to rename the topic with the given id
+func (api *Api) UpdateTopic(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getTopicRequestBody(rw, r, &requestBody, true, true); err != nil {
+		return
+	}
+
+	topic, err := api.Topics.Rename(requestBody.Id, requestBody.Name)
+	api.writeTopic(rw, topic, err, "UpdateTopic", true)
+}
+
+// swagger:route POST /topics/delete TOPICS DeleteTopic
+// Delete a topic, it can be restored through /restore (is password protected)
+// responses:
+//	200: successResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// DeleteTopic handles POST requests to soft delete the topic with the given id
+func (api *Api) DeleteTopic(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := api.getTopicRequestBody(rw, r, &requestBody, false, true); err != nil {
+		return
+	}
+
+	err := api.Deleted.Delete(repository.TopicsTable, requestBody.Id)
+	if err != nil {
+		api.writeTopic(rw, structs.TopicDBModel{}, err, "DeleteTopic", false)
+		return
+	}
+	api.refreshNow(repository.TopicsView)
+	json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: fmt.Sprintf("Successfully deleted the topic with id %d!", requestBody.Id), StatusCode: http.StatusOK})
+}
+
+// swagger:route POST /topics/quotes/add TOPICS AddQuotesToTopic
+// Tag quotes with a topic (is password protected)
+// responses:
+//	200: topicResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
+
+// AddQuotesToTopic handles POST requests to add the quotes with the given ids to the topic
+func (api *Api) AddQuotesToTopic(rw http.ResponseWriter, r *http.Request) {
+	api.changeTopicQuotes(rw, r, api.Topics.AddQuotes, "AddQuotesToTopic")
+}
+
+// swagger:route POST /topics/quotes/remove TOPICS RemoveQuotesFromTopic
+// Untag quotes from a topic (is password protected)
+// responses:
+//	200: topicResponse
+//  400: incorrectBodyStructureResponse
+//  404: notFoundResponse
+//  500: internalServerErrorResponse
 
-	json.NewEncoder(rw).Encode(topicViewsAPI)
+// RemoveQuotesFromTopic handles POST requests to remove the quotes with the given ids from the topic
+func (api *Api) RemoveQuotesFromTopic(rw http.ResponseWriter, r *http.Request) {
+	api.changeTopicQuotes(rw, r, api.Topics.RemoveQuotes, "RemoveQuotesFromTopic")
+}
+
+// changeTopicQuotes adds / removes the quotes to / from the topic
+func (api *Api) changeTopicQuotes(rw http.ResponseWriter, r *http.Request, change func(topicId int, quoteIds []int) (structs.TopicDBModel, error), route string) {
+	var requestBody structs.Request
+	if err := api.getTopicRequestBody(rw, r, &requestBody, false, true); err != nil {
+		return
+	}
+
+	if len(requestBody.Ids) == 0 {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "Please supply the ids of the quotes", StatusCode: http.StatusBadRequest})
+		return
+	}
+
+	topic, err := change(requestBody.Id, requestBody.Ids)
+	api.writeTopic(rw, topic, err, route, true)
+}
+
+// getTopicRequestBody authorizes the GOD-tier user and validates the name and / or id of the topic
+func (api *Api) getTopicRequestBody(rw http.ResponseWriter, r *http.Request, requestBody *structs.Request, needsName bool, needsId bool) error {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return err
+	}
+	if err := handlers.GetRequestBody(rw, r, requestBody, api.Repositories); err != nil {
+		return err
+	}
+
+	requestBody.Name = strings.TrimSpace(requestBody.Name)
+	var err error
+	if needsName && requestBody.Name == "" {
+		err = errors.New("please supply the name of the topic")
+	} else if needsId && requestBody.Id <= 0 {
+		err = errors.New("please supply the id of the topic")
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: err.Error(), StatusCode: http.StatusBadRequest})
+	}
+	return err
+}
+
+// writeTopic writes the changed topic, after refreshing the topicsview if needed, or the error
+func (api *Api) writeTopic(rw http.ResponseWriter, topic structs.TopicDBModel, err error, route string, refresh bool) {
+	switch {
+	case errors.Is(err, repository.ErrTopicExists):
+		rw.WriteHeader(http.StatusConflict)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "A topic with this name already exists", StatusCode: http.StatusConflict})
+	case errors.Is(err, repository.ErrNotFound):
+		rw.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "The topic, or some of the quotes, does not exist", StatusCode: http.StatusNotFound})
+	case err != nil:
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when querying DB in %s: %s", route, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+	default:
+		if refresh {
+			api.refreshNow(repository.TopicsView)
+		}
+		json.NewEncoder(rw).Encode(topic.ConvertToAPIModel())
+	}
 }
@@ -13,6 +13,7 @@ import (
 )
 
 func TestTopics(t *testing.T) {
+	requireDatabase(t)
 	user := createUser(t)
 
 	t.Run("Should return the possible English topics as a list of objects", func(t *testing.T) {
@@ -23,7 +24,7 @@ func TestTopics(t *testing.T) {
 		request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response := httptest.NewRecorder()
 
-		GetTopics(response, request)
+		api.GetTopics(response, request)
 
 		var respObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -40,7 +41,7 @@ func TestTopics(t *testing.T) {
 		var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","language": "%s"}`, user.ApiKey, language))
 		request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response := httptest.NewRecorder()
-		GetTopics(response, request)
+		api.GetTopics(response, request)
 		var respObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &respObj)
 
@@ -57,7 +58,7 @@ func TestTopics(t *testing.T) {
 		var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "topic": "%s", "pageSize":%d, "page":%d}`, user.ApiKey, nameOfTopic, pageSize, page))
 		request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response := httptest.NewRecorder()
-		GetTopic(response, request)
+		api.GetTopic(response, request)
 		var respObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &respObj)
 
@@ -81,7 +82,7 @@ func TestTopics(t *testing.T) {
 		var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","id": %d, "pageSize":%d, "page":%d}`, user.ApiKey, topicId, pageSize, page))
 		request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response := httptest.NewRecorder()
-		GetTopic(response, request)
+		api.GetTopic(response, request)
 		var respObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &respObj)
 
@@ -106,7 +107,7 @@ func TestTopics(t *testing.T) {
 		var jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s","id": %d, "pageSize":%d, "page":%d}`, user.ApiKey, topicId, pageSize, page))
 		request, _ := http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response := httptest.NewRecorder()
-		GetTopic(response, request)
+		api.GetTopic(response, request)
 
 		var respObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &respObj)
@@ -119,7 +120,7 @@ func TestTopics(t *testing.T) {
 		jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "id": %d, "pageSize":%d, "page":%d}`, user.ApiKey, topicId, pageSize, page))
 		request, _ = http.NewRequest(http.MethodPost, "/api", bytes.NewBuffer(jsonStr))
 		response = httptest.NewRecorder()
-		GetTopic(response, request)
+		api.GetTopic(response, request)
 
 		var newRespObj []structs.TestApiResponse
 		_ = json.Unmarshal(response.Body.Bytes(), &newRespObj)
@@ -139,3 +140,95 @@ func TestTopics(t *testing.T) {
 		log.Println("CLEANUP TestTopics!")
 	})
 }
+
+//TestTopicsWithFixtures tests the topics and their quotes against the fixtures
+func TestTopicsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		t.Run("should return the Icelandic topics", func(t *testing.T) {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetTopics, jsonBody{"apiKey": apiKey, "language": "icelandic"}, &respObj)
+			if len(respObj) != 2 {
+				t.Fatalf("got %d topics, want 2", len(respObj))
+			}
+		})
+
+		t.Run("should return the quotes of the topic by name", func(t *testing.T) {
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetTopic, jsonBody{"apiKey": apiKey, "topic": "Inspirational"}, &respObj)
+			if len(respObj) != 5 || respObj[0].TopicName != "inspirational" {
+				t.Fatalf("got %+v, want the 5 quotes of topic inspirational", respObj)
+			}
+		})
+	})
+}
+
+//TestEditTopicsWithFixtures tests creating, renaming and deleting topics and tagging quotes against the fixtures
+func TestEditTopicsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		topicRequest := func(fn httpRequest, body jsonBody) (structs.TopicAPIModel, int) {
+			var topic structs.TopicAPIModel
+			statusCode := requestAndDecode(t, fn, body, &topic)
+			return topic, statusCode
+		}
+
+		t.Run("should create a topic, tag and untag quotes", func(t *testing.T) {
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.CreateTopic, jsonBody{"apiKey": apiKey, "name": "Boxing"}, http.StatusUnauthorized},
+			})
+			topic, statusCode := topicRequest(testApi.CreateTopic, jsonBody{"apiKey": godApiKey, "name": "Boxing"})
+			if statusCode != http.StatusOK || topic.Id == 0 || topic.NrOfQuotes != 0 {
+				t.Fatalf("got status code %d and %+v, want a new topic without quotes", statusCode, topic)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse a taken name", testApi.CreateTopic, jsonBody{"apiKey": godApiKey, "name": "boxing"}, http.StatusConflict},
+				{"should refuse an unknown quote", testApi.AddQuotesToTopic, jsonBody{"apiKey": godApiKey, "id": topic.Id, "ids": []int{9999}}, http.StatusNotFound},
+			})
+
+			topic, statusCode = topicRequest(testApi.AddQuotesToTopic, jsonBody{"apiKey": godApiKey, "id": topic.Id, "ids": []int{1, 3}})
+			if statusCode != http.StatusOK || topic.NrOfQuotes != 2 || topic.IsIcelandic {
+				t.Fatalf("got status code %d and %+v, want the English topic with 2 quotes", statusCode, topic)
+			}
+			var respObj []structs.TestApiResponse
+			requestAndDecode(t, testApi.GetTopic, jsonBody{"apiKey": apiKey, "id": topic.Id}, &respObj)
+			if len(respObj) != 2 || respObj[0].QuoteId != 3 || respObj[1].QuoteId != 1 {
+				t.Fatalf("got %+v, want the quotes 3 and 1 in the topic", respObj)
+			}
+
+			topic, statusCode = topicRequest(testApi.RemoveQuotesFromTopic, jsonBody{"apiKey": godApiKey, "id": topic.Id, "ids": []int{1}})
+			if statusCode != http.StatusOK || topic.NrOfQuotes != 1 {
+				t.Fatalf("got status code %d and %+v, want the topic with 1 quote", statusCode, topic)
+			}
+		})
+
+		t.Run("should mark a topic with only Icelandic quotes as Icelandic", func(t *testing.T) {
+			topic, _ := topicRequest(testApi.CreateTopic, jsonBody{"apiKey": godApiKey, "name": "Hnefaleikar"})
+			topic, _ = topicRequest(testApi.AddQuotesToTopic, jsonBody{"apiKey": godApiKey, "id": topic.Id, "ids": []int{7}})
+			if !topic.IsIcelandic || topic.NrOfQuotes != 1 {
+				t.Fatalf("got %+v, want an Icelandic topic with 1 quote", topic)
+			}
+		})
+
+		t.Run("should rename and delete a topic", func(t *testing.T) {
+			topic, statusCode := topicRequest(testApi.UpdateTopic, jsonBody{"apiKey": godApiKey, "id": 4, "name": "Motivation"})
+			if statusCode != http.StatusOK || topic.Name != "Motivation" {
+				t.Fatalf("got status code %d and %+v, want the renamed topic", statusCode, topic)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse a taken name", testApi.UpdateTopic, jsonBody{"apiKey": godApiKey, "id": 4, "name": "Love"}, http.StatusConflict},
+				{"should delete the topic", testApi.DeleteTopic, jsonBody{"apiKey": godApiKey, "id": 4}, http.StatusOK},
+			})
+
+			var topics []structs.TopicAPIModel
+			requestAndDecode(t, testApi.GetTopics, jsonBody{"apiKey": apiKey}, &topics)
+			for _, topic := range topics {
+				if topic.Id == 4 {
+					t.Fatalf("got %+v, want the deleted topic left out", topics)
+				}
+			}
+		})
+	})
+}
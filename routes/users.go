@@ -4,11 +4,12 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
-	"regexp"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/structs"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
@@ -22,7 +23,7 @@ import (
 //  500: internalServerErrorResponse
 
 // CreateUsers handles post requests to create a user and an accompanying ApiKey
-func CreateUser(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) CreateUser(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.UserApiModel
 	if err := handlers.GetUserRequestBody(rw, r, &requestBody); err != nil {
 		return
@@ -39,24 +40,23 @@ func CreateUser(rw http.ResponseWriter, r *http.Request) {
 	log.Println("The created apikey:", apiKey)
 	user := structs.UserDBModel{Name: requestBody.Name, ApiKey: apiKey, Tier: requestBody.Tier, Email: requestBody.Email, PasswordHash: string(passHash)}
 
-	result := handlers.Db.Table("users").Select("name", "api_key", "tier", "email", "password_hash").Create(&user)
+	err := api.Users.Create(&user)
 
 	//Error handle
-	if result.Error != nil {
-		m1 := regexp.MustCompile(`duplicate key value violates unique constraint "users_email_key"`)
-		if m1.Match([]byte(result.Error.Error())) {
+	if err != nil {
+		if errors.Is(err, repository.ErrEmailTaken) {
 			rw.WriteHeader(http.StatusBadRequest)
-			log.Printf("Got error when creating user, constraint error: %s", result.Error)
+			log.Printf("Got error when creating user, constraint error: %s", err)
 			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "This email is taken."})
 			return
 		}
 		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got error when creating user: %s", result.Error)
+		log.Printf("Got error when creating user: %s", err)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
 		return
 	} else if user.Id <= 0 {
 		rw.WriteHeader(http.StatusInternalServerError)
-		log.Printf("Got no id when creating user: %s", result.Error)
+		log.Printf("Got no id when creating user: %s", err)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
 		return
 	}
@@ -73,14 +73,14 @@ func CreateUser(rw http.ResponseWriter, r *http.Request) {
 //  500: internalServerErrorResponse
 
 // Login handles post requests to login to a user and receive his ApiKey
-func Login(rw http.ResponseWriter, r *http.Request) {
+func (api *Api) Login(rw http.ResponseWriter, r *http.Request) {
 	var requestBody structs.UserApiModel
 	if err := handlers.GetUserRequestBody(rw, r, &requestBody); err != nil {
 		return
 	}
 
-	var user structs.UserDBModel
-	if err := handlers.Db.Table("users").Where("email = ?", requestBody.Email).First(&user).Error; err != nil {
+	user, err := api.Users.GetByEmail(requestBody.Email)
+	if err != nil {
 		rw.WriteHeader(http.StatusBadRequest)
 		log.Printf("Got error when login/fetching user: %s", err)
 		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: "No user with the given email address. Maybe try WindsOfWinterWillNeverBeFinished@WeAreAllSinnersBeforeTheSeven.com"})
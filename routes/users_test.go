@@ -31,11 +31,12 @@ func deleteUser(id int) {
 	handlers.Db.Table("users").Delete(&structs.UserDBModel{Id: id})
 }
 func TestUsers(t *testing.T) {
+	requireDatabase(t)
 	t.Run("Create User", func(t *testing.T) {
 		t.Run("Should create user with name: Þórður Ágústsson, password: 1234567890 and email: random@gmail.com", func(t *testing.T) {
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, CreateUser)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.CreateUser)
 
 			if response.Result().StatusCode != 200 {
 				t.Fatalf("Expected a status code of 200 but got %d", response.Result().StatusCode)
@@ -52,10 +53,10 @@ func TestUsers(t *testing.T) {
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
 
-			userResponse, _ := basicRequestReturnSingle(jsonStr, CreateUser)
+			userResponse, _ := basicRequestReturnSingle(jsonStr, api.CreateUser)
 			defer deleteUser(userResponse.Id)
 			//Do it again with same info
-			_, response := basicRequestReturnSingle(jsonStr, CreateUser)
+			_, response := basicRequestReturnSingle(jsonStr, api.CreateUser)
 
 			if response.Result().StatusCode != 400 {
 				t.Fatalf("Expected a status code of 400 but got %d", response.Result().StatusCode)
@@ -71,7 +72,7 @@ func TestUsers(t *testing.T) {
 			user := getBasicUser()
 			user.PasswordConfirmation = "12345678901"
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, CreateUser)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.CreateUser)
 
 			if response.Result().StatusCode != 400 {
 				t.Fatalf("Expected a status code of 200 but got %d", response.Result().StatusCode)
@@ -87,7 +88,7 @@ func TestUsers(t *testing.T) {
 			user.PasswordConfirmation = "123"
 			user.Password = "123"
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, CreateUser)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.CreateUser)
 
 			if response.Result().StatusCode != 400 {
 				t.Fatalf("Expected a status code of 200 but got %d", response.Result().StatusCode)
@@ -105,7 +106,7 @@ func TestUsers(t *testing.T) {
 
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			firstUserResponse, _ := basicRequestReturnSingle(jsonStr, CreateUser)
+			firstUserResponse, _ := basicRequestReturnSingle(jsonStr, api.CreateUser)
 			defer deleteUser(firstUserResponse.Id)
 			apiKey := firstUserResponse.ApiKey
 
@@ -115,7 +116,7 @@ func TestUsers(t *testing.T) {
 
 			//Login to createdUser
 			jsonStr = []byte(fmt.Sprintf(`{"password":"%s", "email":"%s"}`, user.Password, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, Login)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.Login)
 
 			if response.Result().StatusCode != 200 {
 				t.Fatalf("Expected a status code of 200 but got %d", response.Result().StatusCode)
@@ -136,7 +137,7 @@ func TestUsers(t *testing.T) {
 			user := getBasicUser()
 			//Login to createdUser
 			var jsonStr = []byte(fmt.Sprintf(`{"password":"%s", "email":"%s"}`, user.Password, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, Login)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.Login)
 
 			if userResponse.ApiKey != "" {
 				t.Fatalf("Expected an empty Api Key but got %s!", userResponse.ApiKey)
@@ -151,7 +152,7 @@ func TestUsers(t *testing.T) {
 
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			firstUserResponse, _ := basicRequestReturnSingle(jsonStr, CreateUser)
+			firstUserResponse, _ := basicRequestReturnSingle(jsonStr, api.CreateUser)
 			defer deleteUser(firstUserResponse.Id)
 			apiKey := firstUserResponse.ApiKey
 
@@ -161,7 +162,7 @@ func TestUsers(t *testing.T) {
 
 			//Login to createdUser
 			jsonStr = []byte(fmt.Sprintf(`{"password":"wrooooooooong", "email":"%s"}`, user.Email))
-			userResponse, response := basicRequestReturnSingle(jsonStr, Login)
+			userResponse, response := basicRequestReturnSingle(jsonStr, api.Login)
 
 			if userResponse.ApiKey != "" {
 				t.Fatalf("Expected an empty Api Key but got %s!", userResponse.ApiKey)
@@ -185,3 +186,28 @@ func basicRequestReturnSingle(jsonStr []byte, fn httpRequest) (structs.UserRespo
 	_ = json.Unmarshal(response.Body.Bytes(), &userResponse)
 	return userResponse, response
 }
+
+//TestUsersWithFixtures tests creating users and logging in against the fixtures
+func TestUsersWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		t.Run("should create a user, refuse the same email again and login", func(t *testing.T) {
+			user := getBasicUser()
+			body := jsonBody{"name": user.Name, "password": user.Password, "passwordConfirmation": user.PasswordConfirmation, "email": user.Email}
+			var userResponse structs.UserResponse
+			if statusCode := requestAndDecode(t, testApi.CreateUser, body, &userResponse); statusCode != http.StatusOK || userResponse.ApiKey == "" {
+				t.Fatalf("got status code %d and response %+v, want an api key", statusCode, userResponse)
+			}
+			runStatusTests(t, []statusTest{
+				{"should refuse a taken email", testApi.CreateUser, body, http.StatusBadRequest},
+			})
+
+			var loginResponse structs.UserResponse
+			requestAndDecode(t, testApi.Login, jsonBody{"email": user.Email, "password": user.Password}, &loginResponse)
+			if loginResponse.ApiKey != userResponse.ApiKey {
+				t.Fatalf("got api key %s on login, want %s", loginResponse.ApiKey, userResponse.ApiKey)
+			}
+		})
+	})
+}
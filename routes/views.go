@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+// swagger:route POST /meta/views META GetViewsStatus
+// Get when the materialized views, that search and the random routes use, were last refreshed
+// responses:
+//	200: viewRefreshesResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// GetViewsStatus handles POST requests for when each materialized view was last refreshed
+func (api *Api) GetViewsStatus(rw http.ResponseWriter, r *http.Request) {
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	api.writeViewsStatus(rw)
+}
+
+// swagger:route POST /meta/views/refresh META RefreshViews
+// Refresh the materialized views now (is password protected)
+// responses:
+//	200: viewRefreshesResponse
+//  400: incorrectBodyStructureResponse
+//  500: internalServerErrorResponse
+
+// RefreshViews handles POST requests to refresh the given materialized views, or all of them if none are given
+func (api *Api) RefreshViews(rw http.ResponseWriter, r *http.Request) {
+	if err := handlers.AuthorizeGODApiKey(rw, r, api.Repositories); err != nil {
+		return
+	}
+
+	var requestBody structs.Request
+	if err := handlers.GetRequestBody(rw, r, &requestBody, api.Repositories); err != nil {
+		return
+	}
+
+	views := requestBody.Views
+	if len(views) == 0 {
+		views = repository.MaterializedViews
+	}
+	// Check all the names first so that a single bad name does not refresh the others
+	for _, view := range views {
+		if !repository.IsMaterializedView(strings.ToLower(view)) {
+			rw.WriteHeader(http.StatusBadRequest)
+			message := fmt.Sprintf("The views that can be refreshed are %s", strings.Join(repository.AllViews(), ", "))
+			json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: message, StatusCode: http.StatusBadRequest})
+			return
+		}
+	}
+
+	if err := api.Refresher.Refresh(views...); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when refreshing the views %v: %s", views, err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+
+	api.writeViewsStatus(rw)
+}
+
+func (api *Api) writeViewsStatus(rw http.ResponseWriter) {
+	statuses, err := api.Refresher.Status()
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Got error when getting the refresh status of the views: %s", err)
+		json.NewEncoder(rw).Encode(structs.ErrorResponse{Message: handlers.InternalServerError})
+		return
+	}
+	json.NewEncoder(rw).Encode(statuses)
+}
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Skjaldbaka17/quotes-api/structs"
+)
+
+//TestViewsWithFixtures tests the refreshes of the materialized views against the fixtures
+func TestViewsWithFixtures(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, newApi func() *Api) {
+		testApi := newApi()
+
+		//refreshedAt returns when each view was last refreshed, the zero time if never. Seeding Postgres refreshes them
+		refreshedAt := func() map[string]time.Time {
+			var statuses []structs.ViewRefreshAPIModel
+			requestAndDecode(t, testApi.GetViewsStatus, jsonBody{"apiKey": apiKey}, &statuses)
+			if len(statuses) != 2 || statuses[0].ViewName != "searchview" {
+				t.Fatalf("got %+v, want the status of searchview and topicsview", statuses)
+			}
+			times := map[string]time.Time{}
+			for _, status := range statuses {
+				if status.LastRefreshedAt != nil {
+					times[status.ViewName] = *status.LastRefreshedAt
+				}
+			}
+			return times
+		}
+
+		t.Run("should not refresh any of the views when one of them is unknown", func(t *testing.T) {
+			before := refreshedAt()
+			runStatusTests(t, []statusTest{
+				{"should refuse a free user", testApi.RefreshViews, jsonBody{"apiKey": apiKey}, http.StatusUnauthorized},
+				{"should refuse an unknown view", testApi.RefreshViews, jsonBody{"apiKey": godApiKey, "views": []string{"users"}}, http.StatusBadRequest},
+				{"should refuse a known and an unknown view", testApi.RefreshViews, jsonBody{"apiKey": godApiKey, "views": []string{"searchview", "bogus"}}, http.StatusBadRequest},
+			})
+			for view, at := range refreshedAt() {
+				if !at.Equal(before[view]) {
+					t.Fatalf("got %s refreshed at %s, want none of the views to have been refreshed", view, at)
+				}
+			}
+		})
+
+		t.Run("should refresh the views and report when they were refreshed", func(t *testing.T) {
+			before := refreshedAt()
+			runStatusTests(t, []statusTest{
+				{"should refresh searchview", testApi.RefreshViews, jsonBody{"apiKey": godApiKey, "views": []string{"searchview"}}, http.StatusOK},
+			})
+			after := refreshedAt()
+			if !after["searchview"].After(before["searchview"]) || !after["topicsview"].Equal(before["topicsview"]) {
+				t.Fatalf("got the refreshes %v after %v, want only searchview to have been refreshed", after, before)
+			}
+		})
+	})
+}
@@ -2,9 +2,15 @@
 package main
 
 import (
+	"log"
 	"net/http"
+	"os"
 
+	"github.com/Skjaldbaka17/quotes-api/backfill"
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/importer"
+	"github.com/Skjaldbaka17/quotes-api/migrations"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/routes"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gorilla/mux"
@@ -12,32 +18,115 @@ import (
 
 func main() {
 
+	if err := handlers.ConnectDatabase(); err != nil {
+		panic("failed to connect database")
+	}
+
+	//`go run server.go migrate up|down|status` runs the migrations instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrations.RunCommand(handlers.Db, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	repos := repository.NewPostgresRepositories(handlers.Db)
+
+	//`go run server.go import [-format csv|jsonl] file...` imports quotes instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := importer.RunCommand(repos, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	//`go run server.go backfill duplicates [-batch 1000] [-after id]` records the pairs of near duplicates of the quotes
+	//from before migration 0022 instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		if err := backfill.RunCommand(repos, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	api := routes.NewApi(repos)
+	api.Refresher.Start(handlers.ViewRefreshSchedule())
+
 	r := mux.NewRouter()
 
 	posts := r.Methods(http.MethodPost).Subrouter()
-	posts.HandleFunc("/api/quotes", routes.GetQuotes)
-	posts.HandleFunc("/api/quotes/list", routes.GetQuotesList)
-	posts.HandleFunc("/api/quotes/random", routes.GetRandomQuote)
-	posts.HandleFunc("/api/quotes/qod/new", routes.SetQuoteOfTheDay)
-	posts.HandleFunc("/api/quotes/qod", routes.GetQuoteOfTheDay)
-	posts.HandleFunc("/api/quotes/qod/history", routes.GetQODHistory)
-
-	posts.HandleFunc("/api/search", routes.SearchByString)
-	posts.HandleFunc("/api/search/authors", routes.SearchAuthorsByString)
-	posts.HandleFunc("/api/search/quotes", routes.SearchQuotesByString)
-
-	posts.HandleFunc("/api/authors", routes.GetAuthorsById)
-	posts.HandleFunc("/api/authors/list", routes.GetAuthorsList)
-	posts.HandleFunc("/api/authors/random", routes.GetRandomAuthor)
-	posts.HandleFunc("/api/authors/aod/new", routes.SetAuthorOfTheDay)
-	posts.HandleFunc("/api/authors/aod", routes.GetAuthorOfTheDay)
-	posts.HandleFunc("/api/authors/aod/history", routes.GetAODHistory)
-
-	posts.HandleFunc("/api/topics", routes.GetTopics)
-	posts.HandleFunc("/api/topic", routes.GetTopic)
-
-	posts.HandleFunc("/api/users/signup", routes.CreateUser)
-	posts.HandleFunc("/api/users/login", routes.Login)
+	posts.HandleFunc("/api/quotes", api.GetQuotes)
+	posts.HandleFunc("/api/quotes/list", api.GetQuotesList)
+	posts.HandleFunc("/api/quotes/new", api.CreateQuote)
+	posts.HandleFunc("/api/quotes/random", api.GetRandomQuote)
+	posts.HandleFunc("/api/quotes/related", api.GetRelatedQuotes)
+	posts.HandleFunc("/api/quotes/qod/new", api.SetQuoteOfTheDay)
+	posts.HandleFunc("/api/quotes/qod", api.GetQuoteOfTheDay)
+	posts.HandleFunc("/api/quotes/qod/history", api.GetQODHistory)
+
+	posts.HandleFunc("/api/search", api.SearchByString)
+	posts.HandleFunc("/api/search/authors", api.SearchAuthorsByString)
+	posts.HandleFunc("/api/search/quotes", api.SearchQuotesByString)
+	posts.HandleFunc("/api/search/suggestions", api.SuggestSearchStrings)
+	posts.HandleFunc("/api/search/autocomplete", api.Autocomplete)
+
+	posts.HandleFunc("/api/authors", api.GetAuthorsById)
+	posts.HandleFunc("/api/authors/list", api.GetAuthorsList)
+	posts.HandleFunc("/api/authors/new", api.CreateAuthor)
+	posts.HandleFunc("/api/authors/update", api.UpdateAuthor)
+	posts.HandleFunc("/api/authors/profile", api.UpdateAuthorProfile)
+	posts.HandleFunc("/api/authors/aliases/add", api.AddAuthorAliases)
+	posts.HandleFunc("/api/authors/aliases/remove", api.RemoveAuthorAliases)
+	posts.HandleFunc("/api/authors/merge", api.MergeAuthors)
+	posts.HandleFunc("/api/authors/random", api.GetRandomAuthor)
+	posts.HandleFunc("/api/authors/aod/new", api.SetAuthorOfTheDay)
+	posts.HandleFunc("/api/authors/aod", api.GetAuthorOfTheDay)
+	posts.HandleFunc("/api/authors/aod/history", api.GetAODHistory)
+
+	posts.HandleFunc("/api/topics", api.GetTopics)
+	posts.HandleFunc("/api/topic", api.GetTopic)
+	posts.HandleFunc("/api/topics/new", api.CreateTopic)
+	posts.HandleFunc("/api/topics/update", api.UpdateTopic)
+	posts.HandleFunc("/api/topics/delete", api.DeleteTopic)
+	posts.HandleFunc("/api/topics/quotes/add", api.AddQuotesToTopic)
+	posts.HandleFunc("/api/topics/quotes/remove", api.RemoveQuotesFromTopic)
+
+	posts.HandleFunc("/api/favourites", api.GetFavourites)
+	posts.HandleFunc("/api/favourites/add", api.AddFavourites)
+	posts.HandleFunc("/api/favourites/remove", api.RemoveFavourites)
+	posts.HandleFunc("/api/collections", api.GetCollections)
+	posts.HandleFunc("/api/collection", api.GetCollection)
+	posts.HandleFunc("/api/collections/new", api.CreateCollection)
+	posts.HandleFunc("/api/collections/update", api.UpdateCollection)
+	posts.HandleFunc("/api/collections/delete", api.DeleteCollection)
+	posts.HandleFunc("/api/collections/quotes/set", api.SetCollectionQuote)
+	posts.HandleFunc("/api/collections/quotes/remove", api.RemoveCollectionQuotes)
+	posts.HandleFunc("/api/collections/quotes/order", api.ReorderCollection)
+
+	posts.HandleFunc("/api/submissions/status", api.GetSubmissionStatus)
+	posts.HandleFunc("/api/submissions", api.ListSubmissions)
+	posts.HandleFunc("/api/submissions/approve", api.ApproveSubmission)
+	posts.HandleFunc("/api/submissions/reject", api.RejectSubmission)
+
+	posts.HandleFunc("/api/duplicates", api.ListDuplicates)
+	posts.HandleFunc("/api/duplicates/merge", api.MergeDuplicates)
+
+	posts.HandleFunc("/api/sources", api.ListSources)
+	posts.HandleFunc("/api/sources/new", api.CreateSource)
+	posts.HandleFunc("/api/sources/update", api.UpdateSource)
+	posts.HandleFunc("/api/quotes/source", api.CiteQuote)
+
+	posts.HandleFunc("/api/users/signup", api.CreateUser)
+	posts.HandleFunc("/api/users/login", api.Login)
+
+	posts.HandleFunc("/api/export", api.ExportQuotes)
+
+	posts.HandleFunc("/api/delete", api.DeleteItem)
+	posts.HandleFunc("/api/restore", api.RestoreItem)
+	posts.HandleFunc("/api/deleted", api.ListDeleted)
+
+	posts.HandleFunc("/api/meta/views", api.GetViewsStatus)
+	posts.HandleFunc("/api/meta/views/refresh", api.RefreshViews)
 
 	// handler for documentation
 	opts := middleware.RedocOpts{SpecURL: "/swagger/swagger.yaml"}
@@ -48,7 +137,7 @@ func main() {
 	gets.Handle("/docs", sh)
 	gets.Handle("/swagger/swagger.yaml", http.FileServer(http.Dir("./")))
 
-	r.HandleFunc("/", routes.Home)
+	r.HandleFunc("/", api.Home)
 	s := http.StripPrefix("/assets/", http.FileServer(http.Dir("./assets/")))
 	r.PathPrefix("/assets/").Handler(s)
 
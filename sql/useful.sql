@@ -5,6 +5,6 @@ WHERE similarity(word, 'nietshe') > 0.3
 ORDER BY word <-> 'nietshe'
 LIMIT 3;
 
----To refresh the view after an update 
+---To refresh the view after an update (searchview and topicsview are also refreshed by the server, see POST /api/meta/views/refresh)
 REFRESH MATERIALIZED VIEW unique_lexeme;
-REFRESH MATERIALIZED VIEW searchview;
\ No newline at end of file
+REFRESH MATERIALIZED VIEW CONCURRENTLY searchview;
\ No newline at end of file
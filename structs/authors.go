@@ -1,5 +1,13 @@
 package structs
 
+import (
+	"encoding/json"
+	"time"
+)
+
+//AuthorDBModel is an author with its profile. The dates are ISO 8601 as precise as is known ('1844', '1844-10' or
+//'1844-10-15', negative years are BC), Professions is a json array of the lowercase profession names and Links a json
+//array of AuthorLinks
 type AuthorDBModel struct {
 	Id                  int    `json:"id,omitempty"`
 	Name                string `json:"name,omitempty"`
@@ -7,6 +15,32 @@ type AuthorDBModel struct {
 	NrOfIcelandicQuotes int    `json:"nr_of_icelandic_quotes,omitempty"`
 	NrOfEnglishQuotes   int    `json:"nr_of_english_quotes,omitempty"`
 	Count               int    `json:"count,omitempty"`
+	BirthDate           string `json:"birth_date,omitempty"`
+	DeathDate           string `json:"death_date,omitempty"`
+	Nationality         string `json:"nationality,omitempty"`
+	Professions         string `json:"professions,omitempty"`
+	Bio                 string `json:"bio,omitempty"`
+	Links               string `json:"links,omitempty"`
+}
+
+//AuthorLink is a reference link about an author, e.g. to Wikipedia
+type AuthorLink struct {
+	// What the link is to
+	// example: Wikipedia
+	Title string `json:"title,omitempty"`
+	// The url
+	// example: https://en.wikipedia.org/wiki/Muhammad_Ali
+	Url string `json:"url,omitempty"`
+}
+
+//AuthorProfile is the part of the author that is not computed from its quotes
+type AuthorProfile struct {
+	BirthDate   string
+	DeathDate   string
+	Nationality string
+	Professions []string
+	Bio         string
+	Links       []AuthorLink
 }
 
 type AuthorAPIModel struct {
@@ -29,20 +63,113 @@ type AuthorAPIModel struct {
 	// The popularity index of the author
 	// example: 1111
 	Count int `json:"count,omitempty"`
+	// When the author was born, ISO 8601 as precise as is known (negative years are BC)
+	// example: 1942-01-17
+	BirthDate string `json:"birthDate,omitempty"`
+	// When the author died, ISO 8601 as precise as is known (negative years are BC)
+	// example: 2016-06-03
+	DeathDate string `json:"deathDate,omitempty"`
+	// The author's nationality, an ISO 3166-1 alpha-2 country code
+	// example: US
+	Nationality string `json:"nationality,omitempty"`
+	// The author's professions
+	// example: ["boxer","activist"]
+	Professions []string `json:"professions,omitempty"`
+	// A short biography
+	// example: American professional boxer and activist, nicknamed "The Greatest".
+	Bio string `json:"bio,omitempty"`
+	// Reference links about the author
+	Links []AuthorLink `json:"links,omitempty"`
+	// Other names and spellings of the author (only returned by /authors)
+	// example: ["Cassius Clay"]
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+//AuthorAliasDBModel is another name or spelling of an author
+type AuthorAliasDBModel struct {
+	Id        int       `json:"id,omitempty"`
+	AuthorId  int       `json:"author_id,omitempty"`
+	Alias     string    `json:"alias,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+//ProfessionNames returns the names of the author's professions
+func (dbModel *AuthorDBModel) ProfessionNames() []string {
+	professions := []string{}
+	if dbModel.Professions != "" {
+		json.Unmarshal([]byte(dbModel.Professions), &professions)
+	}
+	return professions
+}
+
+//ReferenceLinks returns the author's reference links
+func (dbModel *AuthorDBModel) ReferenceLinks() []AuthorLink {
+	links := []AuthorLink{}
+	if dbModel.Links != "" {
+		json.Unmarshal([]byte(dbModel.Links), &links)
+	}
+	return links
+}
+
+//SetProfile sets the author's profile, encoding the professions and links as json arrays
+func (dbModel *AuthorDBModel) SetProfile(profile AuthorProfile) {
+	if profile.Professions == nil {
+		profile.Professions = []string{}
+	}
+	if profile.Links == nil {
+		profile.Links = []AuthorLink{}
+	}
+	professions, _ := json.Marshal(profile.Professions)
+	links, _ := json.Marshal(profile.Links)
+	dbModel.BirthDate = profile.BirthDate
+	dbModel.DeathDate = profile.DeathDate
+	dbModel.Nationality = profile.Nationality
+	dbModel.Professions = string(professions)
+	dbModel.Bio = profile.Bio
+	dbModel.Links = string(links)
 }
 
 func (dbModel *AuthorDBModel) ConvertToAPIModel() AuthorAPIModel {
-	return AuthorAPIModel(*dbModel)
+	return AuthorAPIModel{
+		Id:                  dbModel.Id,
+		Name:                dbModel.Name,
+		HasIcelandicQuotes:  dbModel.HasIcelandicQuotes,
+		NrOfIcelandicQuotes: dbModel.NrOfIcelandicQuotes,
+		NrOfEnglishQuotes:   dbModel.NrOfEnglishQuotes,
+		Count:               dbModel.Count,
+		BirthDate:           dbModel.BirthDate,
+		DeathDate:           dbModel.DeathDate,
+		Nationality:         dbModel.Nationality,
+		Professions:         dbModel.ProfessionNames(),
+		Bio:                 dbModel.Bio,
+		Links:               dbModel.ReferenceLinks(),
+	}
 }
 
 func (apiModel *AuthorAPIModel) ConvertToDBModel() AuthorDBModel {
-	return AuthorDBModel(*apiModel)
+	author := AuthorDBModel{
+		Id:                  apiModel.Id,
+		Name:                apiModel.Name,
+		HasIcelandicQuotes:  apiModel.HasIcelandicQuotes,
+		NrOfIcelandicQuotes: apiModel.NrOfIcelandicQuotes,
+		NrOfEnglishQuotes:   apiModel.NrOfEnglishQuotes,
+		Count:               apiModel.Count,
+	}
+	author.SetProfile(AuthorProfile{
+		BirthDate:   apiModel.BirthDate,
+		DeathDate:   apiModel.DeathDate,
+		Nationality: apiModel.Nationality,
+		Professions: apiModel.Professions,
+		Bio:         apiModel.Bio,
+		Links:       apiModel.Links,
+	})
+	return author
 }
 
 func ConvertToAuthorsAPIModel(authors []AuthorDBModel) []AuthorAPIModel {
 	authorsAPI := []AuthorAPIModel{}
 	for _, author := range authors {
-		authorsAPI = append(authorsAPI, AuthorAPIModel(author))
+		authorsAPI = append(authorsAPI, author.ConvertToAPIModel())
 	}
 	return authorsAPI
 }
@@ -50,7 +177,7 @@ func ConvertToAuthorsAPIModel(authors []AuthorDBModel) []AuthorAPIModel {
 func ConvertToAuthorsDBModel(authors []AuthorAPIModel) []AuthorDBModel {
 	authorsDB := []AuthorDBModel{}
 	for _, author := range authors {
-		authorsDB = append(authorsDB, AuthorDBModel(author))
+		authorsDB = append(authorsDB, author.ConvertToDBModel())
 	}
 	return authorsDB
 }
@@ -0,0 +1,64 @@
+package structs
+
+import "strings"
+
+//snippetLength is how many characters of a quote are returned as its snippet
+const snippetLength = 80
+
+//AutocompleteDBModel is what a prefix completes to, each list the most popular first
+type AutocompleteDBModel struct {
+	Authors []AuthorDBModel
+	Topics  []TopicDBModel
+	Quotes  []SearchViewDBModel
+}
+
+type CompletionAPIModel struct {
+	// The id of the author / topic / quote
+	// example: 1
+	Id int `json:"id"`
+	// The name of the author / topic, or the beginning of the quote
+	// example: Friedrich Nietzsche
+	Text string `json:"text"`
+	// The id of the quote's author
+	// example: 2
+	AuthorId int `json:"authorId,omitempty"`
+	// The name of the quote's author
+	// example: Friedrich Nietzsche
+	Name string `json:"name,omitempty"`
+}
+
+type AutocompleteAPIModel struct {
+	// The authors whose names have words starting with the prefix
+	Authors []CompletionAPIModel `json:"authors"`
+	// The topics whose names have words starting with the prefix
+	Topics []CompletionAPIModel `json:"topics"`
+	// Snippets of the quotes that have words starting with the prefix
+	Quotes []CompletionAPIModel `json:"quotes"`
+}
+
+func (dbModel *AutocompleteDBModel) ConvertToAPIModel() AutocompleteAPIModel {
+	apiModel := AutocompleteAPIModel{Authors: []CompletionAPIModel{}, Topics: []CompletionAPIModel{}, Quotes: []CompletionAPIModel{}}
+	for _, author := range dbModel.Authors {
+		apiModel.Authors = append(apiModel.Authors, CompletionAPIModel{Id: author.Id, Text: author.Name})
+	}
+	for _, topic := range dbModel.Topics {
+		apiModel.Topics = append(apiModel.Topics, CompletionAPIModel{Id: topic.Id, Text: topic.Name})
+	}
+	for _, quote := range dbModel.Quotes {
+		apiModel.Quotes = append(apiModel.Quotes, CompletionAPIModel{Id: quote.QuoteId, Text: snippet(quote.Quote), AuthorId: quote.AuthorId, Name: quote.Name})
+	}
+	return apiModel
+}
+
+//snippet returns the beginning of the quote, cut at a space and ending with an ellipsis if the quote is too long
+func snippet(quote string) string {
+	runes := []rune(quote)
+	if len(runes) <= snippetLength {
+		return quote
+	}
+	cut := string(runes[:snippetLength])
+	if space := strings.LastIndex(cut, " "); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " ,;:-") + "…"
+}
@@ -0,0 +1,76 @@
+package structs
+
+import "time"
+
+type CollectionDBModel struct {
+	Id         int       `json:"id,omitempty"`
+	UserId     int       `json:"user_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Slug       string    `json:"slug,omitempty"`
+	IsPublic   bool      `json:"is_public,omitempty"`
+	NrOfQuotes int       `json:"nr_of_quotes,omitempty" gorm:"->"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+}
+
+type CollectionAPIModel struct {
+	// The collection's id
+	// example: 12
+	Id int `json:"id,omitempty"`
+	// The name of the collection
+	// example: Wedding toasts
+	Name string `json:"name,omitempty"`
+	// The slug others can read the collection by, if it is public
+	// example: wedding-toasts-1d8db1d2
+	Slug string `json:"slug,omitempty"`
+	// Whether the collection can be read by others through its slug
+	// example: true
+	IsPublic bool `json:"isPublic,omitempty"`
+	// How many quotes are in the collection
+	// example: 7
+	NrOfQuotes int `json:"nrOfQuotes,omitempty"`
+	// The quotes in the collection, in order
+	Quotes []CollectionQuoteAPIModel `json:"quotes,omitempty"`
+}
+
+//CollectionQuoteDBModel is a quote in a collection, a searchview row with the quote's position and note in the collection
+type CollectionQuoteDBModel struct {
+	SearchViewDBModel
+	Position int    `json:"position,omitempty"`
+	Note     string `json:"note,omitempty"`
+}
+
+type CollectionQuoteAPIModel struct {
+	SearchViewAPIModel
+	// The position of the quote in the collection, starting from 1
+	// example: 1
+	Position int `json:"position,omitempty"`
+	// The user's note on the quote
+	// example: For the best man's speech
+	Note string `json:"note,omitempty"`
+}
+
+func (dbModel *CollectionDBModel) ConvertToAPIModel() CollectionAPIModel {
+	return CollectionAPIModel{
+		Id:         dbModel.Id,
+		Name:       dbModel.Name,
+		Slug:       dbModel.Slug,
+		IsPublic:   dbModel.IsPublic,
+		NrOfQuotes: dbModel.NrOfQuotes,
+	}
+}
+
+func ConvertToCollectionsAPIModel(collections []CollectionDBModel) []CollectionAPIModel {
+	collectionsAPI := []CollectionAPIModel{}
+	for _, collection := range collections {
+		collectionsAPI = append(collectionsAPI, collection.ConvertToAPIModel())
+	}
+	return collectionsAPI
+}
+
+func ConvertToCollectionQuotesAPIModel(quotes []CollectionQuoteDBModel) []CollectionQuoteAPIModel {
+	quotesAPI := []CollectionQuoteAPIModel{}
+	for _, quote := range quotes {
+		quotesAPI = append(quotesAPI, CollectionQuoteAPIModel{SearchViewAPIModel: quote.ConvertToAPIModel(), Position: quote.Position, Note: quote.Note})
+	}
+	return quotesAPI
+}
@@ -0,0 +1,41 @@
+package structs
+
+import "time"
+
+type DeletedDBModel struct {
+	Id        int       `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	AuthorId  int       `json:"author_id,omitempty"`
+	Quote     string    `json:"quote,omitempty"`
+	DeletedAt time.Time `json:"deleted_at,omitempty"`
+}
+
+type DeletedAPIModel struct {
+	// The id of the deleted author, quote or topic
+	// example: 582676
+	Id int `json:"id,omitempty"`
+	// The name of the deleted author or topic, or the name of the deleted quote's author
+	// example: Muhammad Ali
+	Name string `json:"name,omitempty"`
+	// The author's id of the deleted quote
+	// example: 24952
+	AuthorId int `json:"authorId,omitempty"`
+	// The deleted quote
+	// example: Float like a butterfly, sting like a bee.
+	Quote string `json:"quote,omitempty"`
+	// When it was deleted
+	// example: 2021-06-12T10:15:00Z
+	DeletedAt time.Time `json:"deletedAt,omitempty"`
+}
+
+func (dbModel *DeletedDBModel) ConvertToAPIModel() DeletedAPIModel {
+	return DeletedAPIModel(*dbModel)
+}
+
+func ConvertToDeletedAPIModel(deleted []DeletedDBModel) []DeletedAPIModel {
+	deletedAPI := []DeletedAPIModel{}
+	for _, item := range deleted {
+		deletedAPI = append(deletedAPI, DeletedAPIModel(item))
+	}
+	return deletedAPI
+}
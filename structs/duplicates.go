@@ -0,0 +1,52 @@
+package structs
+
+//SimilarQuoteDBModel is an existing quote, in the searchview shape, with its trigram similarity to another quote
+type SimilarQuoteDBModel struct {
+	SearchViewDBModel
+	Similarity float64 `json:"similarity,omitempty"`
+}
+
+type SimilarQuoteAPIModel struct {
+	SearchViewAPIModel
+	// The trigram similarity, from 0 to 1, of the normalized quotes
+	// example: 0.87
+	Similarity float64 `json:"similarity,omitempty"`
+}
+
+//DuplicateClusterDBModel is a group of quotes that are near duplicates of each other, the suggested survivor first
+type DuplicateClusterDBModel struct {
+	Quotes []SimilarQuoteDBModel
+}
+
+type DuplicateClusterAPIModel struct {
+	// The quotes in the cluster, the suggested survivor (the most popular one) first. The similarity of each of the
+	// others is to the survivor
+	Quotes []SimilarQuoteAPIModel `json:"quotes,omitempty"`
+}
+
+//CreatedQuoteAPIModel is the response to a new quote, it lists the existing quotes the new one is similar to
+type CreatedQuoteAPIModel struct {
+	QuoteAPIModel
+	// Existing quotes that are very similar to the new one, a warning that it may be a duplicate
+	SimilarQuotes []SimilarQuoteAPIModel `json:"similarQuotes,omitempty"`
+}
+
+func (dbModel *SimilarQuoteDBModel) ConvertToAPIModel() SimilarQuoteAPIModel {
+	return SimilarQuoteAPIModel{SearchViewAPIModel: dbModel.SearchViewDBModel.ConvertToAPIModel(), Similarity: dbModel.Similarity}
+}
+
+func ConvertToSimilarQuotesAPIModel(quotes []SimilarQuoteDBModel) []SimilarQuoteAPIModel {
+	quotesAPI := []SimilarQuoteAPIModel{}
+	for _, quote := range quotes {
+		quotesAPI = append(quotesAPI, quote.ConvertToAPIModel())
+	}
+	return quotesAPI
+}
+
+func ConvertToDuplicateClustersAPIModel(clusters []DuplicateClusterDBModel) []DuplicateClusterAPIModel {
+	clustersAPI := []DuplicateClusterAPIModel{}
+	for _, cluster := range clusters {
+		clustersAPI = append(clustersAPI, DuplicateClusterAPIModel{Quotes: ConvertToSimilarQuotesAPIModel(cluster.Quotes)})
+	}
+	return clustersAPI
+}
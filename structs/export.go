@@ -0,0 +1,123 @@
+package structs
+
+import "strconv"
+
+//The types of the records of the export
+const (
+	ExportRecordAuthor = "author"
+	ExportRecordTopic  = "topic"
+	ExportRecordQuote  = "quote"
+)
+
+type ExportRowAPIModel struct {
+	// The type of the record, always quote
+	// example: quote
+	Type string `json:"type"`
+	// The author's id
+	// example: 24952
+	AuthorId int `json:"authorId"`
+	// Name of author
+	// example: Muhammad Ali
+	Name string `json:"name"`
+	// The quote's id
+	// example: 582676
+	QuoteId int `json:"quoteId"`
+	// The quote
+	// example: Float like a butterfly, sting like a bee.
+	Quote string `json:"quote"`
+	// Whether or not this quote is in Icelandic
+	// example: false
+	IsIcelandic bool `json:"isIcelandic"`
+	// The topic's id, only when exporting the topics
+	// example: 10
+	TopicId int `json:"topicId,omitempty"`
+	// The topic's name, only when exporting the topics
+	// example: inspirational
+	TopicName string `json:"topicName,omitempty"`
+}
+
+func (dbModel *TopicViewDBModel) ConvertToExportRowAPIModel() ExportRowAPIModel {
+	return ExportRowAPIModel{
+		Type:        ExportRecordQuote,
+		AuthorId:    dbModel.AuthorId,
+		Name:        dbModel.Name,
+		QuoteId:     dbModel.QuoteId,
+		Quote:       dbModel.Quote,
+		IsIcelandic: dbModel.IsIcelandic,
+		TopicId:     dbModel.TopicId,
+		TopicName:   dbModel.TopicName,
+	}
+}
+
+//ExportCSVHeader returns the CSV header of the export, with the topic columns if withTopics
+func ExportCSVHeader(withTopics bool) []string {
+	header := []string{"authorId", "name", "quoteId", "quote", "isIcelandic"}
+	if withTopics {
+		header = append(header, "topicId", "topicName")
+	}
+	return header
+}
+
+//CSVRecord returns the row as a CSV record in the order of ExportCSVHeader
+func (row *ExportRowAPIModel) CSVRecord(withTopics bool) []string {
+	record := []string{strconv.Itoa(row.AuthorId), row.Name, strconv.Itoa(row.QuoteId), row.Quote, strconv.FormatBool(row.IsIcelandic)}
+	if withTopics {
+		record = append(record, strconv.Itoa(row.TopicId), row.TopicName)
+	}
+	return record
+}
+
+type ExportAuthorAPIModel struct {
+	// The type of the record, always author
+	// example: author
+	Type string `json:"type"`
+	// The author's id
+	// example: 24952
+	Id int `json:"id"`
+	// Name of author
+	// example: Muhammad Ali
+	Name string `json:"name"`
+}
+
+func (dbModel *AuthorDBModel) ConvertToExportAuthorAPIModel() ExportAuthorAPIModel {
+	return ExportAuthorAPIModel{Type: ExportRecordAuthor, Id: dbModel.Id, Name: dbModel.Name}
+}
+
+//ExportAuthorCSVHeader returns the CSV header of the export of the authors
+func ExportAuthorCSVHeader() []string {
+	return []string{"id", "name"}
+}
+
+//CSVRecord returns the author as a CSV record in the order of ExportAuthorCSVHeader
+func (author *ExportAuthorAPIModel) CSVRecord() []string {
+	return []string{strconv.Itoa(author.Id), author.Name}
+}
+
+type ExportTopicAPIModel struct {
+	// The type of the record, always topic
+	// example: topic
+	Type string `json:"type"`
+	// The topic's id
+	// example: 10
+	Id int `json:"id"`
+	// The topic's name
+	// example: inspirational
+	Name string `json:"name"`
+	// Whether or not this topic is in Icelandic
+	// example: false
+	IsIcelandic bool `json:"isIcelandic"`
+}
+
+func (dbModel *TopicDBModel) ConvertToExportTopicAPIModel() ExportTopicAPIModel {
+	return ExportTopicAPIModel{Type: ExportRecordTopic, Id: dbModel.Id, Name: dbModel.Name, IsIcelandic: dbModel.IsIcelandic}
+}
+
+//ExportTopicCSVHeader returns the CSV header of the export of the topics
+func ExportTopicCSVHeader() []string {
+	return []string{"id", "name", "isIcelandic"}
+}
+
+//CSVRecord returns the topic as a CSV record in the order of ExportTopicCSVHeader
+func (topic *ExportTopicAPIModel) CSVRecord() []string {
+	return []string{strconv.Itoa(topic.Id), topic.Name, strconv.FormatBool(topic.IsIcelandic)}
+}
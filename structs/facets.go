@@ -0,0 +1,66 @@
+package structs
+
+//FacetDBModel is a topic / author along with how many of the matched quotes are in it / by it
+type FacetDBModel struct {
+	Id    int
+	Name  string
+	Count int
+}
+
+//FacetsDBModel counts the quotes a search matches, on all pages, by language and by their topics and authors
+type FacetsDBModel struct {
+	English   int
+	Icelandic int
+	Topics    []FacetDBModel
+	Authors   []FacetDBModel
+}
+
+type FacetAPIModel struct {
+	// The id of the topic / author, to filter the search by with topicIds / authorIds
+	// example: 3
+	Id int `json:"id"`
+	// The name of the topic / author
+	// example: life
+	Name string `json:"name"`
+	// The number of matched quotes in the topic / by the author
+	// example: 12
+	Count int `json:"count"`
+}
+
+type LanguageFacetsAPIModel struct {
+	// The number of matched quotes in English
+	// example: 312
+	English int `json:"english"`
+	// The number of matched quotes in Icelandic
+	// example: 14
+	Icelandic int `json:"icelandic"`
+}
+
+type FacetsAPIModel struct {
+	// The number of quotes the search matches on all pages
+	// example: 326
+	Total int `json:"total"`
+	// The matched quotes by language, filter the search by one with language
+	Languages LanguageFacetsAPIModel `json:"languages"`
+	// The topics with the most matched quotes, the most first
+	Topics []FacetAPIModel `json:"topics"`
+	// The authors with the most matched quotes, the most first
+	Authors []FacetAPIModel `json:"authors"`
+}
+
+func (dbModel *FacetsDBModel) ConvertToAPIModel() FacetsAPIModel {
+	return FacetsAPIModel{
+		Total:     dbModel.English + dbModel.Icelandic,
+		Languages: LanguageFacetsAPIModel{English: dbModel.English, Icelandic: dbModel.Icelandic},
+		Topics:    convertToFacetsAPIModel(dbModel.Topics),
+		Authors:   convertToFacetsAPIModel(dbModel.Authors),
+	}
+}
+
+func convertToFacetsAPIModel(facets []FacetDBModel) []FacetAPIModel {
+	facetsAPI := []FacetAPIModel{}
+	for _, facet := range facets {
+		facetsAPI = append(facetsAPI, FacetAPIModel{Id: facet.Id, Name: facet.Name, Count: facet.Count})
+	}
+	return facetsAPI
+}
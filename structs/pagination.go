@@ -0,0 +1,38 @@
+package structs
+
+//TotalDBModel is the number of rows on all the pages of a list / search, the planner's estimate if there are too many
+//rows to count
+type TotalDBModel struct {
+	Total     int
+	Estimated bool
+}
+
+//PageAPIModel is the response of the list and search routes when withPagination is set
+type PageAPIModel struct {
+	// The quotes / authors on the page, the same as the response without withPagination
+	Items interface{} `json:"items"`
+	// The page, starts with 0
+	// example: 0
+	Page int `json:"page"`
+	// The number of items on each page
+	// example: 25
+	PageSize int `json:"pageSize"`
+	// The number of items on all the pages
+	// example: 312
+	Total int `json:"total"`
+	// Whether the total is the database's estimate, for lists and searches with more than 10000 items
+	// example: false
+	TotalIsEstimate bool `json:"totalIsEstimate,omitempty"`
+	// Whether there is a next page
+	// example: true
+	HasMore bool `json:"hasMore"`
+	// The request body for the next page, without the apiKey, if there is one
+	Next *Request `json:"next,omitempty"`
+	// The cursor of the next page of the quotes / authors list, if there is one. It is also set in next
+	// example: eyJvIjoicXVvdGVzIHF1b3RlX2lkIEFTQyIsImsiOiIyNSIsImkiOjI1fQ
+	NextCursor string `json:"nextCursor,omitempty"`
+	// Corrected search strings, from the search routes when didYouMean is set
+	Suggestions []SuggestionAPIModel `json:"suggestions,omitempty"`
+	// The counts of all the matched quotes, from the quote searches when facets is set
+	Facets *FacetsAPIModel `json:"facets,omitempty"`
+}
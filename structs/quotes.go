@@ -5,7 +5,9 @@ type QuoteDBModel struct {
 	AuthorId    int    `json:"author_id,omitempty"`
 	Quote       string `json:"quote,omitempty"`
 	Count       int    `json:"count,omitempty"`
-	IsIcelandic string `json:"is_icelandic,omitempty"`
+	IsIcelandic bool   `json:"is_icelandic,omitempty"`
+	IsPrivate   bool   `json:"is_private,omitempty"`
+	UserId      int    `json:"user_id,omitempty"`
 }
 
 type QuoteAPIModel struct {
@@ -13,7 +15,12 @@ type QuoteAPIModel struct {
 	AuthorId    int    `json:"authorId,omitempty"`
 	Quote       string `json:"quote,omitempty"`
 	Count       int    `json:"count,omitempty"`
-	IsIcelandic string `json:"isIcelandic,omitempty"`
+	IsIcelandic bool   `json:"isIcelandic,omitempty"`
+	// Whether the quote is only returned for the user that created it
+	// example: false
+	IsPrivate bool `json:"isPrivate,omitempty"`
+	// The id of the user that created the quote, if created through the api
+	UserId int `json:"userId,omitempty"`
 }
 
 func (dbModel *QuoteDBModel) ConvertToAPIModel() QuoteAPIModel {
@@ -40,6 +47,18 @@ func ConvertToQuotesDBModel(authors []QuoteAPIModel) []QuoteDBModel {
 	return authorsDB
 }
 
+//QuoteInsertModel is a new quote, with its topics given by name and its author either by id (AuthorId) or by name.
+//Private quotes are left out of the views and only returned for the user that created them (UserId).
+type QuoteInsertModel struct {
+	Quote       string   `json:"quote,omitempty"`
+	Author      string   `json:"author,omitempty"`
+	AuthorId    int      `json:"authorId,omitempty"`
+	IsIcelandic bool     `json:"isIcelandic,omitempty"`
+	Topics      []string `json:"topics,omitempty"`
+	IsPrivate   bool     `json:"isPrivate,omitempty"`
+	UserId      int      `json:"userId,omitempty"`
+}
+
 type QodViewDBModel struct {
 	QuoteId     int    `json:"quote_id,omitempty"`
 	Name        string `json:"name,omitempty"`
@@ -47,6 +66,8 @@ type QodViewDBModel struct {
 	AuthorId    int    `json:"author_id,omitempty"`
 	IsIcelandic bool   `json:"is_icelandic,omitempty"`
 	Date        string `json:"date,omitempty"`
+	//Source is only set when it is asked for, see withSource
+	Source *SourceAPIModel `json:"source,omitempty" gorm:"-"`
 }
 
 type QodViewAPIModel struct {
@@ -67,6 +88,8 @@ type QodViewAPIModel struct {
 	// The date when this quote was the quote of the day
 	// example: 2021-06-12T00:00:00Z
 	Date string `json:"date,omitempty"`
+	// The work the quote is cited from and the status of the attribution, only returned if withSource is true
+	Source *SourceAPIModel `json:"source,omitempty"`
 }
 
 func (dbModel *QodViewDBModel) ConvertToAPIModel() QodViewAPIModel {
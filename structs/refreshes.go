@@ -0,0 +1,37 @@
+package structs
+
+import "time"
+
+type ViewRefreshDBModel struct {
+	ViewName    string     `json:"view_name,omitempty"`
+	RefreshedAt *time.Time `json:"refreshed_at,omitempty"`
+	DurationMs  int        `json:"duration_ms,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+type ViewRefreshAPIModel struct {
+	// The name of the materialized view
+	// example: searchview
+	ViewName string `json:"viewName"`
+	// When the view was last refreshed, empty if it has not been refreshed by the api
+	// example: 2021-06-12T10:15:00Z
+	LastRefreshedAt *time.Time `json:"lastRefreshedAt,omitempty"`
+	// How long the last refresh took in milliseconds
+	// example: 1250
+	DurationMs int `json:"durationMs,omitempty"`
+	// The error of the last refresh attempt, if it failed
+	// example: could not refresh the view
+	Error string `json:"error,omitempty"`
+	// Whether there have been writes since the view was last refreshed
+	// example: false
+	Stale bool `json:"stale"`
+}
+
+func (dbModel *ViewRefreshDBModel) ConvertToAPIModel() ViewRefreshAPIModel {
+	return ViewRefreshAPIModel{
+		ViewName:        dbModel.ViewName,
+		LastRefreshedAt: dbModel.RefreshedAt,
+		DurationMs:      dbModel.DurationMs,
+		Error:           dbModel.Error,
+	}
+}
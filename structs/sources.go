@@ -0,0 +1,84 @@
+package structs
+
+import "time"
+
+//The kinds of works a quote can be cited from
+const (
+	SourceBook      = "book"
+	SourceSpeech    = "speech"
+	SourceFilm      = "film"
+	SourceInterview = "interview"
+	SourceOther     = "other"
+)
+
+//SourceTypes are the supported kinds of works
+var SourceTypes = []string{SourceBook, SourceSpeech, SourceFilm, SourceInterview, SourceOther}
+
+//The states of a quote's attribution to its source, a quote is unverified until it is cited and checked
+const (
+	SourceUnverified    = "unverified"
+	SourceVerified      = "verified"
+	SourceMisattributed = "misattributed"
+)
+
+//SourceStatuses are the supported states of a quote's attribution
+var SourceStatuses = []string{SourceUnverified, SourceVerified, SourceMisattributed}
+
+//SourceDBModel is a work, e.g. a book or a speech, that quotes are cited from
+type SourceDBModel struct {
+	Id        int       `json:"id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Type      string    `json:"type,omitempty"`
+	Year      int       `json:"year,omitempty"`
+	Url       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+type SourceAPIModel struct {
+	// The source's id
+	// example: 3
+	Id int `json:"id,omitempty"`
+	// The title of the work
+	// example: The Greatest: My Own Story
+	Title string `json:"title,omitempty"`
+	// The kind of work, one of book, speech, film, interview or other
+	// example: book
+	Type string `json:"type,omitempty"`
+	// The year the work was published / given
+	// example: 1975
+	Year int `json:"year,omitempty"`
+	// A link to the work
+	// example: https://en.wikipedia.org/wiki/The_Greatest:_My_Own_Story
+	Url string `json:"url,omitempty"`
+	// Whether the quote's attribution to the work is unverified, verified or misattributed (only set when the source
+	// is returned with a quote)
+	// example: verified
+	Status string `json:"status,omitempty"`
+}
+
+//CitationDBModel is a quote's source along with the status of the quote's attribution to it
+type CitationDBModel struct {
+	QuoteId  int    `json:"quote_id,omitempty"`
+	SourceId int    `json:"source_id,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Year     int    `json:"year,omitempty"`
+	Url      string `json:"url,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+func (dbModel *SourceDBModel) ConvertToAPIModel() SourceAPIModel {
+	return SourceAPIModel{Id: dbModel.Id, Title: dbModel.Title, Type: dbModel.Type, Year: dbModel.Year, Url: dbModel.Url}
+}
+
+func ConvertToSourcesAPIModel(sources []SourceDBModel) []SourceAPIModel {
+	sourcesAPI := []SourceAPIModel{}
+	for _, source := range sources {
+		sourcesAPI = append(sourcesAPI, source.ConvertToAPIModel())
+	}
+	return sourcesAPI
+}
+
+func (dbModel *CitationDBModel) ConvertToAPIModel() SourceAPIModel {
+	return SourceAPIModel{Id: dbModel.SourceId, Title: dbModel.Title, Type: dbModel.Type, Year: dbModel.Year, Url: dbModel.Url, Status: dbModel.Status}
+}
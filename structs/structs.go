@@ -3,24 +3,70 @@ package structs
 import "encoding/json"
 
 type Request struct {
-	Ids          []int       `json:"ids,omitempty"`
-	Id           int         `json:"id,omitempty"`
-	Page         int         `json:"page,omitempty"`
-	SearchString string      `json:"searchString,omitempty"`
-	PageSize     int         `json:"pageSize,omitempty"`
-	Language     string      `json:"language,omitempty"`
-	Topic        string      `json:"topic,omitempty"`
-	AuthorId     int         `json:"authorId,omitempty"`
-	QuoteId      int         `json:"quoteId,omitempty"`
-	TopicId      int         `json:"topicId,omitempty"`
-	MaxQuotes    int         `json:"maxQuotes,omitempty"`
-	OrderConfig  OrderConfig `json:"orderConfig,omitempty"`
-	Date         string      `json:"date,omitempty"`
-	Minimum      string      `json:"minimum,omitempty"`
-	Maximum      string      `json:"maximum,omitempty"`
-	Qods         []Qod       `json:"qods,omitempty"`
-	Aods         []Qod       `json:"aods,omitempty"`
-	ApiKey       string      `json:"apiKey,omitempty"`
+	Ids          []int        `json:"ids,omitempty"`
+	Id           int          `json:"id,omitempty"`
+	Page         int          `json:"page,omitempty"`
+	SearchString string       `json:"searchString,omitempty"`
+	PageSize     int          `json:"pageSize,omitempty"`
+	Language     string       `json:"language,omitempty"`
+	Topic        string       `json:"topic,omitempty"`
+	AuthorId     int          `json:"authorId,omitempty"`
+	QuoteId      int          `json:"quoteId,omitempty"`
+	TopicId      int          `json:"topicId,omitempty"`
+	MaxQuotes    int          `json:"maxQuotes,omitempty"`
+	OrderConfig  OrderConfig  `json:"orderConfig,omitempty"`
+	Date         string       `json:"date,omitempty"`
+	Minimum      string       `json:"minimum,omitempty"`
+	Maximum      string       `json:"maximum,omitempty"`
+	Qods         []Qod        `json:"qods,omitempty"`
+	Aods         []Qod        `json:"aods,omitempty"`
+	ApiKey       string       `json:"apiKey,omitempty"`
+	Views        []string     `json:"views,omitempty"`
+	Format       string       `json:"format,omitempty"`
+	WithTopics   bool         `json:"withTopics,omitempty"`
+	Type         string       `json:"type,omitempty"`
+	Quote        string       `json:"quote,omitempty"`
+	Author       string       `json:"author,omitempty"`
+	Topics       []string     `json:"topics,omitempty"`
+	Private      bool         `json:"private,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	Public       bool         `json:"public,omitempty"`
+	Note         string       `json:"note,omitempty"`
+	Slug         string       `json:"slug,omitempty"`
+	Status       string       `json:"status,omitempty"`
+	Reason       string       `json:"reason,omitempty"`
+	Similarity   float64      `json:"similarity,omitempty"`
+	Title        string       `json:"title,omitempty"`
+	Year         int          `json:"year,omitempty"`
+	Url          string       `json:"url,omitempty"`
+	SourceId     int          `json:"sourceId,omitempty"`
+	WithSource   bool         `json:"withSource,omitempty"`
+	Verified     bool         `json:"verified,omitempty"`
+	BirthDate    string       `json:"birthDate,omitempty"`
+	DeathDate    string       `json:"deathDate,omitempty"`
+	Nationality  string       `json:"nationality,omitempty"`
+	Professions  []string     `json:"professions,omitempty"`
+	Bio          string       `json:"bio,omitempty"`
+	Links        []AuthorLink `json:"links,omitempty"`
+	Profession   string       `json:"profession,omitempty"`
+	BornBefore   int          `json:"bornBefore,omitempty"`
+	BornAfter    int          `json:"bornAfter,omitempty"`
+	Aliases      []string     `json:"aliases,omitempty"`
+	Authors      []string     `json:"authors,omitempty"`
+	DidYouMean   bool         `json:"didYouMean,omitempty"`
+	//Highlight is nil unless the search results should be highlighted
+	Highlight *HighlightConfig `json:"highlight,omitempty"`
+	//Facets is nil unless the search results should be counted by language, topic and author
+	Facets    *FacetConfig `json:"facets,omitempty"`
+	AuthorIds []int        `json:"authorIds,omitempty"`
+	TopicIds  []int        `json:"topicIds,omitempty"`
+	//WithPagination wraps the results of the list and search routes in a PageAPIModel
+	WithPagination bool `json:"withPagination,omitempty"`
+	//Cursor is the position in the quotes / authors list to continue from, see nextCursor of PageAPIModel
+	Cursor string `json:"cursor,omitempty"`
+	//SameAuthor ranks the quotes by the same author higher, and ExcludeSameAuthor leaves them out, in the related quotes
+	SameAuthor        bool `json:"sameAuthor,omitempty"`
+	ExcludeSameAuthor bool `json:"excludeSameAuthor,omitempty"`
 }
 
 type OrderConfig struct {
@@ -38,9 +84,32 @@ type OrderConfig struct {
 	Reverse bool `json:"reverse,omitempty"`
 }
 
+type HighlightConfig struct {
+	// The marker put in front of each matched word
+	// example: <mark>
+	Start string `json:"start,omitempty"`
+	// The marker put behind each matched word
+	// example: </mark>
+	Stop string `json:"stop,omitempty"`
+	// The maximum number of words in the highlighted fragment, from 2 to 100
+	// example: 20
+	MaxWords int `json:"maxWords,omitempty"`
+	// The maximum number of fragments, separated by ' ... ', if 0 a single fragment is returned
+	// example: 2
+	MaxFragments int `json:"maxFragments,omitempty"`
+}
+
+type FacetConfig struct {
+	// The number of topics and authors with the most matches to count, from 1 to 50
+	// example: 5
+	Limit int `json:"limit,omitempty"`
+}
+
 type ErrorResponse struct {
 	Message    string `json:"message,omitempty"`
 	StatusCode int    `json:"status_code,omitempty"`
+	//The 1-based position of the error in the searchString of an invalid search query
+	Position int `json:"position,omitempty"`
 }
 
 func (errorResponse *ErrorResponse) ToString() string {
@@ -0,0 +1,126 @@
+package structs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+//The states of a submitted quote, a submission is pending until a GOD-tier user approves or rejects it
+const (
+	SubmissionPending  = "pending"
+	SubmissionApproved = "approved"
+	SubmissionRejected = "rejected"
+)
+
+//SubmissionDBModel is a quote submitted by a user, waiting for moderation. Topics is a json array of the topic names
+type SubmissionDBModel struct {
+	Id          int        `json:"id,omitempty"`
+	UserId      int        `json:"user_id,omitempty"`
+	Quote       string     `json:"quote,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	AuthorId    int        `json:"author_id,omitempty"`
+	IsIcelandic bool       `json:"is_icelandic,omitempty"`
+	Topics      string     `json:"topics,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Reason      string     `json:"reason,omitempty"`
+	QuoteId     int        `json:"quote_id,omitempty"`
+	ReviewedBy  int        `json:"reviewed_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type SubmissionAPIModel struct {
+	// The submission's id
+	// example: 31
+	Id int `json:"id,omitempty"`
+	// The id of the user that submitted the quote
+	// example: 1
+	UserId int `json:"userId,omitempty"`
+	// The submitted quote
+	// example: Stay hungry, stay foolish.
+	Quote string `json:"quote,omitempty"`
+	// The name of the author, if the quote was not submitted for an existing author
+	// example: Steve Jobs
+	Author string `json:"author,omitempty"`
+	// The id of the existing author of the quote
+	// example: 24952
+	AuthorId int `json:"authorId,omitempty"`
+	// Whether the quote is in Icelandic
+	// example: false
+	IsIcelandic bool `json:"isIcelandic,omitempty"`
+	// The topics of the quote
+	// example: ["Motivational"]
+	Topics []string `json:"topics,omitempty"`
+	// Either pending, approved or rejected
+	// example: pending
+	Status string `json:"status,omitempty"`
+	// Why the submission was rejected
+	// example: This quote is misattributed
+	Reason string `json:"reason,omitempty"`
+	// The id of the published quote, once the submission is approved
+	// example: 582677
+	QuoteId int `json:"quoteId,omitempty"`
+	// When the quote was submitted
+	// example: 2021-06-12T10:15:00Z
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+	// When the submission was approved or rejected
+	// example: 2021-06-13T08:00:00Z
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+	// Existing quotes that are very similar to the submitted one, a warning that it may be a duplicate
+	SimilarQuotes []SimilarQuoteAPIModel `json:"similarQuotes,omitempty"`
+}
+
+//TopicNames returns the names of the submission's topics
+func (dbModel *SubmissionDBModel) TopicNames() []string {
+	topics := []string{}
+	if dbModel.Topics != "" {
+		json.Unmarshal([]byte(dbModel.Topics), &topics)
+	}
+	return topics
+}
+
+//SetTopicNames sets the submission's topics from their names
+func (dbModel *SubmissionDBModel) SetTopicNames(topics []string) {
+	if topics == nil {
+		topics = []string{}
+	}
+	encoded, _ := json.Marshal(topics)
+	dbModel.Topics = string(encoded)
+}
+
+//ConvertToInsertModel returns the submitted quote as a new public quote by the submitter
+func (dbModel *SubmissionDBModel) ConvertToInsertModel() QuoteInsertModel {
+	return QuoteInsertModel{
+		Quote:       dbModel.Quote,
+		Author:      dbModel.Author,
+		AuthorId:    dbModel.AuthorId,
+		IsIcelandic: dbModel.IsIcelandic,
+		Topics:      dbModel.TopicNames(),
+		UserId:      dbModel.UserId,
+	}
+}
+
+func (dbModel *SubmissionDBModel) ConvertToAPIModel() SubmissionAPIModel {
+	return SubmissionAPIModel{
+		Id:          dbModel.Id,
+		UserId:      dbModel.UserId,
+		Quote:       dbModel.Quote,
+		Author:      dbModel.Author,
+		AuthorId:    dbModel.AuthorId,
+		IsIcelandic: dbModel.IsIcelandic,
+		Topics:      dbModel.TopicNames(),
+		Status:      dbModel.Status,
+		Reason:      dbModel.Reason,
+		QuoteId:     dbModel.QuoteId,
+		CreatedAt:   dbModel.CreatedAt,
+		ReviewedAt:  dbModel.ReviewedAt,
+	}
+}
+
+func ConvertToSubmissionsAPIModel(submissions []SubmissionDBModel) []SubmissionAPIModel {
+	submissionsAPI := []SubmissionAPIModel{}
+	for _, submission := range submissions {
+		submissionsAPI = append(submissionsAPI, submission.ConvertToAPIModel())
+	}
+	return submissionsAPI
+}
@@ -0,0 +1,48 @@
+package structs
+
+//SuggestionDBModel is a corrected search string with the average trigram similarity of the corrected words to the
+//words they replace
+type SuggestionDBModel struct {
+	SearchString string
+	Similarity   float64
+}
+
+type SuggestionAPIModel struct {
+	// The search string with the misspelled words corrected
+	// example: nietzsche
+	SearchString string `json:"searchString"`
+	// The average trigram similarity, from 0 to 1, of the corrected words to the words they replace
+	// example: 0.45
+	Similarity float64 `json:"similarity"`
+}
+
+//SearchResultsDBModel is a page of the results of a quote search, the strategy they were matched with and, if the
+//search asks for them, the total of all the pages and the facets of all the matched rows
+type SearchResultsDBModel struct {
+	Results  []TopicViewDBModel
+	Strategy string
+	Total    TotalDBModel
+	Facets   FacetsDBModel
+}
+
+//SearchResponseAPIModel is the response of the search routes when didYouMean or facets is set
+type SearchResponseAPIModel struct {
+	// The quotes / authors found, the same as the response without didYouMean and facets
+	Results interface{} `json:"results"`
+	// Corrected search strings, the most similar first, if the search found few or no results
+	Suggestions []SuggestionAPIModel `json:"suggestions,omitempty"`
+	// The counts of all the matched quotes by language, topic and author, if asked for
+	Facets *FacetsAPIModel `json:"facets,omitempty"`
+}
+
+func (dbModel *SuggestionDBModel) ConvertToAPIModel() SuggestionAPIModel {
+	return SuggestionAPIModel{SearchString: dbModel.SearchString, Similarity: dbModel.Similarity}
+}
+
+func ConvertToSuggestionsAPIModel(suggestions []SuggestionDBModel) []SuggestionAPIModel {
+	suggestionsAPI := []SuggestionAPIModel{}
+	for _, suggestion := range suggestions {
+		suggestionsAPI = append(suggestionsAPI, suggestion.ConvertToAPIModel())
+	}
+	return suggestionsAPI
+}
@@ -4,12 +4,16 @@ type TopicDBModel struct {
 	Id          int    `json:"id,omitempty"`
 	Name        string `json:"name,omitempty"`
 	IsIcelandic bool   `json:"is_icelandic,omitempty"`
+	NrOfQuotes  int    `json:"nr_of_quotes,omitempty"`
 }
 
 type TopicAPIModel struct {
 	Id          int    `json:"id,omitempty"`
 	Name        string `json:"name,omitempty"`
 	IsIcelandic bool   `json:"isIcelandic,omitempty"`
+	// How many public quotes are in the topic
+	// example: 132
+	NrOfQuotes int `json:"nrOfQuotes,omitempty"`
 }
 
 func (dbModel *TopicDBModel) ConvertToAPIModel() TopicAPIModel {
@@ -44,6 +48,12 @@ type TopicViewDBModel struct {
 	IsIcelandic bool   `json:"is_icelandic,omitempty"`
 	TopicName   string `json:"topic_name,omitempty"`
 	TopicId     int    `json:"topic_id,omitempty"`
+	//Source is only set when it is asked for, see withSource
+	Source *SourceAPIModel `json:"source,omitempty" gorm:"-"`
+	//Highlight is only set when it is asked for, see highlight
+	Highlight string `json:"highlight,omitempty"`
+	//Strategy is only set by the quote searches, see repository.StrategyFullText
+	Strategy string `json:"strategy,omitempty"`
 }
 
 type TopicViewAPIModel struct {
@@ -70,6 +80,16 @@ type TopicViewAPIModel struct {
 	// The topic's id (if topic id / name not supplied this will return a zero id)
 	// example: 10
 	TopicId int `json:"topicId,omitempty"`
+	// The work the quote is cited from and the status of the attribution, only returned if withSource is true
+	Source *SourceAPIModel `json:"source,omitempty"`
+	// The fragment of the quote with the words that matched the search between the highlight markers, only returned if
+	// highlight is set
+	// example: Float like a <b>butterfly</b>, sting like a bee.
+	Highlight string `json:"highlight,omitempty"`
+	// What the search string matched the quote with, fulltext, or phrase or trigram for a search string of only stop
+	// words, only returned by the searches
+	// example: fulltext
+	Strategy string `json:"strategy,omitempty"`
 }
 
 func (dbModel *TopicViewDBModel) ConvertToAPIModel() TopicViewAPIModel {
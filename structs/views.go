@@ -10,6 +10,8 @@ type SearchViewDBModel struct {
 	IsIcelandic bool   `json:"is_icelandic,omitempty"`
 	QuoteCount  int    `json:"quote_count,omitempty"`
 	AuthorCount int    `json:"author_count,omitempty"`
+	//Source is only set when it is asked for, see withSource
+	Source *SourceAPIModel `json:"source,omitempty" gorm:"-"`
 }
 
 type SearchViewAPIModel struct {
@@ -34,6 +36,8 @@ type SearchViewAPIModel struct {
 	QuoteCount int `json:"quoteCount,omitempty"`
 	//swagger:ignore
 	AuthorCount int `json:"authorCount,omitempty"`
+	// The work the quote is cited from and the status of the attribution, only returned if withSource is true
+	Source *SourceAPIModel `json:"source,omitempty"`
 }
 
 func (view *SearchViewAPIModel) ToString() string {
@@ -1,5 +1,7 @@
 package docs
 
+import "github.com/Skjaldbaka17/quotes-api/structs"
+
 // swagger:parameters GetAuthors
 type getAuthorsWrapper struct {
 	// The structure of the request for getting authors by their ids
@@ -8,9 +10,12 @@ type getAuthorsWrapper struct {
 	Body struct {
 		// A list of the authors's ids that you want
 		//
-		// Required: true
 		// Example: [24952,19161]
 		Ids []int `json:"ids"`
+		// A list of the names or aliases of the authors that you want, unknown names are left out
+		//
+		// Example: ["Cassius Clay"]
+		Authors []string `json:"authors"`
 		// The api-key you use to access the api
 		//
 		// Required: true
@@ -46,6 +51,35 @@ type authorsListWrapper struct {
 		// quotes the author has in the given language counts towards the final ordering.
 		// Example: English
 		Language string `json:"language"`
+		// Only return authors with the given profession
+		//
+		// Example: philosopher
+		Profession string `json:"profession"`
+		// Only return authors of the given nationality, an ISO 3166-1 alpha-2 country code
+		//
+		// Example: IS
+		Nationality string `json:"nationality"`
+		// Only return authors born before the given year (negative years are BC)
+		//
+		// Example: 1900
+		BornBefore int `json:"bornBefore"`
+		// Only return authors born after the given year (negative years are BC)
+		//
+		// Example: 1800
+		BornAfter int `json:"bornAfter"`
+		// Whether to answer with a page, i.e. {"items": [...], "page": 0, "pageSize": 25, "total": ..., "hasMore": true,
+		// "next": {...}}, where next is the request body for the next page. A total over 10000 is estimated and
+		// totalIsEstimate is then true
+		//
+		// Default: false
+		// Example: true
+		WithPagination bool `json:"withPagination"`
+		// The nextCursor of the previous page, to continue the list right after its last author. The page is then not an offset,
+		// so deep pages are as fast as the first one and the list does not skip or repeat authors whose counts change in
+		// between. A cursor only works with the orderConfig and language of the request it came from
+		//
+		// Example: eyJvIjoicXVvdGVzIHF1b3RlX2lkIEFTQyIsImsiOiIyNSIsImkiOjI1fQ
+		Cursor string `json:"cursor"`
 		//Model
 		OrderConfig orderConfigListAuthorsModel `json:"orderConfig"`
 	}
@@ -90,6 +124,12 @@ type ofTheDayWrapper struct {
 		// Default: English
 		// Example: English
 		Language string `json:"language"`
+		// Whether to return the source of the quote, i.e. the work it is cited from and the status of its attribution
+		// (only for the quotes of the day)
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
 	}
 }
 
@@ -111,6 +151,12 @@ type historyAODWrapper struct {
 		// The earliest date to return. All authors / quotes between minimum and today will be returned.
 		// Example: 2020-12-21
 		Minimum string `json:"minimum"`
+		// Whether to return the source of the quote, i.e. the work it is cited from and the status of its attribution
+		// (only for the quotes of the day)
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
 	}
 }
 
@@ -161,6 +207,10 @@ type getQuotesByWrapper struct {
 		// The id of the author of the quotes you want.
 		// Example: 24952
 		AuthorId int `json:"authorId"`
+		// The name or an alias of the author of the quotes, instead of the authorId
+		//
+		// Example: Cassius Clay
+		Author string `json:"author"`
 		// If using authorId the response is paged. This parameter controls the number of Authors to be returned on each "page"
 		//
 		// Maximum: 200
@@ -173,6 +223,52 @@ type getQuotesByWrapper struct {
 		// Minimum: 0
 		// Example: 0
 		Page int `json:"page"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+	}
+}
+
+// swagger:parameters CreateQuote
+type createQuoteWrapper struct {
+	// The structure of the request for creating a quote, give either the authorId of an existing author or the name of the author
+	// in: body
+	// required: true
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The quote
+		//
+		// Required: true
+		// Example: Float like a butterfly, sting like a bee.
+		Quote string `json:"quote"`
+		// The id of an existing author of the quote
+		//
+		// Example: 24952
+		AuthorId int `json:"authorId"`
+		// The name of the author, created if no author has the name
+		//
+		// Example: Muhammad Ali
+		Author string `json:"author"`
+		// The language of the quote, english or icelandic
+		//
+		// Default: english
+		// Example: english
+		Language string `json:"language"`
+		// The names of the topics of the quote, created if they do not exist
+		//
+		// Example: ["Motivational"]
+		Topics []string `json:"topics"`
+		// Whether the quote is private, i.e. only returned for your apiKey, or public, i.e. in the search results
+		//
+		// Default: false
+		// Example: true
+		Private bool `json:"private"`
 	}
 }
 
@@ -202,11 +298,83 @@ type quotesListWrapper struct {
 		// is set on the quotes' language.
 		// Example: English
 		Language string `json:"language"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+		// Only return the quotes whose attribution to their source has been verified
+		//
+		// Default: false
+		// Example: true
+		Verified bool `json:"verified"`
+		// Whether to answer with a page, i.e. {"items": [...], "page": 0, "pageSize": 25, "total": ..., "hasMore": true,
+		// "next": {...}}, where next is the request body for the next page. A total over 10000 is estimated and
+		// totalIsEstimate is then true
+		//
+		// Default: false
+		// Example: true
+		WithPagination bool `json:"withPagination"`
+		// The nextCursor of the previous page, to continue the list right after its last quote. The page is then not an offset,
+		// so deep pages are as fast as the first one and the list does not skip or repeat quotes whose counts change in
+		// between. A cursor only works with the orderConfig and language of the request it came from
+		//
+		// Example: eyJvIjoicXVvdGVzIHF1b3RlX2lkIEFTQyIsImsiOiIyNSIsImkiOjI1fQ
+		Cursor string `json:"cursor"`
 		//Model
 		OrderConfig orderConfigListQuotesModel `json:"orderConfig"`
 	}
 }
 
+// swagger:parameters GetRelatedQuotes
+type relatedQuotesWrapper struct {
+	// The structure of the request for the quotes related to a quote
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the quote to find related quotes for
+		//
+		// Required: true
+		// Example: 582676
+		Id int `json:"id"`
+		// The number of related quotes to return, the most similar first
+		//
+		// Maximum: 200
+		// Minimum: 1
+		// Default: 25
+		// Example: 5
+		PageSize int `json:"pageSize"`
+		// The page you are asking for, starts with 0.
+		//
+		// Minimum: 0
+		// Example: 0
+		Page int `json:"page"`
+		// Only return quotes in the given language ("english" or "icelandic"), if left empty quotes in both are returned
+		//
+		// Example: English
+		Language string `json:"language"`
+		// Rank the quotes by the same author higher, and return them even if they share no words or topics with the quote
+		//
+		// Default: false
+		// Example: true
+		SameAuthor bool `json:"sameAuthor"`
+		// Leave out the quotes by the same author
+		//
+		// Default: false
+		// Example: true
+		ExcludeSameAuthor bool `json:"excludeSameAuthor"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+	}
+}
+
 // swagger:parameters GetRandomQuote
 type getRandomQuoteResponseWrapper struct {
 	// The structure of the request for a random quote
@@ -221,7 +389,8 @@ type getRandomQuoteResponseWrapper struct {
 		//
 		// Example: English
 		Language string `json:"language"`
-		// The random quote returned must contain a match with the searchstring
+		// The random quote returned must contain a match with the searchstring. Supports "quoted phrases", -exclusions, OR
+		// and the author:, topic: and lang: filters, an invalid query is answered with a 400 and the position of the error
 		//
 		// Example: float
 		SearchString string `json:"searchString"`
@@ -233,6 +402,20 @@ type getRandomQuoteResponseWrapper struct {
 		//
 		//example: 24952
 		Authorid int `json:"authorId"`
+		// The name or an alias of the author of the random quote, instead of the authorId
+		//
+		// Example: Cassius Clay
+		Author string `json:"author"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+		// Only return the quotes whose attribution to their source has been verified
+		//
+		// Default: false
+		// Example: true
+		Verified bool `json:"verified"`
 	}
 }
 
@@ -247,10 +430,11 @@ type getSearchByStringWrapper struct {
 		// Required: true
 		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
 		ApiKey string `json:"apiKey"`
-		// The string to be used in the search
+		// The string to be used in the search. Supports "quoted phrases", -exclusions, OR and the author:, topic: and lang:
+		// filters, an invalid query is answered with a 400 and the position of the error
 		//
 		// Required: true
-		// Example: sting like butterfly
+		// Example: sting like butterfly -bee
 		SearchString string `json:"searchString"`
 		// The number of quotes to be returned on each "page"
 		//
@@ -264,13 +448,109 @@ type getSearchByStringWrapper struct {
 		// Minimum: 0
 		// Example: 0
 		Page int `json:"page"`
-		// The particular language that the quote should be in
+		// The particular language that the quote should be in, the search string is then parsed with only that language's
+		// text search configuration. Icelandic quotes match without þ, ð, æ or accents, e.g. thad for það
 		// example: English
 		Language string `json:"language"`
 		// Should search in the specified topic for the searchString
 		//
 		// Example: 10
 		TopicId int `json:"topicId"`
+		// Only return the quotes by the author with the given name or alias (only used by /search/quotes)
+		//
+		// Example: Cassius Clay
+		Author string `json:"author"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+		// Only return the quotes whose attribution to their source has been verified
+		//
+		// Default: false
+		// Example: true
+		Verified bool `json:"verified"`
+		// Whether to answer with an object with the results and, if the first page has fewer than 3 results, suggested
+		// corrections of the searchString, i.e. {"results": [...], "suggestions": [{"searchString": ..., "similarity": ...}]}
+		//
+		// Default: false
+		// Example: true
+		DidYouMean bool `json:"didYouMean"`
+		// Highlight the words of each quote that matched the search, with the same tsquery that ranked it. The markers
+		// default to <b> and </b> and the fragment to at most 35 words, an empty object uses the defaults
+		Highlight structs.HighlightConfig `json:"highlight"`
+		// Count all the quotes the search matches, on every page, by language and return the topics and authors with the
+		// most of them, i.e. {"results": [...], "facets": {"total": ..., "languages": {...}, "topics": [...], "authors": [...]}}.
+		// An empty object counts the top 10 topics and authors
+		Facets structs.FacetConfig `json:"facets"`
+		// Only return the quotes by one of the authors with the given ids, e.g. the ids of the clicked author facets
+		//
+		// Example: [2, 11]
+		AuthorIds []int `json:"authorIds"`
+		// Only return the quotes in one of the topics with the given ids, e.g. the ids of the clicked topic facets
+		//
+		// Example: [3]
+		TopicIds []int `json:"topicIds"`
+		// Whether to answer with a page, i.e. {"items": [...], "page": 0, "pageSize": 25, "total": ..., "hasMore": true,
+		// "next": {...}}, where next is the request body for the next page. A total over 10000 is estimated and
+		// totalIsEstimate is then true
+		//
+		// Default: false
+		// Example: true
+		WithPagination bool `json:"withPagination"`
+	}
+}
+
+// swagger:parameters SuggestSearchStrings
+type suggestSearchStringsWrapper struct {
+	// The structure of the request for suggested corrections of a search string
+	// in: body
+	// required: true
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The search string to correct, the same query language as in /search
+		//
+		// Required: true
+		// Example: nietshe
+		SearchString string `json:"searchString"`
+		// Which words to correct by, 'all' (the quotes and the names of the authors, like /search), 'quotes' or 'authors'
+		//
+		// Default: all
+		// Example: authors
+		Type string `json:"type"`
+	}
+}
+
+// swagger:parameters Autocomplete
+type autocompleteWrapper struct {
+	// The structure of the request for completing a prefix
+	// in: body
+	// required: true
+	Body struct {
+		// The api-key you use to access the api, autocomplete requests do not count against its hourly quota
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// What the user has typed so far, every word of it is completed as a prefix
+		//
+		// Required: true
+		// Example: friedrich nie
+		SearchString string `json:"searchString"`
+		// How many authors, topics and quotes to return at most, each
+		//
+		// Maximum: 10
+		// Minimum: 1
+		// Default: 10
+		// Example: 5
+		PageSize int `json:"pageSize"`
+		// The particular language that the authors, topics and quotes should be in
+		// example: English
+		Language string `json:"language"`
 	}
 }
 
@@ -302,9 +582,23 @@ type getSearchAuthorsByStringWrapper struct {
 		// Minimum: 0
 		// Example: 0
 		Page int `json:"page"`
-		// The particular language that the quote should be in
+		// The particular language that the quote should be in, the search string is then parsed with only that language's
+		// text search configuration. Icelandic quotes match without þ, ð, æ or accents, e.g. thad for það
 		// example: English
 		Language string `json:"language"`
+		// Whether to answer with an object with the results and, if the first page has fewer than 3 results, suggested
+		// corrections of the searchString, i.e. {"results": [...], "suggestions": [{"searchString": ..., "similarity": ...}]}
+		//
+		// Default: false
+		// Example: true
+		DidYouMean bool `json:"didYouMean"`
+		// Whether to answer with a page, i.e. {"items": [...], "page": 0, "pageSize": 25, "total": ..., "hasMore": true,
+		// "next": {...}}, where next is the request body for the next page. A total over 10000 is estimated and
+		// totalIsEstimate is then true
+		//
+		// Default: false
+		// Example: true
+		WithPagination bool `json:"withPagination"`
 	}
 }
 
@@ -351,6 +645,23 @@ type quotesFromTopicWrapper struct {
 		// Minimum: 0
 		// Example: 0
 		Page int `json:"page"`
+		// Whether to return the source of each quote, i.e. the work it is cited from and the status of its attribution
+		//
+		// Default: false
+		// Example: true
+		WithSource bool `json:"withSource"`
+		// Only return the quotes whose attribution to their source has been verified
+		//
+		// Default: false
+		// Example: true
+		Verified bool `json:"verified"`
+		// Whether to answer with a page, i.e. {"items": [...], "page": 0, "pageSize": 25, "total": ..., "hasMore": true,
+		// "next": {...}}, where next is the request body for the next page. A total over 10000 is estimated and
+		// totalIsEstimate is then true
+		//
+		// Default: false
+		// Example: true
+		WithPagination bool `json:"withPagination"`
 	}
 }
 
@@ -388,3 +699,765 @@ type LoginParameterWrapper struct {
 		Password string `json:"password"`
 	}
 }
+
+// swagger:parameters GetViewsStatus
+type viewsStatusWrapper struct {
+	// The structure of the request for getting when the materialized views were last refreshed
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+	}
+}
+
+// swagger:parameters RefreshViews
+type refreshViewsWrapper struct {
+	// The structure of the request for refreshing the materialized views
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The views to refresh, "searchview" and / or "topicsview". All of them are refreshed if left empty
+		//
+		// Example: ["searchview"]
+		Views []string `json:"views"`
+	}
+}
+
+// swagger:parameters ExportQuotes
+type exportWrapper struct {
+	// The structure of the request for exporting the quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be lilleBoy-tier or higher
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The format of the export, "ndjson" or "csv"
+		//
+		// Default: ndjson
+		// Example: csv
+		Format string `json:"format"`
+		// Only export the records of the given type, "author", "topic" or "quote". An NDJSON export has all of them if left
+		// empty, a CSV export the quotes
+		//
+		// Example: author
+		Type string `json:"type"`
+		// Only export quotes and topics in the given language ("english" or "icelandic"), all languages if left empty
+		//
+		// Example: icelandic
+		Language string `json:"language"`
+		// Export one row per quote and topic, i.e. the topic links, instead of one row per quote
+		//
+		// Example: true
+		WithTopics bool `json:"withTopics"`
+		// Only export the quotes in, and the topic with, the given id
+		//
+		// Example: 10
+		TopicId int `json:"topicId"`
+		// Only export the quotes in, and the topic with, the given name
+		//
+		// Example: inspirational
+		Topic string `json:"topic"`
+	}
+}
+
+// swagger:parameters DeleteItem RestoreItem
+type deleteItemWrapper struct {
+	// The structure of the request for deleting or restoring an author, quote or topic
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// What to delete / restore, "authors", "quotes" or "topics"
+		//
+		// Required: true
+		// Example: quotes
+		Type string `json:"type"`
+		// The id of the author, quote or topic
+		//
+		// Required: true
+		// Example: 582676
+		Id int `json:"id"`
+	}
+}
+
+// swagger:parameters ListDeleted
+type listDeletedWrapper struct {
+	// The structure of the request for listing the deleted authors, quotes or topics
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// What to list, "authors", "quotes" or "topics"
+		//
+		// Required: true
+		// Example: quotes
+		Type string `json:"type"`
+		// Response is paged. This parameter controls the number of items to be returned on each "page"
+		//
+		// Maximum: 200
+		// Minimum: 1
+		// Default: 25
+		// Example: 30
+		PageSize int `json:"pageSize"`
+		// Response is paged. This parameter controls the page you are asking for, starts with 0.
+		//
+		// Minimum: 0
+		// Example: 0
+		Page int `json:"page"`
+	}
+}
+
+// swagger:parameters CreateAuthor
+type createAuthorWrapper struct {
+	// The structure of the request for creating an author
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The name of the author
+		//
+		// Required: true
+		// Example: Muhammad Ali
+		Name string `json:"name"`
+	}
+}
+
+// swagger:parameters UpdateAuthor
+type updateAuthorWrapper struct {
+	// The structure of the request for updating an author
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the author
+		//
+		// Required: true
+		// Example: 24952
+		Id int `json:"id"`
+		// The new name of the author
+		//
+		// Required: true
+		// Example: Muhammad Ali
+		Name string `json:"name"`
+	}
+}
+
+// swagger:parameters CreateTopic
+type createTopicWrapper struct {
+	// The structure of the request for creating a topic
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The name of the topic
+		//
+		// Required: true
+		// Example: Wedding toasts
+		Name string `json:"name"`
+		// The language of the topic, english or icelandic. Once the topic has quotes it is icelandic if all its quotes are
+		//
+		// Default: english
+		// Example: english
+		Language string `json:"language"`
+	}
+}
+
+// swagger:parameters UpdateTopic
+type updateTopicWrapper struct {
+	// The structure of the request for renaming a topic
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the topic
+		//
+		// Required: true
+		// Example: 10
+		Id int `json:"id"`
+		// The new name of the topic
+		//
+		// Required: true
+		// Example: Wedding toasts
+		Name string `json:"name"`
+	}
+}
+
+// swagger:parameters DeleteTopic
+type deleteTopicWrapper struct {
+	// The structure of the request for deleting a topic
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the topic
+		//
+		// Required: true
+		// Example: 10
+		Id int `json:"id"`
+	}
+}
+
+// swagger:parameters AddQuotesToTopic RemoveQuotesFromTopic
+type topicQuotesWrapper struct {
+	// The structure of the request for tagging / untagging quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the topic
+		//
+		// Required: true
+		// Example: 10
+		Id int `json:"id"`
+		// The ids of the quotes
+		//
+		// Required: true
+		// Example: [582676,443976]
+		Ids []int `json:"ids"`
+	}
+}
+
+// swagger:parameters GetFavourites
+type getFavouritesWrapper struct {
+	// The structure of the request for your favourite quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The page you are asking for, starts with 0.
+		//
+		// Example: 0
+		Page int `json:"page"`
+		// The number of quotes to be returned on each "page"
+		//
+		// Maximum: 100
+		// Minimum: 1
+		// Default: 25
+		// Example: 30
+		PageSize int `json:"pageSize"`
+	}
+}
+
+// swagger:parameters AddFavourites RemoveFavourites
+type changeFavouritesWrapper struct {
+	// The structure of the request for adding / removing favourite quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The ids of the quotes
+		//
+		// Required: true
+		// Example: [582676,443976]
+		Ids []int `json:"ids"`
+	}
+}
+
+// swagger:parameters GetCollections
+type getCollectionsWrapper struct {
+	// The structure of the request for listing your collections
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+	}
+}
+
+// swagger:parameters GetCollection
+type getCollectionWrapper struct {
+	// The structure of the request for a collection, give either the id or the slug
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of your collection
+		//
+		// Example: 3
+		Id int `json:"id"`
+		// The slug of the collection, public collections of other users can be read with it
+		//
+		// Example: wedding-toasts-1d8db1d2
+		Slug string `json:"slug"`
+	}
+}
+
+// swagger:parameters CreateCollection UpdateCollection
+type saveCollectionWrapper struct {
+	// The structure of the request for creating / updating a collection
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the collection, only when updating
+		//
+		// Example: 3
+		Id int `json:"id"`
+		// The name of the collection, required when creating, the name is kept if left out when updating
+		//
+		// Example: Wedding toasts
+		Name string `json:"name"`
+		// Whether anyone can read the collection through its slug
+		//
+		// Default: false
+		// Example: true
+		Public bool `json:"public"`
+	}
+}
+
+// swagger:parameters DeleteCollection
+type deleteCollectionWrapper struct {
+	// The structure of the request for deleting a collection
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the collection
+		//
+		// Required: true
+		// Example: 3
+		Id int `json:"id"`
+	}
+}
+
+// swagger:parameters SetCollectionQuote
+type setCollectionQuoteWrapper struct {
+	// The structure of the request for adding a quote to a collection
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the collection
+		//
+		// Required: true
+		// Example: 3
+		Id int `json:"id"`
+		// The id of the quote
+		//
+		// Required: true
+		// Example: 582676
+		QuoteId int `json:"quoteId"`
+		// Your note on the quote
+		//
+		// Example: For the best man's speech
+		Note string `json:"note"`
+	}
+}
+
+// swagger:parameters RemoveCollectionQuotes ReorderCollection
+type collectionQuotesWrapper struct {
+	// The structure of the request for removing / reordering the quotes of a collection
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the collection
+		//
+		// Required: true
+		// Example: 3
+		Id int `json:"id"`
+		// The ids of the quotes, when reordering they are moved to the front in this order
+		//
+		// Required: true
+		// Example: [582676,443976]
+		Ids []int `json:"ids"`
+	}
+}
+
+// swagger:parameters GetSubmissionStatus
+type getSubmissionStatusWrapper struct {
+	// The structure of the request for the status of a submission
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the submission
+		//
+		// Required: true
+		// Example: 31
+		Id int `json:"id"`
+	}
+}
+
+// swagger:parameters ListSubmissions
+type listSubmissionsWrapper struct {
+	// The structure of the request for listing the submissions
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The status of the submissions, either pending, approved or rejected
+		//
+		// Default: pending
+		// Example: pending
+		Status string `json:"status"`
+		// The page you are asking for, starts with 0.
+		//
+		// Example: 0
+		Page int `json:"page"`
+		// The number of submissions to be returned on each "page"
+		//
+		// Maximum: 100
+		// Minimum: 1
+		// Default: 25
+		// Example: 30
+		PageSize int `json:"pageSize"`
+	}
+}
+
+// swagger:parameters ApproveSubmission
+type approveSubmissionWrapper struct {
+	// The structure of the request for approving a submission, the fields left out are kept as they were submitted
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the submission
+		//
+		// Required: true
+		// Example: 31
+		Id int `json:"id"`
+		// The corrected quote
+		//
+		// Example: Stay hungry. Stay foolish.
+		Quote string `json:"quote"`
+		// The id of the existing author of the quote
+		//
+		// Example: 24952
+		AuthorId int `json:"authorId"`
+		// The name of the author of the quote, created if needed
+		//
+		// Example: Steve Jobs
+		Author string `json:"author"`
+		// The language of the quote, either english or icelandic
+		//
+		// Example: english
+		Language string `json:"language"`
+		// The topics of the quote, replaces the submitted ones
+		//
+		// Example: ["Motivational"]
+		Topics []string `json:"topics"`
+	}
+}
+
+// swagger:parameters RejectSubmission
+type rejectSubmissionWrapper struct {
+	// The structure of the request for rejecting a submission
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the submission
+		//
+		// Required: true
+		// Example: 31
+		Id int `json:"id"`
+		// Why the submission is rejected
+		//
+		// Required: true
+		// Example: This quote is misattributed
+		Reason string `json:"reason"`
+	}
+}
+
+// swagger:parameters ListDuplicates
+type listDuplicatesWrapper struct {
+	// The structure of the request for listing the near duplicate quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The trigram similarity of the normalized quotes from which they count as near duplicates
+		//
+		// Maximum: 1
+		// Minimum: 0.5
+		// Default: 0.7
+		// Example: 0.8
+		Similarity float64 `json:"similarity"`
+		// The page you are asking for, starts with 0.
+		//
+		// Example: 0
+		Page int `json:"page"`
+		// The number of clusters to be returned on each "page"
+		//
+		// Maximum: 100
+		// Minimum: 1
+		// Default: 25
+		// Example: 30
+		PageSize int `json:"pageSize"`
+	}
+}
+
+// swagger:parameters MergeDuplicates
+type mergeDuplicatesWrapper struct {
+	// The structure of the request for merging near duplicate quotes
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the surviving quote
+		//
+		// Required: true
+		// Example: 582676
+		Id int `json:"id"`
+		// The ids of its duplicates, which are soft deleted
+		//
+		// Required: true
+		// Example: [582677,582690]
+		Ids []int `json:"ids"`
+	}
+}
+
+// swagger:parameters ListSources
+type listSourcesWrapper struct {
+	// The structure of the request for listing the sources
+	// in: body
+	Body struct {
+		// The api-key you use to access the api
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// Only return the sources whose title contains the searchString (case insensitive)
+		//
+		// Example: greatest
+		SearchString string `json:"searchString"`
+		// Only return the sources of the given type, one of book, speech, film, interview or other
+		//
+		// Example: book
+		Type string `json:"type"`
+		// The page you are asking for, starts with 0.
+		//
+		// Example: 0
+		Page int `json:"page"`
+		// The number of sources to be returned on each "page"
+		//
+		// Maximum: 200
+		// Minimum: 1
+		// Default: 25
+		// Example: 30
+		PageSize int `json:"pageSize"`
+	}
+}
+
+// swagger:parameters CreateSource UpdateSource
+type sourceWrapper struct {
+	// The structure of the request for creating / updating a source
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the source, only when updating a source
+		//
+		// Example: 3
+		Id int `json:"id"`
+		// The title of the work
+		//
+		// Required: true
+		// Example: The Greatest: My Own Story
+		Title string `json:"title"`
+		// The kind of work, one of book, speech, film, interview or other
+		//
+		// Default: other
+		// Example: book
+		Type string `json:"type"`
+		// The year the work was published / given
+		//
+		// Example: 1975
+		Year int `json:"year"`
+		// An absolute http(s) link to the work
+		//
+		// Example: https://en.wikipedia.org/wiki/The_Greatest:_My_Own_Story
+		Url string `json:"url"`
+	}
+}
+
+// swagger:parameters CiteQuote
+type citeQuoteWrapper struct {
+	// The structure of the request for citing the source of a quote
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the quote
+		//
+		// Required: true
+		// Example: 582676
+		QuoteId int `json:"quoteId"`
+		// The id of the source the quote is cited from, 0 to remove the quote's source
+		//
+		// Example: 3
+		SourceId int `json:"sourceId"`
+		// Whether the quote's attribution is unverified, verified or misattributed
+		//
+		// Default: unverified
+		// Example: verified
+		Status string `json:"status"`
+	}
+}
+
+// swagger:parameters UpdateAuthorProfile
+type updateAuthorProfileWrapper struct {
+	// The structure of the request for setting the profile of an author, the whole profile is replaced
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the author
+		//
+		// Required: true
+		// Example: 24952
+		Id int `json:"id"`
+		// When the author was born, ISO 8601 as precise as is known (negative years are BC)
+		//
+		// Example: 1942-01-17
+		BirthDate string `json:"birthDate"`
+		// When the author died, ISO 8601 as precise as is known (negative years are BC)
+		//
+		// Example: 2016-06-03
+		DeathDate string `json:"deathDate"`
+		// The author's nationality, an ISO 3166-1 alpha-2 country code
+		//
+		// Example: US
+		Nationality string `json:"nationality"`
+		// The author's professions
+		//
+		// Example: ["boxer","activist"]
+		Professions []string `json:"professions"`
+		// A short biography, at most 2000 characters
+		//
+		// Example: American professional boxer and activist, nicknamed "The Greatest".
+		Bio string `json:"bio"`
+		// Reference links about the author
+		Links []structs.AuthorLink `json:"links"`
+	}
+}
+
+// swagger:parameters AddAuthorAliases RemoveAuthorAliases
+type authorAliasesWrapper struct {
+	// The structure of the request for adding / removing aliases of an author
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the author
+		//
+		// Required: true
+		// Example: 24952
+		Id int `json:"id"`
+		// The other names or spellings of the author (case insensitive)
+		//
+		// Required: true
+		// Example: ["Cassius Clay"]
+		Aliases []string `json:"aliases"`
+	}
+}
+
+// swagger:parameters MergeAuthors
+type mergeAuthorsWrapper struct {
+	// The structure of the request for merging duplicate authors
+	// in: body
+	Body struct {
+		// The api-key you use to access the api, must be GOD-tier
+		//
+		// Required: true
+		// Example: 91fd6d19-2c32-4081-8729-4d9786d43b95
+		ApiKey string `json:"apiKey"`
+		// The id of the canonical author
+		//
+		// Required: true
+		// Example: 24952
+		Id int `json:"id"`
+		// The ids of the duplicate authors, which are soft deleted and whose names become aliases of the canonical author
+		//
+		// Required: true
+		// Example: [24953]
+		Ids []int `json:"ids"`
+	}
+}
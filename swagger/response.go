@@ -10,6 +10,46 @@ type authorsResponseWrapper struct {
 	Body []structs.AuthorAPIModel
 }
 
+// Data structure representing the response for a created / updated author
+// swagger:response authorResponse
+type authorResponseWrapper struct {
+	// The author
+	// in: body
+	Body structs.AuthorAPIModel
+}
+
+// Data structure representing the error response when an author with the name already exists
+// swagger:response authorExistsResponse
+type authorExistsResponseWrapper struct {
+	// The error response when the name is taken
+	// in: body
+	Body struct {
+		// The error message
+		// Example: An author with this name already exists
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
+// Data structure representing the error response when an alias is the name or alias of another author
+// swagger:response aliasTakenResponse
+type aliasTakenResponseWrapper struct {
+	// The error response when an alias is taken
+	// in: body
+	Body struct {
+		// The error message
+		// Example: Some of the aliases are the name or an alias of another author
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
 // Data structure representing the response for quotes
 // swagger:response searchViewsResponse
 type searchViewsResponseWrapper struct {
@@ -42,6 +82,18 @@ type topicViewsResponseWrapper struct {
 	Body []structs.TopicViewAPIModel
 }
 
+// Data structure representing the quotes a search found
+// swagger:response searchQuotesResponse
+type searchQuotesResponseWrapper struct {
+	// The strategy the first result was matched with, fulltext unless the searchString is only stop words, then
+	// phrase if the quote has the words of the searchString in a row and otherwise trigram
+	// in: header
+	XSearchStrategy string `json:"X-Search-Strategy"`
+	// The quotes found
+	// in: body
+	Body []structs.TopicViewAPIModel
+}
+
 // Data structure representing the response for the author of the day
 // swagger:response aodResponse
 type aodResponseWrapper struct {
@@ -74,6 +126,14 @@ type qodHistoryResponseWrapper struct {
 	Body []structs.QodViewAPIModel
 }
 
+// Data structure representing when the materialized views were last refreshed
+// swagger:response viewRefreshesResponse
+type viewRefreshesResponseWrapper struct {
+	// The refresh status of each view
+	// in: body
+	Body []structs.ViewRefreshAPIModel
+}
+
 // swagger:response successResponse
 type successResponseWrapper struct {
 	// The successful response to a successful setting of an asset
@@ -112,6 +172,77 @@ type internalServerErrorResponseWrapper struct {
 	}
 }
 
+// Data structure representing the response for a newly created quote
+// swagger:response quoteResponse
+type quoteResponseWrapper struct {
+	// The created quote
+	// in: body
+	Body structs.QuoteAPIModel
+}
+
+// Data structure representing the error response when the quote already exists
+// swagger:response quoteExistsResponse
+type quoteExistsResponseWrapper struct {
+	// The error response when the quote already exists
+	// in: body
+	Body struct {
+		// The error message
+		// Example: This quote already exists, it has the id 582676
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
+// Data structure representing the response for the deleted authors, quotes or topics
+// swagger:response deletedResponse
+type deletedResponseWrapper struct {
+	// The deleted items
+	// in: body
+	Body []structs.DeletedAPIModel
+}
+
+// Data structure representing the error response when the user's tier does not give access to the route
+// swagger:response unauthorizedResponse
+type unauthorizedResponseWrapper struct {
+	// The error response when the user's tier is not high enough
+	// in: body
+	Body struct {
+		// The error message
+		// Example: your tier free does not give access to this resource, you need at least the lilleBoy tier.
+		Message string `json:"message"`
+	}
+}
+
+// Data structure representing the error response when the apiKey has made too many requests to the route this minute
+// swagger:response tooManyRequestsResponse
+type tooManyRequestsResponseWrapper struct {
+	// How many seconds until the apiKey may make requests to the route again
+	// in: header
+	RetryAfter int `json:"Retry-After"`
+	// The error response when the apiKey has made too many requests
+	// in: body
+	Body struct {
+		// The error message
+		// Example: you have used all the requests per minute to this resource that your tier free allows for, i.e. 60 requests per minute. Try again in 12 seconds
+		Message string `json:"message"`
+	}
+}
+
+// Data structure representing the streamed export, one record per line in NDJSON or CSV. The author records
+// (ExportAuthorAPIModel) come first, then the topic records (ExportTopicAPIModel) and then the quote records
+// swagger:response exportResponse
+type exportResponseWrapper struct {
+	// The time of the snapshot the rows were read from
+	// in: header
+	XSnapshotTimestamp string `json:"X-Snapshot-Timestamp"`
+	// The exported rows
+	// in: body
+	Body []structs.ExportRowAPIModel
+}
+
 // Data structure representing the error response to a not found error
 // swagger:response notFoundResponse
 type notFoundResponseWrapper struct {
@@ -124,6 +255,30 @@ type notFoundResponseWrapper struct {
 	}
 }
 
+// Data structure representing the response for a created / changed topic
+// swagger:response topicResponse
+type topicResponseWrapper struct {
+	// The topic
+	// in: body
+	Body structs.TopicAPIModel
+}
+
+// Data structure representing the error response when a topic with the name already exists
+// swagger:response topicExistsResponse
+type topicExistsResponseWrapper struct {
+	// The error response when the name is taken
+	// in: body
+	Body struct {
+		// The error message
+		// Example: A topic with this name already exists
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
 // Data structure representing a list response for topics
 // swagger:response topicsResponse
 type topicsResponseWrapper struct {
@@ -163,3 +318,115 @@ type listOfStringsWrapper struct {
 		Languages []string `json:"languages"`
 	}
 }
+
+// Data structure representing the response for a collection
+// swagger:response collectionResponse
+type collectionResponseWrapper struct {
+	// The collection, with its quotes in order when asked for a single collection
+	// in: body
+	Body structs.CollectionAPIModel
+}
+
+// Data structure representing a list response for collections
+// swagger:response collectionsResponse
+type collectionsResponseWrapper struct {
+	// The user's collections, without their quotes
+	// in: body
+	Body []structs.CollectionAPIModel
+}
+
+// Data structure representing the error response when the user already has a collection with the name
+// swagger:response collectionExistsResponse
+type collectionExistsResponseWrapper struct {
+	// The error response when the name is taken
+	// in: body
+	Body struct {
+		// The error message
+		// Example: You already have a collection with this name
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
+// Data structure representing the response for a submitted quote
+// swagger:response submissionResponse
+type submissionResponseWrapper struct {
+	// The submission and its status
+	// in: body
+	Body structs.SubmissionAPIModel
+}
+
+// Data structure representing a list response for submitted quotes
+// swagger:response submissionsResponse
+type submissionsResponseWrapper struct {
+	// The submissions, oldest first
+	// in: body
+	Body []structs.SubmissionAPIModel
+}
+
+// Data structure representing the error response when the submission is no longer pending
+// swagger:response submissionReviewedResponse
+type submissionReviewedResponseWrapper struct {
+	// The error response when the submission has already been approved or rejected
+	// in: body
+	Body struct {
+		// The error message
+		// Example: This submission has already been reviewed
+		Message string `json:"message"`
+		// HTTP status code
+		//
+		// Example: 409
+		StatusCode int `json:"statusCode"`
+	}
+}
+
+// Data structure representing the response for a newly created quote, with the existing quotes that are very similar to it
+// swagger:response createdQuoteResponse
+type createdQuoteResponseWrapper struct {
+	// The created quote
+	// in: body
+	Body structs.CreatedQuoteAPIModel
+}
+
+// Data structure representing the clusters of near duplicate quotes
+// swagger:response duplicatesResponse
+type duplicatesResponseWrapper struct {
+	// The clusters, ordered by their lowest quote id
+	// in: body
+	Body []structs.DuplicateClusterAPIModel
+}
+
+// Data structure representing a source
+// swagger:response sourceResponse
+type sourceResponseWrapper struct {
+	// The source
+	// in: body
+	Body structs.SourceAPIModel
+}
+
+// Data structure representing a list of sources
+// swagger:response sourcesResponse
+type sourcesResponseWrapper struct {
+	// The sources, ordered by title
+	// in: body
+	Body []structs.SourceAPIModel
+}
+
+// Data structure representing the suggested corrections of a search string
+// swagger:response suggestionsResponse
+type suggestionsResponseWrapper struct {
+	// The corrected search strings, the most similar first
+	// in: body
+	Body []structs.SuggestionAPIModel
+}
+
+// Data structure representing what a prefix completes to
+// swagger:response autocompleteResponse
+type autocompleteResponseWrapper struct {
+	// The authors, topics and quote snippets, each the most popular first
+	// in: body
+	Body structs.AutocompleteAPIModel
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/Skjaldbaka17/quotes-api/handlers"
+	"github.com/Skjaldbaka17/quotes-api/repository"
 	"github.com/Skjaldbaka17/quotes-api/routes"
 	"github.com/Skjaldbaka17/quotes-api/structs"
 	"github.com/google/uuid"
@@ -17,29 +18,32 @@ import (
 
 type httpRequest func(http.ResponseWriter, *http.Request)
 
-var functions map[string]httpRequest = map[string]httpRequest{
-	"GetAuthorsById":        routes.GetAuthorsById,
-	"GetAuthorsList":        routes.GetAuthorsList,
-	"GetRandomAuthor":       routes.GetRandomAuthor,
-	"GetAuthorOfTheDay":     routes.GetAuthorOfTheDay,
-	"GetAODHistory":         routes.GetAODHistory,
-	"GetQuotes":             routes.GetQuotes,
-	"GetQuotesList":         routes.GetQuotesList,
-	"GetRandomQuote":        routes.GetRandomQuote,
-	"GetQuoteOfTheDay":      routes.GetQuoteOfTheDay,
-	"GetQODHistory":         routes.GetQODHistory,
-	"SearchByString":        routes.SearchByString,
-	"SearchAuthorsByString": routes.SearchAuthorsByString,
-	"SearchQuotesByString":  routes.SearchQuotesByString,
-	"GetTopics":             routes.GetTopics,
-	"GetTopic":              routes.GetTopic,
-}
-
-func deleteUser(id int) {
-	handlers.Db.Table("users").Delete(&structs.UserDBModel{Id: id})
+// protectedFunctions returns the routes that need an ApiKey
+func protectedFunctions(api *routes.Api) map[string]httpRequest {
+	return map[string]httpRequest{
+		"GetAuthorsById":        api.GetAuthorsById,
+		"GetAuthorsList":        api.GetAuthorsList,
+		"GetRandomAuthor":       api.GetRandomAuthor,
+		"GetAuthorOfTheDay":     api.GetAuthorOfTheDay,
+		"GetAODHistory":         api.GetAODHistory,
+		"GetQuotes":             api.GetQuotes,
+		"GetQuotesList":         api.GetQuotesList,
+		"GetRandomQuote":        api.GetRandomQuote,
+		"GetQuoteOfTheDay":      api.GetQuoteOfTheDay,
+		"GetQODHistory":         api.GetQODHistory,
+		"SearchByString":        api.SearchByString,
+		"SearchAuthorsByString": api.SearchAuthorsByString,
+		"SearchQuotesByString":  api.SearchQuotesByString,
+		"GetTopics":             api.GetTopics,
+		"GetTopic":              api.GetTopic,
+		"GetViewsStatus":        api.GetViewsStatus,
+	}
 }
 
 func TestUtils(t *testing.T) {
+	repos := repository.NewMemoryRepositories(repository.SeedFixtures())
+	api := routes.NewApi(repos)
+	functions := protectedFunctions(api)
 	t.Run("Get Request Body Test", func(t *testing.T) {
 		t.Run("Running all protected functions without ApiKey, should return error for all", func(t *testing.T) {
 			var jsonStr = []byte(`{}`)
@@ -63,36 +67,25 @@ func TestUtils(t *testing.T) {
 		t.Run("Running all protected functions with existant ApiKey but all requests-per hour for this user are used, should return error for all", func(t *testing.T) {
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			userResponse, _ := basicRequestReturnSingle(jsonStr, routes.CreateUser)
+			userResponse, _ := basicRequestReturnSingle(jsonStr, api.CreateUser)
 
 			if userResponse.ApiKey == "" {
 				t.Fatalf("Expected an Api key but got %s", userResponse.ApiKey)
 			}
 
-			defer deleteUser(userResponse.Id)
-			defer func() {
-				// Delete from request History
-				handlers.Db.Exec("delete from requesthistory where user_id = ?", userResponse.Id)
-			}()
-
-			var userStruct structs.UserDBModel
-			handlers.Db.Table("users").Where("api_key = ?", userResponse.ApiKey).First(&userStruct)
+			userStruct, _ := repos.Users.GetByApiKey(userResponse.ApiKey)
 
-			//Creating events to insert, use up all allowed requests for this apikey
-			requestEvents := []structs.RequestEvent{}
+			//Inserting events into the history, use up all allowed requests for this apikey
 			for i := 0.0; i < handlers.REQUESTS_PER_HOUR[handlers.TIERS[0]]; i++ {
-				requestEvents = append(requestEvents, structs.RequestEvent{
+				err := repos.RequestHistory.Create(&structs.RequestEvent{
 					UserId:      userStruct.Id,
 					ApiKey:      userResponse.ApiKey,
 					RequestBody: `{}`,
 					Route:       "/",
 				})
-			}
-
-			//Inserting the created events into the history
-			err := handlers.Db.Table("requesthistory").Create(&requestEvents).Error
-			if err != nil {
-				t.Fatalf("Failed inserting the request events into the table, %s", err)
+				if err != nil {
+					t.Fatalf("Failed inserting the request events into the table, %s", err)
+				}
 			}
 
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s"}`, userResponse.ApiKey))
@@ -110,18 +103,17 @@ func TestUtils(t *testing.T) {
 		t.Run("Should not be able to setQOD nor setAOD", func(t *testing.T) {
 			user := getBasicUser()
 			var jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "passwordConfirmation":"%s", "email":"%s"}`, user.Name, user.Password, user.PasswordConfirmation, user.Email))
-			userResponse, _ := basicRequestReturnSingle(jsonStr, routes.CreateUser)
-			defer deleteUser(userResponse.Id)
+			userResponse, _ := basicRequestReturnSingle(jsonStr, api.CreateUser)
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "aods": [{"id":%d, "date":""}]}`, userResponse.ApiKey, 1))
 
-			response := basicRequestReturnResponse(jsonStr, routes.SetAuthorOfTheDay)
+			response := basicRequestReturnResponse(jsonStr, api.SetAuthorOfTheDay)
 			if response.Result().StatusCode != http.StatusUnauthorized {
 				t.Fatalf("Expected to be declined, unauthorized user trying to setAOD, and receive status code %d, but got %d ", http.StatusUnauthorized, response.Result().StatusCode)
 			}
 
 			jsonStr = []byte(fmt.Sprintf(`{"apiKey":"%s", "qods": [{"id":%d, "date":""}]}`, userResponse.ApiKey, 1))
 
-			response = basicRequestReturnResponse(jsonStr, routes.SetQuoteOfTheDay)
+			response = basicRequestReturnResponse(jsonStr, api.SetQuoteOfTheDay)
 			if response.Result().StatusCode != http.StatusUnauthorized {
 				t.Fatalf("Expected to be declined, unauthorized user trying to setQOD, and receive status code %d, but got %d ", http.StatusUnauthorized, response.Result().StatusCode)
 			}